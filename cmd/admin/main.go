@@ -0,0 +1,47 @@
+// Package main entry point for the admin commands
+//
+//	admin replay [-mode truncate|shadow] [-restart] [projection...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"techunicorn.com/udc-core/prototodo/pkg/app/admin"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "replay":
+		fs := flag.NewFlagSet("replay", flag.ExitOnError)
+		mode := fs.String(
+			"mode",
+			"shadow",
+			"truncate to rebuild in place, shadow to rebuild into a copy and swap",
+		)
+		restart := fs.Bool(
+			"restart",
+			false,
+			"start over instead of resuming an unfinished replay",
+		)
+		fs.Parse(os.Args[2:])
+		if err := admin.Replay(fs.Args(), *mode, *restart); err != nil {
+			os.Exit(1)
+		}
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(
+		os.Stderr,
+		"usage: admin replay [-mode truncate|shadow] [-restart] [projection...]",
+	)
+	os.Exit(2)
+}
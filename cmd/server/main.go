@@ -1,8 +1,18 @@
 // Package main entry point for the server application
 package main
 
-import "techunicorn.com/udc-core/prototodo/pkg/app/server"
+import (
+	"flag"
+
+	"techunicorn.com/udc-core/prototodo/pkg/app/server"
+)
 
 func main() {
-	server.Start("")
+	impl := flag.String(
+		"impl",
+		"",
+		"implementation of the domain layer to use, inmem, sqlite or empty for evcqrs",
+	)
+	flag.Parse()
+	server.Start(*impl)
 }
@@ -7,10 +7,12 @@ require (
 	github.com/BetaLixT/tsqlx v0.2.0
 	github.com/betalixt/gorr v0.2.1
 	github.com/bwmarrin/snowflake v0.3.0
+	github.com/go-redis/redis/v8 v8.11.5
 	github.com/golang/protobuf v1.5.2
 	github.com/jmoiron/sqlx v1.3.5
 	github.com/lib/pq v1.10.7
 	github.com/mailru/easyjson v0.7.7
+	github.com/mattn/go-sqlite3 v1.14.16
 	go.uber.org/zap v1.24.0
 	google.golang.org/genproto v0.0.0-20230221151758-ace64dc21148
 	google.golang.org/protobuf v1.28.1
@@ -45,7 +47,6 @@ require (
 	github.com/go-playground/locales v0.14.0 // indirect
 	github.com/go-playground/universal-translator v0.18.0 // indirect
 	github.com/go-playground/validator/v10 v10.11.1 // indirect
-	github.com/go-redis/redis/v8 v8.11.5 // indirect
 	github.com/goccy/go-json v0.9.11 // indirect
 	github.com/gocql/gocql v1.3.1 // indirect
 	github.com/gofrs/uuid v4.2.0+incompatible // indirect
@@ -0,0 +1,118 @@
+// Package admin contains administrative routines that are run as one off
+// commands against the service's infrastructure
+package admin
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/replay"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+
+	"github.com/BetaLixT/tsqlx"
+	"github.com/google/wire"
+	"go.uber.org/zap"
+)
+
+// Replay rebuilds the given read models (all of them if none are provided)
+// from the event store
+func Replay(projections []string, mode string, restart bool) error {
+	a, err := initializeAppCQRS()
+	if err != nil {
+		return err
+	}
+	defer a.lgrf.Close()
+
+	ctx, cancel := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+	defer cancel()
+	return a.replay(ctx, projections, replay.Mode(mode), restart)
+}
+
+// cqrsDependencySet dependency set with the event source CQRS implementation
+var cqrsDependencySet = wire.NewSet(
+	evcqrs.DependencySet,
+	newApp,
+)
+
+// =============================================================================
+// Application
+// =============================================================================
+
+type app struct {
+	dbctx *tsqlx.TracedDB
+	rplyr *replay.Replayer
+	lgrf  *lgr.LoggerFactory
+	lgr   *zap.Logger
+}
+
+func newApp(
+	dbctx *tsqlx.TracedDB,
+	rplyr *replay.Replayer,
+	lgrf *lgr.LoggerFactory,
+) *app {
+	return &app{
+		dbctx: dbctx,
+		rplyr: rplyr,
+		lgrf:  lgrf,
+		lgr:   lgrf.Create(context.Background()),
+	}
+}
+
+func (a *app) replay(
+	ctx context.Context,
+	projections []string,
+	mode replay.Mode,
+	restart bool,
+) error {
+	err := psqldb.RunMigrations(
+		ctx,
+		a.lgr,
+		a.dbctx,
+		entities.GetMigrationScripts(),
+	)
+	if err != nil {
+		a.lgr.Error("failed to run migration", zap.Error(err))
+		return err
+	}
+
+	if len(projections) == 0 {
+		projections = a.rplyr.Projections()
+	}
+	for _, prj := range projections {
+		err = a.rplyr.Replay(ctx, prj, mode, restart, a.reportProgress)
+		if err != nil {
+			a.lgr.Error(
+				"replay failed, rerun to resume",
+				zap.String("projection", prj),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *app) reportProgress(prgs replay.Progress) {
+	pct := 100.0
+	if prgs.EventsTotal != 0 {
+		pct = float64(prgs.EventsApplied) / float64(prgs.EventsTotal) * 100
+	}
+	a.lgr.Info(
+		"replay progress",
+		zap.String("projection", prgs.Projection),
+		zap.Uint64("lastEventId", prgs.LastEventID),
+		zap.Uint64("eventsApplied", prgs.EventsApplied),
+		zap.Uint64("eventsTotal", prgs.EventsTotal),
+		zap.Float64("percent", pct),
+		zap.Bool("completed", prgs.Completed),
+	)
+}
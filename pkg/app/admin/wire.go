@@ -0,0 +1,14 @@
+//go:build wireinject
+// +build wireinject
+
+// The build tag makes sure the stub is not built in the final build.
+package admin
+
+import (
+	"github.com/google/wire"
+)
+
+func initializeAppCQRS() (*app, error) {
+	wire.Build(cqrsDependencySet)
+	return &app{}, nil
+}
@@ -0,0 +1,62 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package admin
+
+import (
+	"techunicorn.com/udc-core/prototodo/pkg/infra/config"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/replay"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/appinsights"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/jaeger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/promex"
+)
+
+// Injectors from wire.go:
+
+func initializeAppCQRS() (*app, error) {
+	loggerFactory, err := lgr.NewLoggerFactory()
+	if err != nil {
+		return nil, err
+	}
+	initializer := config.NewInitializer(loggerFactory)
+	exporterOptions := config.NewAppInsightsExporterOptions(initializer)
+	traceExporter, err := appinsights.NewTraceExporter(exporterOptions)
+	if err != nil {
+		return nil, err
+	}
+	jaegerExporterOptions := config.NewJaegerExporterOptions(initializer)
+	jaegerTraceExporter, err := jaeger.NewJaegerTraceExporter(jaegerExporterOptions)
+	if err != nil {
+		return nil, err
+	}
+	promexTraceExporter, err := promex.NewTraceExporter()
+	if err != nil {
+		return nil, err
+	}
+	exporterList := evcqrs.NewTraceExporterList(traceExporter, jaegerTraceExporter, promexTraceExporter, loggerFactory)
+	options, err := config.NewTraceOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	tracer, err := trace.NewTracer(exporterList, options, loggerFactory)
+	if err != nil {
+		return nil, err
+	}
+	databaseOptions := config.NewPSQLDBOptions(initializer)
+	tracedDB, err := psqldb.NewDatabaseContext(tracer, databaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	replayOptions := config.NewReplayOptions(initializer)
+	v := replay.NewProjectionList()
+	replayer := replay.NewReplayer(tracedDB, loggerFactory, replayOptions, v)
+	adminApp := newApp(tracedDB, replayer, loggerFactory)
+	return adminApp, nil
+}
@@ -13,7 +13,9 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/impl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/trace"
+	domcontracts "techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evsqlite"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem"
 	"sync"
 	"syscall"
@@ -35,6 +37,11 @@ func Start(impl string) {
 		if err != nil {
 			panic(err)
 		}
+	case "sqlite":
+		a, err = initializeAppSQLite()
+		if err != nil {
+			panic(err)
+		}
 	default:
 		a, err = initializeAppCQRS()
 		if err != nil {
@@ -59,8 +66,16 @@ var inMemDependencySet = wire.NewSet(
 	dependencySet,
 )
 
+// sqliteDependencySet dependency set with the SQLite CQRS implementation
+var sqliteDependencySet = wire.NewSet(
+	evsqlite.DependencySet,
+	domain.DependencySet,
+	dependencySet,
+)
+
 var dependencySet = wire.NewSet(
 	newApp,
+	newTaskScheduler,
 	handlers.NewQuotesHandler,
 	wire.Bind(
 		new(contracts.QuotesHTTPServer),
@@ -71,6 +86,16 @@ var dependencySet = wire.NewSet(
 		new(*handlers.QuotesHandler),
 	),
 
+	handlers.NewSagasHandler,
+	wire.Bind(
+		new(contracts.SagasHTTPServer),
+		new(*handlers.SagasHandler),
+	),
+	wire.Bind(
+		new(contracts.SagasServer),
+		new(*handlers.SagasHandler),
+	),
+
 	handlers.NewTasksHandler,
 	wire.Bind(
 		new(contracts.TasksHTTPServer),
@@ -80,8 +105,22 @@ var dependencySet = wire.NewSet(
 		new(contracts.TasksServer),
 		new(*handlers.TasksHandler),
 	),
+	wire.Bind(
+		new(taskEventStreamer),
+		new(*handlers.TasksHandler),
+	),
 )
 
+// taskEventStreamer streams task events, used by the http event stream since
+// streaming methods aren't part of the generated http server
+type taskEventStreamer interface {
+	StreamEvents(
+		ctx context.Context,
+		qry *domcontracts.SubscribeEventsQuery,
+		send func(*domcontracts.TaskEvent) error,
+	) error
+}
+
 // =============================================================================
 // Application
 // =============================================================================
@@ -92,10 +131,17 @@ type app struct {
 	// http handler interfaces
 	tasksHTTPHandler  contracts.TasksHTTPServer
 	quotesHTTPHandler contracts.QuotesHTTPServer
+	sagasHTTPHandler  contracts.SagasHTTPServer
 
 	// grpc handler interfaces
 	tasksGRPCHandler  contracts.TasksServer
 	quotesGRPCHandler contracts.QuotesServer
+	sagasGRPCHandler  contracts.SagasServer
+
+	// stream handler interfaces
+	tasksEventStreamer taskEventStreamer
+
+	tsch *taskScheduler
 
 	impl impl.IImplementation
 	lgrf logger.IFactory
@@ -113,8 +159,12 @@ type app struct {
 func newApp(
 	tasksHTTPHandler contracts.TasksHTTPServer,
 	quotesHTTPHandler contracts.QuotesHTTPServer,
+	sagasHTTPHandler contracts.SagasHTTPServer,
 	tasksGRPCHandler contracts.TasksServer,
 	quotesGRPCHandler contracts.QuotesServer,
+	sagasGRPCHandler contracts.SagasServer,
+	tasksEventStreamer taskEventStreamer,
+	tsch *taskScheduler,
 	impl impl.IImplementation,
 	lgrf logger.IFactory,
 	ctxf cntxt.IFactory,
@@ -124,10 +174,17 @@ func newApp(
 		// http handler interfaces
 		tasksHTTPHandler:  tasksHTTPHandler,
 		quotesHTTPHandler: quotesHTTPHandler,
+		sagasHTTPHandler:  sagasHTTPHandler,
 
 		// grpc handler interfaces
 		tasksGRPCHandler:  tasksGRPCHandler,
 		quotesGRPCHandler: quotesGRPCHandler,
+		sagasGRPCHandler:  sagasGRPCHandler,
+
+		// stream handler interfaces
+		tasksEventStreamer: tasksEventStreamer,
+
+		tsch: tsch,
 
 		impl: impl,
 		lgrf: lgrf,
@@ -142,11 +199,14 @@ func newApp(
 func (a *app) registerGRPCHandlers(s *grpc.Server) {
 	contracts.RegisterTasksServer(s, a.tasksGRPCHandler)
 	contracts.RegisterQuotesServer(s, a.quotesGRPCHandler)
+	contracts.RegisterSagasServer(s, a.sagasGRPCHandler)
 }
 
 func (a *app) registerHTTPHandlers(g *gin.RouterGroup) {
 	contracts.RegisterTasksHTTPServer(g, a.tasksHTTPHandler)
 	contracts.RegisterQuotesHTTPServer(g, a.quotesHTTPHandler)
+	contracts.RegisterSagasHTTPServer(g, a.sagasHTTPHandler)
+	g.GET("/streams/taskEvents", a.streamTaskEvents)
 }
 
 func (a *app) start(ctx context.Context) {
@@ -155,6 +215,7 @@ func (a *app) start(ctx context.Context) {
 		a.lgr.Error("failed to start implementation", zap.Error(err))
 		panic(err)
 	}
+	a.tsch.Start(ctx)
 
 	wg := sync.WaitGroup{}
 	wg.Add(2)
@@ -187,6 +248,7 @@ func (a *app) start(ctx context.Context) {
 	a.closeServers()
 
 	wg.Wait()
+	a.tsch.Stop(ctx)
 	a.impl.Stop(ctx)
 	a.lgr.Info("Server exiting")
 }
@@ -54,6 +54,10 @@ func (a *app) startGRPC(portStr string) {
 			}
 
 			ctx := a.ctxf.Create(traceparent)
+			temp = md[common.IdempotencyKeyMetadata]
+			if len(temp) > 0 {
+				ctx.SetValue(common.IdempotencyKeyContextKey, temp[0])
+			}
 			resp, err = handler(ctx, req)
 			end := time.Now()
 			status := 200
@@ -80,6 +84,70 @@ func (a *app) startGRPC(portStr string) {
 			)
 			return
 		}),
+		grpc.StreamInterceptor(func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) (err error) {
+			start := time.Now()
+			c := ss.Context()
+
+			agent := ""
+			p, _ := peer.FromContext(c)
+			ip := p.Addr.String()
+
+			md, ok := metadata.FromIncomingContext(c)
+			if !ok {
+				return fmt.Errorf("empty context")
+			}
+
+			temp := md["traceparent"]
+			traceparent := ""
+			if len(temp) > 0 {
+				traceparent = temp[0]
+			}
+			temp = md["user-agent"]
+			if len(temp) > 0 {
+				agent = temp[0]
+			}
+
+			// the internal context isn't derived from the stream's context, so
+			// it's canceled when the client goes away
+			ctx := a.ctxf.Create(traceparent)
+			go func() {
+				select {
+				case <-c.Done():
+					ctx.Cancel()
+				case <-ctx.Done():
+				}
+			}()
+			err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+			end := time.Now()
+			status := 200
+			if err != nil {
+				if err, ok := err.(*gorr.Error); ok {
+					status = err.StatusCode
+				} else {
+					status = 500
+				}
+			}
+
+			a.traceRequest(
+				c,
+				common.GRPCLable,
+				info.FullMethod,
+				"",
+				agent,
+				ip,
+				status,
+				0,
+				start,
+				end,
+				common.GRPCLable,
+			)
+			return
+		}),
 	)
 
 	_, err := os.Stat(common.CertKeyLocation)
@@ -129,6 +197,16 @@ func (a *app) startGRPC(portStr string) {
 	}
 }
 
+// serverStream grpc server stream that provides the internal context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
 func loadTLSCredentials() (credentials.TransportCredentials, error) {
 	// Load server's certificate and private key
 	serverCert, err := tls.LoadX509KeyPair(common.CertPEMLocation, common.CertKeyLocation)
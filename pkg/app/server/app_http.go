@@ -2,10 +2,14 @@ package server
 
 import (
 	"embed"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/pprof"
 	"techunicorn.com/udc-core/prototodo/pkg/app/server/common"
 	"techunicorn.com/udc-core/prototodo/pkg/app/server/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	domcontracts "techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
 	"strconv"
 	"time"
 
@@ -13,6 +17,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 //go:embed static/*
@@ -95,6 +100,9 @@ func (a *app) startHTTP(portStr string) {
 
 		traceparent := ctx.GetHeader("traceparent")
 		c := a.ctxf.Create(traceparent)
+		if key := ctx.GetHeader(common.IdempotencyKeyHeader); key != "" {
+			c.SetValue(common.IdempotencyKeyContextKey, key)
+		}
 		ctx.Set(contracts.InternalContextKey, c)
 		ctx.Next()
 
@@ -162,3 +170,90 @@ func (a *app) startHTTP(portStr string) {
 		)
 	}
 }
+
+// streamTaskEvents streams task events as server sent events, the user context
+// is provided through the query parameters as event sources can't send a body
+func (a *app) streamTaskEvents(ctx *gin.Context) {
+	v, _ := ctx.Get(contracts.InternalContextKey)
+	c, ok := v.(cntxt.IContext)
+	if !ok {
+		ctx.Error(common.NewInvalidContextProvidedToHandlerError())
+		return
+	}
+
+	qry := &domcontracts.SubscribeEventsQuery{
+		UserContext: &domcontracts.UserContext{
+			UserType: ctx.Query("userType"),
+			Id:       ctx.Query("userId"),
+		},
+	}
+	if qry.UserContext.UserType == "" || qry.UserContext.Id == "" {
+		ctx.Error(common.NewUserContextMissingError())
+		return
+	}
+	if from := ctx.Query("fromEventId"); from != "" {
+		id, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			ctx.Error(common.NewInvalidQueryParameterError("fromEventId"))
+			return
+		}
+		qry.FromEventId = id
+	}
+	// reconnecting event sources resume after the last event they received
+	if last := ctx.GetHeader("Last-Event-ID"); last != "" {
+		id, err := strconv.ParseUint(last, 10, 64)
+		if err != nil {
+			ctx.Error(common.NewInvalidQueryParameterError("Last-Event-ID"))
+			return
+		}
+		qry.FromEventId = id + 1
+	}
+
+	go func() {
+		select {
+		case <-ctx.Request.Context().Done():
+			c.Cancel()
+		case <-c.Done():
+		}
+	}()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Status(200)
+	ctx.Writer.Flush()
+
+	marsh := protojson.MarshalOptions{EmitUnpopulated: true}
+	err := a.tasksEventStreamer.StreamEvents(
+		c,
+		qry,
+		func(evnt *domcontracts.TaskEvent) error {
+			raw, err := marsh.Marshal(evnt)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(
+				ctx.Writer,
+				"id: %d\nevent: %s\ndata: %s\n\n",
+				evnt.Id,
+				evnt.Event,
+				raw,
+			)
+			if err != nil {
+				return err
+			}
+			ctx.Writer.Flush()
+			return nil
+		},
+	)
+	// the response has already started so errors are sent as an event
+	if err != nil {
+		gerr, ok := err.(*gorr.Error)
+		if !ok {
+			gerr = gorr.NewUnexpectedError(err)
+		}
+		raw, _ := json.Marshal(gerr)
+		fmt.Fprintf(ctx.Writer, "event: error\ndata: %s\n\n", raw)
+		ctx.Writer.Flush()
+	}
+}
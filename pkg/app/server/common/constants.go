@@ -9,3 +9,16 @@ const (
 	CertKeyLocation = "cert/server.key"
 	CertPEMLocation = "cert/server.pem"
 )
+
+// Idempotency keys can be passed with the header (http) or metadata (grpc)
+// instead of the command field, the key is carried to the handlers through the
+// context
+const (
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotencyKeyMetadata = "idempotency-key"
+)
+
+type contextKey string
+
+// IdempotencyKeyContextKey key of the idempotency key in the context
+const IdempotencyKeyContextKey contextKey = "idempotencyKey"
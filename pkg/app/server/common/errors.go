@@ -13,6 +13,9 @@ const (
 
 	UserContextMissingErrorCode    = 1_99_001
 	UserContextMissingErrorMessage = "UserContextMissingError"
+
+	InvalidQueryParameterErrorCode    = 1_99_002
+	InvalidQueryParameterErrorMessage = "InvalidQueryParameterError"
 )
 
 // NewInvalidContextProvidedToHandlerError creates new error
@@ -38,3 +41,15 @@ func NewUserContextMissingError() *gorr.Error {
 		"",
 	)
 }
+
+// NewInvalidQueryParameterError creates a new invalid query parameter error
+func NewInvalidQueryParameterError(param string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidQueryParameterErrorCode,
+			Message: InvalidQueryParameterErrorMessage,
+		},
+		400,
+		param,
+	)
+}
@@ -21,12 +21,36 @@ type TasksHTTPServer interface {
 	Create(context.Context, *contracts.CreateTaskCommand) (*contracts.TaskEvent, error)
 	Delete(context.Context, *contracts.DeleteTaskCommand) (*contracts.TaskEvent, error)
 	Update(context.Context, *contracts.UpdateTaskCommand) (*contracts.TaskEvent, error)
-	// Update existing task state to progress
+	// Update existing task state to progress, the event written is
+	// "progressed" (it used to be "updated")
 	Progress(context.Context, *contracts.ProgressTaskCommand) (*contracts.TaskEvent, error)
-	// Update existing task to complete
+	// Update existing task to complete, the event written is "completed" (it
+	// used to be "updated")
 	Complete(context.Context, *contracts.CompleteTaskCommand) (*contracts.TaskEvent, error)
+	// Move an existing task to another status of the workflow
+	Transition(context.Context, *contracts.TransitionTaskCommand) (*contracts.TaskEvent, error)
+	// Assign an existing task to a user
+	Assign(context.Context, *contracts.AssignTaskCommand) (*contracts.TaskEvent, error)
+	// Move an existing task under another task
+	Move(context.Context, *contracts.MoveTaskCommand) (*contracts.TaskEvent, error)
+	// Block an existing task by another task
+	AddDependency(context.Context, *contracts.AddTaskDependencyCommand) (*contracts.TaskEvent, error)
+	// Unblock an existing task from another task
+	RemoveDependency(context.Context, *contracts.RemoveTaskDependencyCommand) (*contracts.TaskEvent, error)
+	// Grant a user access to an existing task
+	Share(context.Context, *contracts.ShareTaskCommand) (*contracts.TaskEvent, error)
+	// Revoke the access of a user to an existing task
+	Unshare(context.Context, *contracts.UnshareTaskCommand) (*contracts.TaskEvent, error)
+	// Get an existing task
+	Get(context.Context, *contracts.GetTaskQuery) (*contracts.TaskEntity, error)
 	// Query for existing tasks
 	ListQuery(context.Context, *contracts.ListTasksQuery) (*contracts.TaskEntityList, error)
+	// Query for the event history of a task
+	HistoryQuery(context.Context, *contracts.GetTaskHistoryQuery) (*contracts.TaskEventList, error)
+	// Query for a task as it was at a version or point in time
+	AsOfQuery(context.Context, *contracts.GetTaskAsOfQuery) (*contracts.TaskEntity, error)
+	// Query for the dependency graph of a task
+	DependenciesQuery(context.Context, *contracts.GetTaskDependenciesQuery) (*contracts.TaskDependencyGraph, error)
 }
 type tasks struct {
 	app TasksHTTPServer
@@ -146,7 +170,7 @@ func (p *tasks) update(ctx *gin.Context) {
 	}
 }
 
-// update state of existing task to progress
+// update state of existing task to progress, writes a progressed event (formerly an updated event)
 func (p *tasks) progress(ctx *gin.Context) {
 	body := contracts.ProgressTaskCommand{}
 	raw, err := ioutil.ReadAll(ctx.Request.Body)
@@ -184,7 +208,7 @@ func (p *tasks) progress(ctx *gin.Context) {
 	}
 }
 
-// update state of existing task to complete
+// update state of existing task to complete, writes a completed event (formerly an updated event)
 func (p *tasks) complete(ctx *gin.Context) {
 	body := contracts.CompleteTaskCommand{}
 	raw, err := ioutil.ReadAll(ctx.Request.Body)
@@ -222,6 +246,310 @@ func (p *tasks) complete(ctx *gin.Context) {
 	}
 }
 
+// move an existing task to a status the workflow allows moving to from it's current status
+func (p *tasks) transition(ctx *gin.Context) {
+	body := contracts.TransitionTaskCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Transition(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// assign an existing task to a user, granting them access to it
+func (p *tasks) assign(ctx *gin.Context) {
+	body := contracts.AssignTaskCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Assign(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// move an existing task under another task or to the top level
+func (p *tasks) move(ctx *gin.Context) {
+	body := contracts.MoveTaskCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Move(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// block an existing task until another task is completed
+func (p *tasks) addDependency(ctx *gin.Context) {
+	body := contracts.AddTaskDependencyCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.AddDependency(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// remove a task from the tasks blocking an existing task
+func (p *tasks) removeDependency(ctx *gin.Context) {
+	body := contracts.RemoveTaskDependencyCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.RemoveDependency(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// grant a user or application access to an existing task
+func (p *tasks) share(ctx *gin.Context) {
+	body := contracts.ShareTaskCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Share(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// revoke the access of a user or application to an existing task
+func (p *tasks) unshare(ctx *gin.Context) {
+	body := contracts.UnshareTaskCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Unshare(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// get an existing task
+func (p *tasks) get(ctx *gin.Context) {
+	body := contracts.GetTaskQuery{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Get(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
 // query all existing tasks
 func (p *tasks) listQuery(ctx *gin.Context) {
 	body := contracts.ListTasksQuery{}
@@ -259,6 +587,120 @@ func (p *tasks) listQuery(ctx *gin.Context) {
 		return
 	}
 }
+
+// query the ordered event history of an existing task
+func (p *tasks) historyQuery(ctx *gin.Context) {
+	body := contracts.GetTaskHistoryQuery{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.HistoryQuery(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// query a task as it was at a given version or event time
+func (p *tasks) asOfQuery(ctx *gin.Context) {
+	body := contracts.GetTaskAsOfQuery{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.AsOfQuery(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// query the tasks blocking an existing task and the tasks it blocks
+func (p *tasks) dependenciesQuery(ctx *gin.Context) {
+	body := contracts.GetTaskDependenciesQuery{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.DependenciesQuery(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
 func RegisterTasksHTTPServer(
 	grp *gin.RouterGroup,
 	srv TasksHTTPServer,
@@ -269,7 +711,18 @@ func RegisterTasksHTTPServer(
 	grp.POST("/commands/updateTask", ctrl.update)
 	grp.POST("/commands/progressTask", ctrl.progress)
 	grp.POST("/commands/completeTask", ctrl.complete)
+	grp.POST("/commands/transitionTask", ctrl.transition)
+	grp.POST("/commands/assignTask", ctrl.assign)
+	grp.POST("/commands/moveTask", ctrl.move)
+	grp.POST("/commands/addTaskDependency", ctrl.addDependency)
+	grp.POST("/commands/removeTaskDependency", ctrl.removeDependency)
+	grp.POST("/commands/shareTask", ctrl.share)
+	grp.POST("/commands/unshareTask", ctrl.unshare)
+	grp.POST("/queries/getTask", ctrl.get)
 	grp.POST("/queries/listTasks", ctrl.listQuery)
+	grp.POST("/queries/getTaskHistory", ctrl.historyQuery)
+	grp.POST("/queries/getTaskAsOf", ctrl.asOfQuery)
+	grp.POST("/queries/getTaskDependencies", ctrl.dependenciesQuery)
 }
 
 // Quotes
@@ -365,3 +818,98 @@ func RegisterQuotesHTTPServer(
 	grp.POST("/queries/getQuote", ctrl.get)
 	grp.POST("/commands/createQuote", ctrl.create)
 }
+
+// Sagas
+type SagasHTTPServer interface {
+	// Undo everything written under a saga
+	Compensate(context.Context, *contracts.CompensateSagaCommand) (*contracts.SagaEntity, error)
+	// Get the progress of a saga
+	Get(context.Context, *contracts.GetSagaQuery) (*contracts.SagaEntity, error)
+}
+type sagas struct {
+	app SagasHTTPServer
+}
+
+// compensates the events and constraints written under a saga
+func (p *sagas) compensate(ctx *gin.Context) {
+	body := contracts.CompensateSagaCommand{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Compensate(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+
+// get the state of a saga
+func (p *sagas) get(ctx *gin.Context) {
+	body := contracts.GetSagaQuery{}
+	raw, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	protojson.Unmarshal(raw, &body)
+	var c context.Context
+	if v, ok := ctx.Get(InternalContextKey); ok {
+		c, _ = v.(context.Context)
+	}
+	if c == nil {
+		c = ctx
+	}
+	res, err := p.app.Get(
+		c,
+		&body,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	resraw, err := protomarsh.Marshal(res)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(200)
+	ctx.Header("Content-Type", "application/json")
+	_, err = ctx.Writer.Write(resraw)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+}
+func RegisterSagasHTTPServer(
+	grp *gin.RouterGroup,
+	srv SagasHTTPServer,
+) {
+	ctrl := sagas{app: srv}
+	grp.POST("/commands/compensateSaga", ctrl.compensate)
+	grp.POST("/queries/getSaga", ctrl.get)
+}
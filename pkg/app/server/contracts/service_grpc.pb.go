@@ -27,12 +27,38 @@ type TasksClient interface {
 	Create(ctx context.Context, in *contracts.CreateTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
 	Delete(ctx context.Context, in *contracts.DeleteTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
 	Update(ctx context.Context, in *contracts.UpdateTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
-	// Update existing task state to progress
+	// Update existing task state to progress, the event written is
+	// "progressed" (it used to be "updated")
 	Progress(ctx context.Context, in *contracts.ProgressTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
-	// Update existing task to complete
+	// Update existing task to complete, the event written is "completed" (it
+	// used to be "updated")
 	Complete(ctx context.Context, in *contracts.CompleteTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Move an existing task to another status of the workflow
+	Transition(ctx context.Context, in *contracts.TransitionTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Assign an existing task to a user
+	Assign(ctx context.Context, in *contracts.AssignTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Move an existing task under another task
+	Move(ctx context.Context, in *contracts.MoveTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Block an existing task by another task
+	AddDependency(ctx context.Context, in *contracts.AddTaskDependencyCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Unblock an existing task from another task
+	RemoveDependency(ctx context.Context, in *contracts.RemoveTaskDependencyCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Grant a user access to an existing task
+	Share(ctx context.Context, in *contracts.ShareTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Revoke the access of a user to an existing task
+	Unshare(ctx context.Context, in *contracts.UnshareTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error)
+	// Get an existing task
+	Get(ctx context.Context, in *contracts.GetTaskQuery, opts ...grpc.CallOption) (*contracts.TaskEntity, error)
 	// Query for existing tasks
 	ListQuery(ctx context.Context, in *contracts.ListTasksQuery, opts ...grpc.CallOption) (*contracts.TaskEntityList, error)
+	// Query for the event history of a task
+	HistoryQuery(ctx context.Context, in *contracts.GetTaskHistoryQuery, opts ...grpc.CallOption) (*contracts.TaskEventList, error)
+	// Query for a task as it was at a version or point in time
+	AsOfQuery(ctx context.Context, in *contracts.GetTaskAsOfQuery, opts ...grpc.CallOption) (*contracts.TaskEntity, error)
+	// Query for the dependency graph of a task
+	DependenciesQuery(ctx context.Context, in *contracts.GetTaskDependenciesQuery, opts ...grpc.CallOption) (*contracts.TaskDependencyGraph, error)
+	// Stream task events from an event id onwards, stays open for live events
+	SubscribeEvents(ctx context.Context, in *contracts.SubscribeEventsQuery, opts ...grpc.CallOption) (Tasks_SubscribeEventsClient, error)
 }
 
 type tasksClient struct {
@@ -88,6 +114,78 @@ func (c *tasksClient) Complete(ctx context.Context, in *contracts.CompleteTaskCo
 	return out, nil
 }
 
+func (c *tasksClient) Transition(ctx context.Context, in *contracts.TransitionTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Transition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Assign(ctx context.Context, in *contracts.AssignTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Assign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Move(ctx context.Context, in *contracts.MoveTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Move", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) AddDependency(ctx context.Context, in *contracts.AddTaskDependencyCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/AddDependency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) RemoveDependency(ctx context.Context, in *contracts.RemoveTaskDependencyCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/RemoveDependency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Share(ctx context.Context, in *contracts.ShareTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Share", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Unshare(ctx context.Context, in *contracts.UnshareTaskCommand, opts ...grpc.CallOption) (*contracts.TaskEvent, error) {
+	out := new(contracts.TaskEvent)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Unshare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) Get(ctx context.Context, in *contracts.GetTaskQuery, opts ...grpc.CallOption) (*contracts.TaskEntity, error) {
+	out := new(contracts.TaskEntity)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tasksClient) ListQuery(ctx context.Context, in *contracts.ListTasksQuery, opts ...grpc.CallOption) (*contracts.TaskEntityList, error) {
 	out := new(contracts.TaskEntityList)
 	err := c.cc.Invoke(ctx, "/tasks.Tasks/ListQuery", in, out, opts...)
@@ -97,6 +195,65 @@ func (c *tasksClient) ListQuery(ctx context.Context, in *contracts.ListTasksQuer
 	return out, nil
 }
 
+func (c *tasksClient) HistoryQuery(ctx context.Context, in *contracts.GetTaskHistoryQuery, opts ...grpc.CallOption) (*contracts.TaskEventList, error) {
+	out := new(contracts.TaskEventList)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/HistoryQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) AsOfQuery(ctx context.Context, in *contracts.GetTaskAsOfQuery, opts ...grpc.CallOption) (*contracts.TaskEntity, error) {
+	out := new(contracts.TaskEntity)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/AsOfQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) DependenciesQuery(ctx context.Context, in *contracts.GetTaskDependenciesQuery, opts ...grpc.CallOption) (*contracts.TaskDependencyGraph, error) {
+	out := new(contracts.TaskDependencyGraph)
+	err := c.cc.Invoke(ctx, "/tasks.Tasks/DependenciesQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksClient) SubscribeEvents(ctx context.Context, in *contracts.SubscribeEventsQuery, opts ...grpc.CallOption) (Tasks_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Tasks_ServiceDesc.Streams[0], "/tasks.Tasks/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tasksSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Tasks_SubscribeEventsClient interface {
+	Recv() (*contracts.TaskEvent, error)
+	grpc.ClientStream
+}
+
+type tasksSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tasksSubscribeEventsClient) Recv() (*contracts.TaskEvent, error) {
+	m := new(contracts.TaskEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TasksServer is the server API for Tasks service.
 // All implementations must embed UnimplementedTasksServer
 // for forward compatibility
@@ -105,12 +262,38 @@ type TasksServer interface {
 	Create(context.Context, *contracts.CreateTaskCommand) (*contracts.TaskEvent, error)
 	Delete(context.Context, *contracts.DeleteTaskCommand) (*contracts.TaskEvent, error)
 	Update(context.Context, *contracts.UpdateTaskCommand) (*contracts.TaskEvent, error)
-	// Update existing task state to progress
+	// Update existing task state to progress, the event written is
+	// "progressed" (it used to be "updated")
 	Progress(context.Context, *contracts.ProgressTaskCommand) (*contracts.TaskEvent, error)
-	// Update existing task to complete
+	// Update existing task to complete, the event written is "completed" (it
+	// used to be "updated")
 	Complete(context.Context, *contracts.CompleteTaskCommand) (*contracts.TaskEvent, error)
+	// Move an existing task to another status of the workflow
+	Transition(context.Context, *contracts.TransitionTaskCommand) (*contracts.TaskEvent, error)
+	// Assign an existing task to a user
+	Assign(context.Context, *contracts.AssignTaskCommand) (*contracts.TaskEvent, error)
+	// Move an existing task under another task
+	Move(context.Context, *contracts.MoveTaskCommand) (*contracts.TaskEvent, error)
+	// Block an existing task by another task
+	AddDependency(context.Context, *contracts.AddTaskDependencyCommand) (*contracts.TaskEvent, error)
+	// Unblock an existing task from another task
+	RemoveDependency(context.Context, *contracts.RemoveTaskDependencyCommand) (*contracts.TaskEvent, error)
+	// Grant a user access to an existing task
+	Share(context.Context, *contracts.ShareTaskCommand) (*contracts.TaskEvent, error)
+	// Revoke the access of a user to an existing task
+	Unshare(context.Context, *contracts.UnshareTaskCommand) (*contracts.TaskEvent, error)
+	// Get an existing task
+	Get(context.Context, *contracts.GetTaskQuery) (*contracts.TaskEntity, error)
 	// Query for existing tasks
 	ListQuery(context.Context, *contracts.ListTasksQuery) (*contracts.TaskEntityList, error)
+	// Query for the event history of a task
+	HistoryQuery(context.Context, *contracts.GetTaskHistoryQuery) (*contracts.TaskEventList, error)
+	// Query for a task as it was at a version or point in time
+	AsOfQuery(context.Context, *contracts.GetTaskAsOfQuery) (*contracts.TaskEntity, error)
+	// Query for the dependency graph of a task
+	DependenciesQuery(context.Context, *contracts.GetTaskDependenciesQuery) (*contracts.TaskDependencyGraph, error)
+	// Stream task events from an event id onwards, stays open for live events
+	SubscribeEvents(*contracts.SubscribeEventsQuery, Tasks_SubscribeEventsServer) error
 	mustEmbedUnimplementedTasksServer()
 }
 
@@ -133,9 +316,45 @@ func (UnimplementedTasksServer) Progress(context.Context, *contracts.ProgressTas
 func (UnimplementedTasksServer) Complete(context.Context, *contracts.CompleteTaskCommand) (*contracts.TaskEvent, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
 }
+func (UnimplementedTasksServer) Transition(context.Context, *contracts.TransitionTaskCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transition not implemented")
+}
+func (UnimplementedTasksServer) Assign(context.Context, *contracts.AssignTaskCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Assign not implemented")
+}
+func (UnimplementedTasksServer) Move(context.Context, *contracts.MoveTaskCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedTasksServer) AddDependency(context.Context, *contracts.AddTaskDependencyCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDependency not implemented")
+}
+func (UnimplementedTasksServer) RemoveDependency(context.Context, *contracts.RemoveTaskDependencyCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDependency not implemented")
+}
+func (UnimplementedTasksServer) Share(context.Context, *contracts.ShareTaskCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Share not implemented")
+}
+func (UnimplementedTasksServer) Unshare(context.Context, *contracts.UnshareTaskCommand) (*contracts.TaskEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unshare not implemented")
+}
+func (UnimplementedTasksServer) Get(context.Context, *contracts.GetTaskQuery) (*contracts.TaskEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
 func (UnimplementedTasksServer) ListQuery(context.Context, *contracts.ListTasksQuery) (*contracts.TaskEntityList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListQuery not implemented")
 }
+func (UnimplementedTasksServer) HistoryQuery(context.Context, *contracts.GetTaskHistoryQuery) (*contracts.TaskEventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HistoryQuery not implemented")
+}
+func (UnimplementedTasksServer) AsOfQuery(context.Context, *contracts.GetTaskAsOfQuery) (*contracts.TaskEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AsOfQuery not implemented")
+}
+func (UnimplementedTasksServer) DependenciesQuery(context.Context, *contracts.GetTaskDependenciesQuery) (*contracts.TaskDependencyGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DependenciesQuery not implemented")
+}
+func (UnimplementedTasksServer) SubscribeEvents(*contracts.SubscribeEventsQuery, Tasks_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
 func (UnimplementedTasksServer) mustEmbedUnimplementedTasksServer() {}
 
 // UnsafeTasksServer may be embedded to opt out of forward compatibility for this service.
@@ -239,6 +458,150 @@ func _Tasks_Complete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Tasks_Transition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.TransitionTaskCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Transition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Transition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Transition(ctx, req.(*contracts.TransitionTaskCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Assign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.AssignTaskCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Assign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Assign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Assign(ctx, req.(*contracts.AssignTaskCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.MoveTaskCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Move(ctx, req.(*contracts.MoveTaskCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.AddTaskDependencyCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/AddDependency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).AddDependency(ctx, req.(*contracts.AddTaskDependencyCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.RemoveTaskDependencyCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/RemoveDependency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).RemoveDependency(ctx, req.(*contracts.RemoveTaskDependencyCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Share_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.ShareTaskCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Share(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Share",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Share(ctx, req.(*contracts.ShareTaskCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Unshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.UnshareTaskCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Unshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Unshare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Unshare(ctx, req.(*contracts.UnshareTaskCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.GetTaskQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).Get(ctx, req.(*contracts.GetTaskQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Tasks_ListQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(contracts.ListTasksQuery)
 	if err := dec(in); err != nil {
@@ -257,6 +620,81 @@ func _Tasks_ListQuery_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Tasks_HistoryQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.GetTaskHistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).HistoryQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/HistoryQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).HistoryQuery(ctx, req.(*contracts.GetTaskHistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_AsOfQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.GetTaskAsOfQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).AsOfQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/AsOfQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).AsOfQuery(ctx, req.(*contracts.GetTaskAsOfQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_DependenciesQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.GetTaskDependenciesQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServer).DependenciesQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Tasks/DependenciesQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServer).DependenciesQuery(ctx, req.(*contracts.GetTaskDependenciesQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tasks_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(contracts.SubscribeEventsQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TasksServer).SubscribeEvents(m, &tasksSubscribeEventsServer{stream})
+}
+
+type Tasks_SubscribeEventsServer interface {
+	Send(*contracts.TaskEvent) error
+	grpc.ServerStream
+}
+
+type tasksSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tasksSubscribeEventsServer) Send(m *contracts.TaskEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Tasks_ServiceDesc is the grpc.ServiceDesc for Tasks service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -284,12 +722,62 @@ var Tasks_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Complete",
 			Handler:    _Tasks_Complete_Handler,
 		},
+		{
+			MethodName: "Transition",
+			Handler:    _Tasks_Transition_Handler,
+		},
+		{
+			MethodName: "Assign",
+			Handler:    _Tasks_Assign_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _Tasks_Move_Handler,
+		},
+		{
+			MethodName: "AddDependency",
+			Handler:    _Tasks_AddDependency_Handler,
+		},
+		{
+			MethodName: "RemoveDependency",
+			Handler:    _Tasks_RemoveDependency_Handler,
+		},
+		{
+			MethodName: "Share",
+			Handler:    _Tasks_Share_Handler,
+		},
+		{
+			MethodName: "Unshare",
+			Handler:    _Tasks_Unshare_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Tasks_Get_Handler,
+		},
 		{
 			MethodName: "ListQuery",
 			Handler:    _Tasks_ListQuery_Handler,
 		},
+		{
+			MethodName: "HistoryQuery",
+			Handler:    _Tasks_HistoryQuery_Handler,
+		},
+		{
+			MethodName: "AsOfQuery",
+			Handler:    _Tasks_AsOfQuery_Handler,
+		},
+		{
+			MethodName: "DependenciesQuery",
+			Handler:    _Tasks_DependenciesQuery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _Tasks_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/contracts/service.proto",
 }
 
@@ -416,3 +904,129 @@ var Quotes_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/contracts/service.proto",
 }
+
+// SagasClient is the client API for Sagas service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SagasClient interface {
+	// Undo everything written under a saga
+	Compensate(ctx context.Context, in *contracts.CompensateSagaCommand, opts ...grpc.CallOption) (*contracts.SagaEntity, error)
+	// Get the progress of a saga
+	Get(ctx context.Context, in *contracts.GetSagaQuery, opts ...grpc.CallOption) (*contracts.SagaEntity, error)
+}
+
+type sagasClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSagasClient(cc grpc.ClientConnInterface) SagasClient {
+	return &sagasClient{cc}
+}
+
+func (c *sagasClient) Compensate(ctx context.Context, in *contracts.CompensateSagaCommand, opts ...grpc.CallOption) (*contracts.SagaEntity, error) {
+	out := new(contracts.SagaEntity)
+	err := c.cc.Invoke(ctx, "/tasks.Sagas/Compensate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sagasClient) Get(ctx context.Context, in *contracts.GetSagaQuery, opts ...grpc.CallOption) (*contracts.SagaEntity, error) {
+	out := new(contracts.SagaEntity)
+	err := c.cc.Invoke(ctx, "/tasks.Sagas/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SagasServer is the server API for Sagas service.
+// All implementations must embed UnimplementedSagasServer
+// for forward compatibility
+type SagasServer interface {
+	// Undo everything written under a saga
+	Compensate(context.Context, *contracts.CompensateSagaCommand) (*contracts.SagaEntity, error)
+	// Get the progress of a saga
+	Get(context.Context, *contracts.GetSagaQuery) (*contracts.SagaEntity, error)
+	mustEmbedUnimplementedSagasServer()
+}
+
+// UnimplementedSagasServer must be embedded to have forward compatible implementations.
+type UnimplementedSagasServer struct {
+}
+
+func (UnimplementedSagasServer) Compensate(context.Context, *contracts.CompensateSagaCommand) (*contracts.SagaEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compensate not implemented")
+}
+func (UnimplementedSagasServer) Get(context.Context, *contracts.GetSagaQuery) (*contracts.SagaEntity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedSagasServer) mustEmbedUnimplementedSagasServer() {}
+
+// UnsafeSagasServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SagasServer will
+// result in compilation errors.
+type UnsafeSagasServer interface {
+	mustEmbedUnimplementedSagasServer()
+}
+
+func RegisterSagasServer(s grpc.ServiceRegistrar, srv SagasServer) {
+	s.RegisterService(&Sagas_ServiceDesc, srv)
+}
+
+func _Sagas_Compensate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.CompensateSagaCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SagasServer).Compensate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Sagas/Compensate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SagasServer).Compensate(ctx, req.(*contracts.CompensateSagaCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sagas_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(contracts.GetSagaQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SagasServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tasks.Sagas/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SagasServer).Get(ctx, req.(*contracts.GetSagaQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sagas_ServiceDesc is the grpc.ServiceDesc for Sagas service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sagas_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tasks.Sagas",
+	HandlerType: (*SagasServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Compensate",
+			Handler:    _Sagas_Compensate_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Sagas_Get_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/contracts/service.proto",
+}
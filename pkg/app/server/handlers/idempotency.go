@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"techunicorn.com/udc-core/prototodo/pkg/app/server/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotencyKeyField name of the optional idempotency key field of commands
+const idempotencyKeyField = "idempotencyKey"
+
+// idempotencyGuard replays the stored results of commands that were already
+// handled with the same idempotency key instead of handling them again
+type idempotencyGuard struct {
+	repo idempotency.IRepository
+}
+
+// idempotencyKey gets the idempotency key of a command, the key set on the
+// command takes precedence over the one passed with the header or metadata
+func idempotencyKey(ctx context.Context, key *string) string {
+	if key != nil && *key != "" {
+		return *key
+	}
+	if key, ok := ctx.Value(common.IdempotencyKeyContextKey).(string); ok {
+		return key
+	}
+	return ""
+}
+
+// replay fills res with the stored result of the command if it was already
+// handled with the key
+func (g *idempotencyGuard) replay(
+	ctx context.Context,
+	key string,
+	uctx *contracts.UserContext,
+	cmd proto.Message,
+	res proto.Message,
+) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+	rec, err := g.repo.Get(ctx, uctx.UserType, uctx.Id, key)
+	if err != nil || rec == nil {
+		return false, err
+	}
+	fngr, err := fingerprint(cmd)
+	if err != nil {
+		return false, err
+	}
+	if rec.Command != commandName(cmd) || rec.Fingerprint != fngr {
+		return false, domcom.NewIdempotencyKeyReusedError()
+	}
+	return true, proto.Unmarshal(rec.Result, res)
+}
+
+// record stores the result of the command with the transaction of the command
+func (g *idempotencyGuard) record(
+	ctx context.Context,
+	key string,
+	uctx *contracts.UserContext,
+	cmd proto.Message,
+	res proto.Message,
+) error {
+	if key == "" {
+		return nil
+	}
+	fngr, err := fingerprint(cmd)
+	if err != nil {
+		return err
+	}
+	raw, err := proto.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return g.repo.Save(ctx, idempotency.Record{
+		UserType:    uctx.UserType,
+		UserId:      uctx.Id,
+		Key:         key,
+		Command:     commandName(cmd),
+		Fingerprint: fngr,
+		Result:      raw,
+	})
+}
+
+func commandName(cmd proto.Message) string {
+	return string(cmd.ProtoReflect().Descriptor().FullName())
+}
+
+// fingerprint hashes the command without its idempotency key
+func fingerprint(cmd proto.Message) (string, error) {
+	msg := proto.Clone(cmd).ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(idempotencyKeyField)
+	if fd != nil {
+		msg.Clear(fd)
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(
+		msg.Interface(),
+	)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
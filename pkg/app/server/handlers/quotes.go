@@ -7,6 +7,7 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/app/server/common"
 	srvcontracts "techunicorn.com/udc-core/prototodo/pkg/app/server/contracts"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
@@ -21,6 +22,7 @@ type QuotesHandler struct {
 	srvcontracts.UnimplementedQuotesServer
 	lgrf logger.IFactory
 	svc  *quotes.Service
+	idmp *idempotencyGuard
 }
 
 var _ srvcontracts.QuotesServer = (*QuotesHandler)(nil)
@@ -29,10 +31,12 @@ var _ srvcontracts.QuotesServer = (*QuotesHandler)(nil)
 func NewQuotesHandler(
 	lgrf logger.IFactory,
 	svc *quotes.Service,
+	idmp idempotency.IRepository,
 ) *QuotesHandler {
 	return &QuotesHandler{
 		lgrf: lgrf,
 		svc:  svc,
+		idmp: &idempotencyGuard{repo: idmp},
 	}
 }
 
@@ -150,10 +154,23 @@ func (h *QuotesHandler) Create(
 		}
 		return
 	}()
-	res, err = h.svc.CreateQuote(
-		ctx,
-		cmd,
-	)
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.QuoteData{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.CreateQuote(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
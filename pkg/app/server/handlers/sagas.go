@@ -0,0 +1,175 @@
+// Package handlers handles incoming saga requets
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"techunicorn.com/udc-core/prototodo/pkg/app/server/common"
+	srvcontracts "techunicorn.com/udc-core/prototodo/pkg/app/server/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
+	"time"
+
+	"github.com/betalixt/gorr"
+	"go.uber.org/zap"
+)
+
+// SagasHandler encapsulates handlers related to the Sagas Server
+type SagasHandler struct {
+	srvcontracts.UnimplementedSagasServer
+	lgrf logger.IFactory
+	svc  *sagas.Service
+}
+
+var _ srvcontracts.SagasServer = (*SagasHandler)(nil)
+
+// NewSagasHandler Constructs a new SagasHandler
+func NewSagasHandler(
+	lgrf logger.IFactory,
+	svc *sagas.Service,
+) *SagasHandler {
+	return &SagasHandler{
+		lgrf: lgrf,
+		svc:  svc,
+	}
+}
+
+func (h *SagasHandler) Compensate(
+	c context.Context,
+	cmd *contracts.CompensateSagaCommand,
+) (res *contracts.SagaEntity, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.CompensateSaga(
+		ctx,
+		cmd,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *SagasHandler) Get(
+	c context.Context,
+	qry *contracts.GetSagaQuery,
+) (res *contracts.SagaEntity, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.GetSaga(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
@@ -6,6 +6,7 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/app/server/common"
 	appcontr "techunicorn.com/udc-core/prototodo/pkg/app/server/contracts"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
@@ -21,15 +22,18 @@ type TasksHandler struct {
 	appcontr.UnimplementedTasksServer
 	lgrf logger.IFactory
 	svc  *tasks.Service
+	idmp *idempotencyGuard
 }
 
 func NewTasksHandler(
 	lgrf logger.IFactory,
 	svc *tasks.Service,
+	idmp idempotency.IRepository,
 ) *TasksHandler {
 	return &TasksHandler{
 		lgrf: lgrf,
 		svc:  svc,
+		idmp: &idempotencyGuard{repo: idmp},
 	}
 }
 
@@ -78,10 +82,23 @@ func (h *TasksHandler) Create(
 		}
 		return
 	}()
-	res, err = h.svc.CreateTask(
-		ctx,
-		cmd,
-	)
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.CreateTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -147,10 +164,23 @@ func (h *TasksHandler) Delete(
 		}
 		return
 	}()
-	res, err = h.svc.DeleteTask(
-		ctx,
-		cmd,
-	)
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.DeleteTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -216,10 +246,23 @@ func (h *TasksHandler) Update(
 		}
 		return
 	}()
-	res, err = h.svc.UpdateTask(
-		ctx,
-		cmd,
-	)
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.UpdateTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -285,10 +328,23 @@ func (h *TasksHandler) Progress(
 		}
 		return
 	}()
-	res, err = h.svc.ProgressTask(
-		ctx,
-		cmd,
-	)
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.ProgressTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -354,10 +410,105 @@ func (h *TasksHandler) Complete(
 		}
 		return
 	}()
-	res, err = h.svc.CompleteTask(
-		ctx,
-		cmd,
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.CompleteTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) Transition(
+	c context.Context,
+	cmd *contracts.TransitionTaskCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
 	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.TransitionTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -378,11 +529,11 @@ func (h *TasksHandler) Complete(
 	return
 }
 
-func (h *TasksHandler) ListQuery(
+func (h *TasksHandler) Assign(
 	c context.Context,
-	qry *contracts.ListTasksQuery,
-) (res *contracts.TaskEntityList, err error) {
-	if qry.UserContext == nil {
+	cmd *contracts.AssignTaskCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
 		return nil, common.NewUserContextMissingError()
 	}
 	ctx, ok := c.(cntxt.IContext)
@@ -393,7 +544,7 @@ func (h *TasksHandler) ListQuery(
 	lgr := h.lgrf.Create(ctx)
 	lgr.Info(
 		"handling",
-		zap.Any("qry", qry),
+		zap.Any("cmd", cmd),
 	)
 	defer func() {
 		if r := recover(); r != nil {
@@ -423,10 +574,187 @@ func (h *TasksHandler) ListQuery(
 		}
 		return
 	}()
-	res, err = h.svc.QueryTask(
-		ctx,
-		qry,
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.AssignTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) Move(
+	c context.Context,
+	cmd *contracts.MoveTaskCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.MoveTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) AddDependency(
+	c context.Context,
+	cmd *contracts.AddTaskDependencyCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
 	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.AddTaskDependency(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
 	if err != nil {
 		lgr.Error(
 			"command handling failed",
@@ -446,3 +774,661 @@ func (h *TasksHandler) ListQuery(
 	ctx.Cancel()
 	return
 }
+
+func (h *TasksHandler) RemoveDependency(
+	c context.Context,
+	cmd *contracts.RemoveTaskDependencyCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.RemoveTaskDependency(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) Share(
+	c context.Context,
+	cmd *contracts.ShareTaskCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.ShareTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) Unshare(
+	c context.Context,
+	cmd *contracts.UnshareTaskCommand,
+) (res *contracts.TaskEvent, err error) {
+	if cmd.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("cmd", cmd),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	key := idempotencyKey(ctx, cmd.IdempotencyKey)
+	stored := &contracts.TaskEvent{}
+	replayed, err := h.idmp.replay(ctx, key, cmd.UserContext, cmd, stored)
+	if err == nil && replayed {
+		lgr.Info("replaying stored result", zap.String("idempotencyKey", key))
+		ctx.Cancel()
+		return stored, nil
+	}
+	if err == nil {
+		res, err = h.svc.UnshareTask(
+			ctx,
+			cmd,
+		)
+	}
+	if err == nil {
+		err = h.idmp.record(ctx, key, cmd.UserContext, cmd, res)
+	}
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) Get(
+	c context.Context,
+	qry *contracts.GetTaskQuery,
+) (res *contracts.TaskEntity, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.GetTask(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) ListQuery(
+	c context.Context,
+	qry *contracts.ListTasksQuery,
+) (res *contracts.TaskEntityList, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.QueryTask(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) HistoryQuery(
+	c context.Context,
+	qry *contracts.GetTaskHistoryQuery,
+) (res *contracts.TaskEventList, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.GetTaskHistory(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) AsOfQuery(
+	c context.Context,
+	qry *contracts.GetTaskAsOfQuery,
+) (res *contracts.TaskEntity, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.GetTaskAsOf(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) DependenciesQuery(
+	c context.Context,
+	qry *contracts.GetTaskDependenciesQuery,
+) (res *contracts.TaskDependencyGraph, err error) {
+	if qry.UserContext == nil {
+		return nil, common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return nil, common.NewInvalidContextProvidedToHandlerError()
+	}
+	ctx.SetTimeout(2 * time.Minute)
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+			ctx.RollbackTransaction()
+			ctx.Cancel()
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		return
+	}()
+	res, err = h.svc.GetTaskDependencies(
+		ctx,
+		qry,
+	)
+	if err != nil {
+		lgr.Error(
+			"command handling failed",
+			zap.Error(err),
+		)
+		ctx.RollbackTransaction()
+	} else {
+		err = ctx.CommitTransaction()
+		if err != nil {
+			lgr.Error(
+				"failed to commit transaction",
+				zap.Error(err),
+			)
+			ctx.RollbackTransaction()
+		}
+	}
+	ctx.Cancel()
+	return
+}
+
+func (h *TasksHandler) SubscribeEvents(
+	qry *contracts.SubscribeEventsQuery,
+	stream appcontr.Tasks_SubscribeEventsServer,
+) error {
+	return h.StreamEvents(stream.Context(), qry, stream.Send)
+}
+
+// StreamEvents streams task events to the sender until the context is done,
+// shared by the grpc stream and the http event stream
+func (h *TasksHandler) StreamEvents(
+	c context.Context,
+	qry *contracts.SubscribeEventsQuery,
+	send func(*contracts.TaskEvent) error,
+) (err error) {
+	if qry.UserContext == nil {
+		return common.NewUserContextMissingError()
+	}
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		return common.NewInvalidContextProvidedToHandlerError()
+	}
+	lgr := h.lgrf.Create(ctx)
+	lgr.Info(
+		"handling",
+		zap.Any("qry", qry),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = gorr.NewUnexpectedError(fmt.Errorf("%v", r))
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+			} else {
+				lgr.Error(
+					"root panic recovered handling request",
+					zap.Error(err),
+					zap.Stack("stack"),
+				)
+			}
+		}
+		if err != nil {
+			if _, ok := err.(*gorr.Error); !ok {
+				err = gorr.NewUnexpectedError(err)
+			}
+		}
+		ctx.Cancel()
+		return
+	}()
+	err = h.svc.SubscribeEvents(
+		ctx,
+		qry,
+		send,
+	)
+	if err != nil {
+		lgr.Error(
+			"subscription failed",
+			zap.Error(err),
+		)
+	}
+	return
+}
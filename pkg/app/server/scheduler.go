@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// taskScheduler fires the due schedules of tasks in the background, the
+// schedules are persisted by the repository so nothing is lost on restarts
+// and each schedule is claimed in the transaction writing it's event so
+// multiple instances can run without firing a schedule twice
+type taskScheduler struct {
+	opts *tasks.Options
+	svc  *tasks.Service
+	ctxf cntxt.IFactory
+	lgrf logger.IFactory
+
+	stop    chan struct{}
+	done    chan struct{}
+	runmtx  sync.Mutex
+	running bool
+}
+
+func newTaskScheduler(
+	opts *tasks.Options,
+	svc *tasks.Service,
+	ctxf cntxt.IFactory,
+	lgrf logger.IFactory,
+) *taskScheduler {
+	return &taskScheduler{
+		opts: opts,
+		svc:  svc,
+		ctxf: ctxf,
+		lgrf: lgrf,
+	}
+}
+
+// Start starts firing schedules in the background
+func (s *taskScheduler) Start(ctx context.Context) {
+	s.runmtx.Lock()
+	defer s.runmtx.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run()
+}
+
+// Stop stops the scheduler, waiting for the schedule being fired to complete
+func (s *taskScheduler) Stop(ctx context.Context) {
+	s.runmtx.Lock()
+	defer s.runmtx.Unlock()
+	if !s.running {
+		return
+	}
+	s.running = false
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	}
+}
+
+func (s *taskScheduler) run() {
+	defer close(s.done)
+	lgr := s.lgrf.Create(context.Background())
+	tckr := time.NewTicker(s.opts.SchedulerInterval)
+	defer tckr.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-tckr.C:
+		}
+
+		// firing until nothing is due, bounded so stopping isn't held up
+		for cnt := 0; cnt < s.opts.SchedulerBatchSize; cnt++ {
+			fired, err := s.fire()
+			if err != nil {
+				lgr.Error("failed to fire task schedule", zap.Error(err))
+				break
+			}
+			if !fired {
+				break
+			}
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// fire fires a single due schedule in it's own transaction so the schedules
+// fired before a failure are kept, the failed schedule is retried on the next
+// tick
+func (s *taskScheduler) fire() (bool, error) {
+	ctx := s.ctxf.Create("")
+	ctx.SetTimeout(time.Minute)
+	defer ctx.Cancel()
+
+	fired, err := s.svc.FireDueSchedule(ctx, time.Now())
+	if err != nil {
+		ctx.RollbackTransaction()
+		return false, err
+	}
+	return fired, ctx.CommitTransaction()
+}
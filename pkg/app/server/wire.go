@@ -33,3 +33,8 @@ func initializeAppInMem() (*app, error) {
 	wire.Build(inMemDependencySet)
 	return &app{}, nil
 }
+
+func initializeAppSQLite() (*app, error) {
+	wire.Build(sqliteDependencySet)
+	return &app{}, nil
+}
@@ -10,15 +10,24 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/app/server/handlers"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/config"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/dispatch"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/outbox"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evsqlite"
+	repos3 "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evsqlite/repos"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem"
 	repos2 "techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/memcache"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/redisdb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/snowflake"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/sqlitedb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/appinsights"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/jaeger"
@@ -35,6 +44,10 @@ func initializeAppCQRS() (*app, error) {
 		return nil, err
 	}
 	initializer := config.NewInitializer(loggerFactory)
+	options, err := config.NewTasksOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
 	exporterOptions := config.NewAppInsightsExporterOptions(initializer)
 	traceExporter, err := appinsights.NewTraceExporter(exporterOptions)
 	if err != nil {
@@ -50,11 +63,11 @@ func initializeAppCQRS() (*app, error) {
 		return nil, err
 	}
 	exporterList := evcqrs.NewTraceExporterList(traceExporter, jaegerTraceExporter, promexTraceExporter, loggerFactory)
-	options, err := config.NewTraceOptions(initializer)
+	traceOptions, err := config.NewTraceOptions(initializer)
 	if err != nil {
 		return nil, err
 	}
-	tracer, err := trace.NewTracer(exporterList, options, loggerFactory)
+	tracer, err := trace.NewTracer(exporterList, traceOptions, loggerFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +77,8 @@ func initializeAppCQRS() (*app, error) {
 		return nil, err
 	}
 	baseDataRepository := repos.NewBaseDataRepository(tracedDB)
-	tasksRepository := repos.NewTasksRepository(baseDataRepository, loggerFactory)
+	snapshotOptions := config.NewSnapshotOptions(initializer)
+	tasksRepository := repos.NewTasksRepository(baseDataRepository, loggerFactory, snapshotOptions)
 	redisdbOptions := config.NewRedisOptions(initializer)
 	client, err := redisdb.NewRedisContext(redisdbOptions, tracer)
 	if err != nil {
@@ -77,14 +91,35 @@ func initializeAppCQRS() (*app, error) {
 		return nil, err
 	}
 	uidRepository := repos.NewUIDRepository(node)
-	service := tasks.NewService(tasksRepository, loggerFactory, aclRepository, uidRepository)
-	tasksHandler := handlers.NewTasksHandler(loggerFactory, service)
+	foreignsRepository := repos.NewForeignsRepository(baseDataRepository, loggerFactory)
+	eventhubOptions := config.NewEventHubOptions(initializer)
+	hub := eventhub.NewHub(eventhubOptions)
+	jsonschemaOptions, err := config.NewMetadataSchemaOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := jsonschema.NewValidator(jsonschemaOptions)
+	if err != nil {
+		return nil, err
+	}
+	service := tasks.NewService(options, tasksRepository, loggerFactory, aclRepository, uidRepository, foreignsRepository, hub, validator)
+	idempotencyOptions := config.NewIdempotencyOptions(initializer)
+	idempotencyRepository := repos.NewIdempotencyRepository(baseDataRepository, loggerFactory, idempotencyOptions)
+	tasksHandler := handlers.NewTasksHandler(loggerFactory, service, idempotencyRepository)
 	quotesRepository := repos.NewQuotesRepository(tracedDB, loggerFactory)
 	quotesService := quotes.NewService(quotesRepository, loggerFactory, uidRepository)
-	quotesHandler := handlers.NewQuotesHandler(loggerFactory, quotesService)
-	implementation := evcqrs.NewImplementation(tracedDB, loggerFactory)
-	contextFactory := repos.NewContextFactory(loggerFactory)
-	serverApp := newApp(tasksHandler, quotesHandler, tasksHandler, quotesHandler, implementation, loggerFactory, contextFactory, tracer)
+	quotesHandler := handlers.NewQuotesHandler(loggerFactory, quotesService, idempotencyRepository)
+	sagasRepository := repos.NewSagasRepository(baseDataRepository, loggerFactory)
+	uniquesRepository := repos.NewUniquesRepository(baseDataRepository, loggerFactory)
+	sagasService := sagas.NewService(sagasRepository, loggerFactory, uniquesRepository, foreignsRepository, service, quotesService)
+	sagasHandler := handlers.NewSagasHandler(loggerFactory, sagasService)
+	logDispatcher := dispatch.NewLogDispatcher(loggerFactory)
+	outboxOptions := config.NewOutboxOptions(initializer)
+	relay := outbox.NewRelay(tracedDB, logDispatcher, loggerFactory, outboxOptions)
+	contextFactory := repos.NewContextFactory(loggerFactory, relay, hub)
+	serverTaskScheduler := newTaskScheduler(options, service, contextFactory, loggerFactory)
+	implementation := evcqrs.NewImplementation(tracedDB, loggerFactory, relay, hub)
+	serverApp := newApp(tasksHandler, quotesHandler, sagasHandler, tasksHandler, quotesHandler, sagasHandler, tasksHandler, serverTaskScheduler, implementation, loggerFactory, contextFactory, tracer)
 	return serverApp, nil
 }
 
@@ -93,23 +128,123 @@ func initializeAppInMem() (*app, error) {
 	if err != nil {
 		return nil, err
 	}
-	tasksRepository := repos2.NewTasksRepository()
-	aclRepository := repos2.NewACLRepository()
 	initializer := config.NewInitializer(loggerFactory)
-	options := config.NewSnowflakeOptions(initializer)
-	node, err := snowflake.NewSnowflake(options)
+	options, err := config.NewTasksOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	memoryStore := repos2.NewMemoryStore()
+	tasksRepository := repos2.NewTasksRepository(memoryStore)
+	aclRepository := repos2.NewACLRepository(memoryStore)
+	snowflakeOptions := config.NewSnowflakeOptions(initializer)
+	node, err := snowflake.NewSnowflake(snowflakeOptions)
 	if err != nil {
 		return nil, err
 	}
 	uidRepository := repos2.NewUIDRepository(node)
-	service := tasks.NewService(tasksRepository, loggerFactory, aclRepository, uidRepository)
-	tasksHandler := handlers.NewTasksHandler(loggerFactory, service)
-	quotesRepository := repos2.NewQuotesRepository()
+	foreignsRepository := repos2.NewForeignsRepository(memoryStore)
+	eventhubOptions := config.NewEventHubOptions(initializer)
+	hub := eventhub.NewHub(eventhubOptions)
+	jsonschemaOptions, err := config.NewMetadataSchemaOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := jsonschema.NewValidator(jsonschemaOptions)
+	if err != nil {
+		return nil, err
+	}
+	service := tasks.NewService(options, tasksRepository, loggerFactory, aclRepository, uidRepository, foreignsRepository, hub, validator)
+	idempotencyOptions := config.NewInMemIdempotencyOptions(initializer)
+	idempotencyRepository := repos2.NewIdempotencyRepository(memoryStore, idempotencyOptions)
+	tasksHandler := handlers.NewTasksHandler(loggerFactory, service, idempotencyRepository)
+	quotesRepository := repos2.NewQuotesRepository(memoryStore)
 	quotesService := quotes.NewService(quotesRepository, loggerFactory, uidRepository)
-	quotesHandler := handlers.NewQuotesHandler(loggerFactory, quotesService)
-	implementation := inmem.NewImplementation()
-	contextFactory := repos2.NewContextFactory()
+	quotesHandler := handlers.NewQuotesHandler(loggerFactory, quotesService, idempotencyRepository)
+	sagasRepository := repos2.NewSagasRepository(memoryStore)
+	uniquesRepository := repos2.NewUniquesRepository(memoryStore)
+	sagasService := sagas.NewService(sagasRepository, loggerFactory, uniquesRepository, foreignsRepository, service, quotesService)
+	sagasHandler := handlers.NewSagasHandler(loggerFactory, sagasService)
+	contextFactory := repos2.NewContextFactory(loggerFactory, hub)
+	serverTaskScheduler := newTaskScheduler(options, service, contextFactory, loggerFactory)
+	implementation := inmem.NewImplementation(loggerFactory, hub)
 	badTracer := inmem.NewBadTracer()
-	serverApp := newApp(tasksHandler, quotesHandler, tasksHandler, quotesHandler, implementation, loggerFactory, contextFactory, badTracer)
+	serverApp := newApp(tasksHandler, quotesHandler, sagasHandler, tasksHandler, quotesHandler, sagasHandler, tasksHandler, serverTaskScheduler, implementation, loggerFactory, contextFactory, badTracer)
+	return serverApp, nil
+}
+
+func initializeAppSQLite() (*app, error) {
+	loggerFactory, err := lgr.NewLoggerFactory()
+	if err != nil {
+		return nil, err
+	}
+	initializer := config.NewInitializer(loggerFactory)
+	options, err := config.NewTasksOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	exporterOptions := config.NewAppInsightsExporterOptions(initializer)
+	traceExporter, err := appinsights.NewTraceExporter(exporterOptions)
+	if err != nil {
+		return nil, err
+	}
+	jaegerExporterOptions := config.NewJaegerExporterOptions(initializer)
+	jaegerTraceExporter, err := jaeger.NewJaegerTraceExporter(jaegerExporterOptions)
+	if err != nil {
+		return nil, err
+	}
+	promexTraceExporter, err := promex.NewTraceExporter()
+	if err != nil {
+		return nil, err
+	}
+	exporterList := evsqlite.NewTraceExporterList(traceExporter, jaegerTraceExporter, promexTraceExporter, loggerFactory)
+	traceOptions, err := config.NewTraceOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	tracer, err := trace.NewTracer(exporterList, traceOptions, loggerFactory)
+	if err != nil {
+		return nil, err
+	}
+	databaseOptions := config.NewSQLiteDBOptions(initializer)
+	tracedDB, err := sqlitedb.NewDatabaseContext(tracer, databaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	baseDataRepository := repos3.NewBaseDataRepository(tracedDB)
+	tasksRepository := repos3.NewTasksRepository(baseDataRepository, loggerFactory)
+	cache := memcache.NewMemoryCache()
+	aclRepository := repos3.NewACLRepository(baseDataRepository, cache, loggerFactory)
+	snowflakeOptions := config.NewSnowflakeOptions(initializer)
+	node, err := snowflake.NewSnowflake(snowflakeOptions)
+	if err != nil {
+		return nil, err
+	}
+	uidRepository := repos3.NewUIDRepository(node)
+	foreignsRepository := repos3.NewForeignsRepository(baseDataRepository, loggerFactory)
+	eventhubOptions := config.NewEventHubOptions(initializer)
+	hub := eventhub.NewHub(eventhubOptions)
+	jsonschemaOptions, err := config.NewMetadataSchemaOptions(initializer)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := jsonschema.NewValidator(jsonschemaOptions)
+	if err != nil {
+		return nil, err
+	}
+	service := tasks.NewService(options, tasksRepository, loggerFactory, aclRepository, uidRepository, foreignsRepository, hub, validator)
+	idempotencyOptions := config.NewIdempotencyOptions(initializer)
+	idempotencyRepository := repos3.NewIdempotencyRepository(baseDataRepository, loggerFactory, idempotencyOptions)
+	tasksHandler := handlers.NewTasksHandler(loggerFactory, service, idempotencyRepository)
+	quotesRepository := repos3.NewQuotesRepository(baseDataRepository, loggerFactory)
+	quotesService := quotes.NewService(quotesRepository, loggerFactory, uidRepository)
+	quotesHandler := handlers.NewQuotesHandler(loggerFactory, quotesService, idempotencyRepository)
+	sagasRepository := repos3.NewSagasRepository(baseDataRepository, loggerFactory)
+	uniquesRepository := repos3.NewUniquesRepository(baseDataRepository, loggerFactory)
+	sagasService := sagas.NewService(sagasRepository, loggerFactory, uniquesRepository, foreignsRepository, service, quotesService)
+	sagasHandler := handlers.NewSagasHandler(loggerFactory, sagasService)
+	contextFactory := repos3.NewContextFactory(loggerFactory, hub)
+	serverTaskScheduler := newTaskScheduler(options, service, contextFactory, loggerFactory)
+	implementation := evsqlite.NewImplementation(tracedDB, loggerFactory, hub)
+	serverApp := newApp(tasksHandler, quotesHandler, sagasHandler, tasksHandler, quotesHandler, sagasHandler, tasksHandler, serverTaskScheduler, implementation, loggerFactory, contextFactory, tracer)
 	return serverApp, nil
 }
@@ -14,6 +14,8 @@ type IRepository interface {
 		userID string,
 		permissions int,
 	) error
+	// DeleteACLEntry deletes the entry of the user, ACLEntryMissingError is
+	// returned if the user doesn't have an entry
 	DeleteACLEntry(
 		ctx context.Context,
 		stream string,
@@ -26,6 +28,15 @@ type IRepository interface {
 		stream string,
 		streamID string,
 	) error
+	// GetPermissions gets the permissions of the user, no permissions if the
+	// user doesn't have an entry
+	GetPermissions(
+		ctx context.Context,
+		stream string,
+		streamID string,
+		userType string,
+		userID string,
+	) (int, error)
 
 	CanRead(
 		ctx context.Context,
@@ -41,4 +52,11 @@ type IRepository interface {
 		userType string,
 		userID string,
 	) error
+	CanShare(
+		ctx context.Context,
+		stream string,
+		streamIds []string,
+		userType string,
+		userID string,
+	) error
 }
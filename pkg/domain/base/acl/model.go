@@ -5,4 +5,7 @@ const (
 	Read = 0b01
 	// Write flag constant  to allow for writes
 	Write = 0b10
+	// Share flag constant to allow for granting and revoking the access of
+	// other users
+	Share = 0b100
 )
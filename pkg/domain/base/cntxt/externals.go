@@ -21,4 +21,6 @@ type IContext interface {
 	RollbackTransaction()
 	SetTimeout(time.Duration)
 	Cancel()
+	// SetValue sets a request scoped value that is returned by Value
+	SetValue(key, value any)
 }
@@ -0,0 +1,8 @@
+package events
+
+// INotifier notifies subscribers that events of a stream may have been
+// committed, notifications carry no data and subscribers are expected to check
+// the event store for new events
+type INotifier interface {
+	Subscribe(stream string) (ntfy <-chan struct{}, unsubscribe func())
+}
@@ -16,6 +16,15 @@ type IRepository interface {
 		foreignStreamId string,
 	) error
 
+	// Registers a foreign item unless it's already registered, concurrent calls
+	// for the same item all succeed and an existing item keeps it's saga
+	EnsureForeignItem(
+		ctx context.Context,
+		sagaId *string,
+		foreignStream string,
+		foreignStreamId string,
+	) error
+
 	// Removes a foreign item, will fail if the item is registered in a constraint
 	RemoveForeignItem(
 		ctx context.Context,
@@ -42,6 +51,12 @@ type IRepository interface {
 		streamId string,
 	) error
 
+	// Removes all foreign items and constraints registered under a saga
+	RemoveSagaEntries(
+		ctx context.Context,
+		sagaId string,
+	) error
+
 	// List objects tied to a foreign object
 	ListAssociatedObjects(
 		ctx context.Context,
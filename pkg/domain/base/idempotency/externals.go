@@ -0,0 +1,23 @@
+package idempotency
+
+import "context"
+
+// The idempotency repository stores the results of commands handled with an
+// idempotency key so that a retried command gets the original result instead
+// of being handled again, records are only kept until they expire
+type IRepository interface {
+	// Get fetches the unexpired record of a key used by a user, nil is returned
+	// if the key hasn't been used or the record has expired
+	Get(
+		ctx context.Context,
+		userType string,
+		userId string,
+		key string,
+	) (*Record, error)
+	// Save stores the result of a command along with the changes of the
+	// command, saving a key that is already in use fails
+	Save(
+		ctx context.Context,
+		record Record,
+	) error
+}
@@ -0,0 +1,17 @@
+package idempotency
+
+import "time"
+
+// Record the stored result of a command handled with an idempotency key, the
+// fingerprint identifies the command so a key can't be reused for a different
+// command
+type Record struct {
+	UserType        string
+	UserId          string
+	Key             string
+	Command         string
+	Fingerprint     string
+	Result          []byte
+	DateTimeCreated time.Time
+	DateTimeExpires time.Time
+}
@@ -19,4 +19,9 @@ type IRepository interface {
 		stream string,
 		streamId string,
 	) error
+	// RemoveSagaConstraints removes all constraints registered under a saga
+	RemoveSagaConstraints(
+		ctx context.Context,
+		sagaId string,
+	) error
 }
@@ -13,4 +13,37 @@ const (
 	EventCreated = "created"
 	EventUpdated = "updated"
 	EventDeleted = "deleted"
+	// EventCompensated event restoring the state of an entity to what it was
+	// before a saga, the event holds the complete restored state
+	EventCompensated = "compensated"
+	// EventShared event granting or changing the access of a user to an
+	// entity, EventUnshared revoking it, neither change the entity's data
+	EventShared   = "shared"
+	EventUnshared = "unshared"
+	// EventAssigned event changing the user a task is assigned to
+	EventAssigned = "assigned"
+	// EventMoved event changing the parent of a task
+	EventMoved = "moved"
+	// EventReminderDue event written by the scheduler ahead of the due date
+	// of a task, EventOverdue once the due date has passed, neither change the
+	// task's data
+	EventReminderDue = "reminderDue"
+	EventOverdue     = "overdue"
+	// EventDependencyAdded event blocking a task by another task,
+	// EventDependencyRemoved unblocking it, neither change the task's data
+	EventDependencyAdded   = "dependencyAdded"
+	EventDependencyRemoved = "dependencyRemoved"
+	// EventProgressed and EventCompleted events of the transitions of the
+	// default task workflow, configured workflows name their own events
+	EventProgressed = "progressed"
+	EventCompleted  = "completed"
+
+	SagaStatusActive      = "active"
+	SagaStatusCompensated = "compensated"
+
+	// TaskSnapshotInterval default number of events between task snapshots
+	TaskSnapshotInterval = 100
+	// QuoteSnapshotInterval default number of events between quote snapshots,
+	// quotes are never updated so they aren't snapshotted
+	QuoteSnapshotInterval = 0
 )
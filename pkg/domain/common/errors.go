@@ -1,8 +1,8 @@
 // All of the domain level errors (errors used by and known to the domain)
 // first digit identifies the layer (2 = domain)
 // the first two digit identify the domain the error was created for 00 refers
-// to the acl domain, 01 to the foreigns domain, 02 to the uniques domain and
-// 99 refers to a non domain specific error,
+// to the acl domain, 01 to the foreigns domain, 02 to the uniques domain, 06
+// to the idempotency domain and 99 refers to a non domain specific error,
 
 package common
 
@@ -12,6 +12,27 @@ const (
 	UserACLCheckFailedErrorCode    = 2_00_000
 	UserACLCheckFailedErrorMessage = "UserACLCheckFailedError"
 
+	ACLEntryExistsErrorCode    = 2_00_001
+	ACLEntryExistsErrorMessage = "ACLEntryExistsError"
+
+	ACLEntryMissingErrorCode    = 2_00_002
+	ACLEntryMissingErrorMessage = "ACLEntryMissingError"
+
+	ForeignItemExistsErrorCode    = 2_01_000
+	ForeignItemExistsErrorMessage = "ForeignItemExistsError"
+
+	ForeignItemMissingErrorCode    = 2_01_001
+	ForeignItemMissingErrorMessage = "ForeignItemMissingError"
+
+	ForeignItemInUseErrorCode    = 2_01_002
+	ForeignItemInUseErrorMessage = "ForeignItemInUseError"
+
+	ForeignConstraintExistsErrorCode    = 2_01_003
+	ForeignConstraintExistsErrorMessage = "ForeignConstraintExistsError"
+
+	UniqueConstraintViolationErrorCode    = 2_02_000
+	UniqueConstraintViolationErrorMessage = "UniqueConstraintViolationError"
+
 	InvalidUserTypeForTaskErrorCode    = 2_03_000
 	InvalidUserTypeForTaskErrorMessage = "InvalidUserTypeForTaskError"
 
@@ -29,8 +50,80 @@ const (
 
 	NoTaskUpdatesErrorCode    = 2_03_005
 	NoTaskUpdatesErrorMessage = "NoTaskUpdatesError"
+
+	InvalidAsOfQueryErrorCode    = 2_03_006
+	InvalidAsOfQueryErrorMessage = "InvalidAsOfQueryError"
+
+	InvalidListTasksQueryErrorCode    = 2_03_007
+	InvalidListTasksQueryErrorMessage = "InvalidListTasksQueryError"
+
+	InvalidShareTargetErrorCode    = 2_03_008
+	InvalidShareTargetErrorMessage = "InvalidShareTargetError"
+
+	InvalidDueDateErrorCode    = 2_03_009
+	InvalidDueDateErrorMessage = "InvalidDueDateError"
+
+	InvalidTaskParentErrorCode    = 2_03_010
+	InvalidTaskParentErrorMessage = "InvalidTaskParentError"
+
+	OpenSubtasksErrorCode    = 2_03_011
+	OpenSubtasksErrorMessage = "OpenSubtasksError"
+
+	TaskHasSubtasksErrorCode    = 2_03_012
+	TaskHasSubtasksErrorMessage = "TaskHasSubtasksError"
+
+	InvalidTaskDependencyErrorCode    = 2_03_013
+	InvalidTaskDependencyErrorMessage = "InvalidTaskDependencyError"
+
+	TaskBlockedErrorCode    = 2_03_014
+	TaskBlockedErrorMessage = "TaskBlockedError"
+
+	InvalidTaskTransitionErrorCode    = 2_03_015
+	InvalidTaskTransitionErrorMessage = "InvalidTaskTransitionError"
+
+	UnknownTransitionGuardErrorCode    = 2_03_016
+	UnknownTransitionGuardErrorMessage = "UnknownTransitionGuardError"
+
+	InvalidTaskMetadataErrorCode    = 2_03_017
+	InvalidTaskMetadataErrorMessage = "InvalidTaskMetadataError"
+
+	InvalidTaskRandomMapErrorCode    = 2_03_018
+	InvalidTaskRandomMapErrorMessage = "InvalidTaskRandomMapError"
+
+	TaskHierarchyCycleErrorCode    = 2_03_019
+	TaskHierarchyCycleErrorMessage = "TaskHierarchyCycleError"
+
+	QuoteMissingErrorCode    = 2_04_000
+	QuoteMissingErrorMessage = "QuoteMissingError"
+
+	InvalidUserTypeForSagaErrorCode    = 2_05_000
+	InvalidUserTypeForSagaErrorMessage = "InvalidUserTypeForSagaError"
+
+	SagaMissingErrorCode    = 2_05_001
+	SagaMissingErrorMessage = "SagaMissingError"
+
+	SagaCompensatedErrorCode    = 2_05_002
+	SagaCompensatedErrorMessage = "SagaCompensatedError"
+
+	SagaNotCompensatableErrorCode    = 2_05_003
+	SagaNotCompensatableErrorMessage = "SagaNotCompensatableError"
+
+	IdempotencyKeyReusedErrorCode    = 2_06_000
+	IdempotencyKeyReusedErrorMessage = "IdempotencyKeyReusedError"
+
+	IdempotencyKeyInUseErrorCode    = 2_06_001
+	IdempotencyKeyInUseErrorMessage = "IdempotencyKeyInUseError"
+
+	VersionConflictErrorCode    = 2_99_000
+	VersionConflictErrorMessage = "VersionConflictError"
 )
 
+// IsError checks if the error is a gorr error with the given code
+func IsError(err error, code int) bool {
+	gerr, ok := err.(*gorr.Error)
+	return ok && gerr.ErrorCode.Code == code
+}
+
 func NewUserACLCheckFailedError() *gorr.Error {
 	return gorr.NewError(
 		gorr.ErrorCode{
@@ -53,14 +146,15 @@ func NewInvalidUserTypeForTaskError() *gorr.Error {
 	)
 }
 
+// NewTaskMissingError returns error for when a task doesn't exist
 func NewTaskMissingError() *gorr.Error {
 	return gorr.NewError(
 		gorr.ErrorCode{
 			Code:    TaskMissingErrorCode,
 			Message: TaskMissingErrorMessage,
 		},
-		403,
-		"only users are allowed to create task",
+		404,
+		"",
 	)
 }
 
@@ -111,3 +205,378 @@ func NewNoTaskUpdatesError() *gorr.Error {
 		"",
 	)
 }
+
+// NewInvalidAsOfQueryError returns error for when a point in time query does
+// not provide exactly one of version or event time
+func NewInvalidAsOfQueryError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidAsOfQueryErrorCode,
+			Message: InvalidAsOfQueryErrorMessage,
+		},
+		400,
+		"exactly one of version or eventTime must be provided",
+	)
+}
+
+// NewInvalidListTasksQueryError returns error for when the filters or sorting
+// of a task listing are invalid
+func NewInvalidListTasksQueryError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidListTasksQueryErrorCode,
+			Message: InvalidListTasksQueryErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewInvalidShareTargetError returns error for when a task is being shared
+// with or unshared from a user it can't be
+func NewInvalidShareTargetError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidShareTargetErrorCode,
+			Message: InvalidShareTargetErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewInvalidDueDateError returns error for when the due date of a task is
+// set and cleared at once
+func NewInvalidDueDateError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidDueDateErrorCode,
+			Message: InvalidDueDateErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewInvalidTaskParentError returns error for when a task is being created
+// under or moved to a task it can't be a subtask of
+func NewInvalidTaskParentError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidTaskParentErrorCode,
+			Message: InvalidTaskParentErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewOpenSubtasksError returns error for when a task is being completed while
+// some of it's subtasks aren't
+func NewOpenSubtasksError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    OpenSubtasksErrorCode,
+			Message: OpenSubtasksErrorMessage,
+		},
+		409,
+		"all subtasks must be finished first",
+	)
+}
+
+// NewTaskHasSubtasksError returns error for when a task with subtasks is
+// being deleted and subtasks aren't deleted along with their parent
+func NewTaskHasSubtasksError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    TaskHasSubtasksErrorCode,
+			Message: TaskHasSubtasksErrorMessage,
+		},
+		409,
+		"the subtasks of the task must be deleted first",
+	)
+}
+
+// NewInvalidTaskDependencyError returns error for when a task is being blocked
+// by a task it can't depend on or unblocked from a task it doesn't depend on
+func NewInvalidTaskDependencyError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidTaskDependencyErrorCode,
+			Message: InvalidTaskDependencyErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewTaskBlockedError returns error for when a task is being progressed while
+// some of the tasks blocking it aren't completed
+func NewTaskBlockedError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    TaskBlockedErrorCode,
+			Message: TaskBlockedErrorMessage,
+		},
+		409,
+		"all blocking tasks must be finished first",
+	)
+}
+
+// NewInvalidTaskTransitionError returns error for when a task is being moved
+// to a status the workflow doesn't allow moving to from it's current status
+func NewInvalidTaskTransitionError(from string, to string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidTaskTransitionErrorCode,
+			Message: InvalidTaskTransitionErrorMessage,
+		},
+		400,
+		"task can't be moved from "+from+" to "+to,
+	)
+}
+
+// NewUnknownTransitionGuardError returns error for when a transition of the
+// configured workflow has a guard that isn't registered
+func NewUnknownTransitionGuardError(guard string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    UnknownTransitionGuardErrorCode,
+			Message: UnknownTransitionGuardErrorMessage,
+		},
+		500,
+		"transition guard "+guard+" isn't registered",
+	)
+}
+
+// NewInvalidTaskMetadataError returns error for when the metadata of a task
+// doesn't match the schema configured for the user type setting it
+func NewInvalidTaskMetadataError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidTaskMetadataErrorCode,
+			Message: InvalidTaskMetadataErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewInvalidTaskRandomMapError returns error for when the random map of a
+// task is set and cleared at once
+func NewInvalidTaskRandomMapError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidTaskRandomMapErrorCode,
+			Message: InvalidTaskRandomMapErrorMessage,
+		},
+		400,
+		detail,
+	)
+}
+
+// NewTaskHierarchyCycleError returns error for when the stored hierarchy of
+// the tasks loops, the task is one of the tasks in the loop
+func NewTaskHierarchyCycleError(id string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    TaskHierarchyCycleErrorCode,
+			Message: TaskHierarchyCycleErrorMessage,
+		},
+		409,
+		"the task hierarchy loops through task "+id,
+	)
+}
+
+// NewInvalidUserTypeForSagaError returns error for when a user that isn't an
+// application attempts to manage a saga
+func NewInvalidUserTypeForSagaError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidUserTypeForSagaErrorCode,
+			Message: InvalidUserTypeForSagaErrorMessage,
+		},
+		403,
+		"only applications are allowed to manage sagas",
+	)
+}
+
+// NewSagaMissingError returns error for when nothing was written under a saga
+func NewSagaMissingError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    SagaMissingErrorCode,
+			Message: SagaMissingErrorMessage,
+		},
+		404,
+		"",
+	)
+}
+
+// NewSagaCompensatedError returns error for when a saga that has already been
+// compensated is being compensated or written to again
+func NewSagaCompensatedError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    SagaCompensatedErrorCode,
+			Message: SagaCompensatedErrorMessage,
+		},
+		409,
+		"saga has already been compensated",
+	)
+}
+
+// NewSagaNotCompensatableError returns error for when the changes of a saga
+// can no longer be undone
+func NewSagaNotCompensatableError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    SagaNotCompensatableErrorCode,
+			Message: SagaNotCompensatableErrorMessage,
+		},
+		409,
+		detail,
+	)
+}
+
+// NewIdempotencyKeyReusedError returns error for when an idempotency key is
+// sent with a command different to the one it was first used with
+func NewIdempotencyKeyReusedError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    IdempotencyKeyReusedErrorCode,
+			Message: IdempotencyKeyReusedErrorMessage,
+		},
+		422,
+		"idempotency key was used with a different command",
+	)
+}
+
+// NewIdempotencyKeyInUseError returns error for when a command with the same
+// idempotency key is being handled concurrently
+func NewIdempotencyKeyInUseError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    IdempotencyKeyInUseErrorCode,
+			Message: IdempotencyKeyInUseErrorMessage,
+		},
+		409,
+		"a command with the idempotency key is already being handled",
+	)
+}
+
+// NewVersionConflictError returns error for when an entity was changed since
+// the version a command expected
+func NewVersionConflictError(detail string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    VersionConflictErrorCode,
+			Message: VersionConflictErrorMessage,
+		},
+		409,
+		detail,
+	)
+}
+
+// NewACLEntryExistsError returns error for when a user already has an acl
+// entry for an entity
+func NewACLEntryExistsError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ACLEntryExistsErrorCode,
+			Message: ACLEntryExistsErrorMessage,
+		},
+		409,
+		"",
+	)
+}
+
+// NewACLEntryMissingError returns error for when a user doesn't have an acl
+// entry for an entity
+func NewACLEntryMissingError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ACLEntryMissingErrorCode,
+			Message: ACLEntryMissingErrorMessage,
+		},
+		404,
+		"",
+	)
+}
+
+// NewForeignItemExistsError returns error for when a foreign item is
+// registered more than once
+func NewForeignItemExistsError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ForeignItemExistsErrorCode,
+			Message: ForeignItemExistsErrorMessage,
+		},
+		409,
+		"",
+	)
+}
+
+// NewForeignItemMissingError returns error for when a constraint references a
+// foreign item that hasn't been registered
+func NewForeignItemMissingError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ForeignItemMissingErrorCode,
+			Message: ForeignItemMissingErrorMessage,
+		},
+		404,
+		"",
+	)
+}
+
+// NewForeignItemInUseError returns error for when a foreign item that is still
+// referenced by constraints is being removed
+func NewForeignItemInUseError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ForeignItemInUseErrorCode,
+			Message: ForeignItemInUseErrorMessage,
+		},
+		409,
+		"",
+	)
+}
+
+// NewForeignConstraintExistsError returns error for when a foreign constraint
+// is registered more than once
+func NewForeignConstraintExistsError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ForeignConstraintExistsErrorCode,
+			Message: ForeignConstraintExistsErrorMessage,
+		},
+		409,
+		"",
+	)
+}
+
+// NewUniqueConstraintViolationError returns error for when a value of a
+// property is already taken by another entity of the stream
+func NewUniqueConstraintViolationError(property string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    UniqueConstraintViolationErrorCode,
+			Message: UniqueConstraintViolationErrorMessage,
+		},
+		409,
+		property,
+	)
+}
+
+// NewQuoteMissingError returns error for when there are no quotes
+func NewQuoteMissingError() *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    QuoteMissingErrorCode,
+			Message: QuoteMissingErrorMessage,
+		},
+		404,
+		"",
+	)
+}
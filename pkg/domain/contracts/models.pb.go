@@ -32,6 +32,9 @@ const (
 	Status_PENDING   Status = 0
 	Status_PROGRESS  Status = 1
 	Status_COMPLETED Status = 2
+	Status_BLOCKED   Status = 3
+	Status_REVIEW    Status = 4
+	Status_CANCELLED Status = 5
 )
 
 // Enum value maps for Status.
@@ -40,11 +43,17 @@ var (
 		0: "PENDING",
 		1: "PROGRESS",
 		2: "COMPLETED",
+		3: "BLOCKED",
+		4: "REVIEW",
+		5: "CANCELLED",
 	}
 	Status_value = map[string]int32{
 		"PENDING":   0,
 		"PROGRESS":  1,
 		"COMPLETED": 2,
+		"BLOCKED":   3,
+		"REVIEW":    4,
+		"CANCELLED": 5,
 	}
 )
 
@@ -75,6 +84,150 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{0}
 }
 
+type TaskSortField int32
+
+const (
+	TaskSortField_DATE_TIME_CREATED TaskSortField = 0
+	TaskSortField_DATE_TIME_UPDATED TaskSortField = 1
+	TaskSortField_TITLE             TaskSortField = 2
+	TaskSortField_STATUS            TaskSortField = 3
+)
+
+// Enum value maps for TaskSortField.
+var (
+	TaskSortField_name = map[int32]string{
+		0: "DATE_TIME_CREATED",
+		1: "DATE_TIME_UPDATED",
+		2: "TITLE",
+		3: "STATUS",
+	}
+	TaskSortField_value = map[string]int32{
+		"DATE_TIME_CREATED": 0,
+		"DATE_TIME_UPDATED": 1,
+		"TITLE":             2,
+		"STATUS":            3,
+	}
+)
+
+func (x TaskSortField) Enum() *TaskSortField {
+	p := new(TaskSortField)
+	*p = x
+	return p
+}
+
+func (x TaskSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_models_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskSortField) Type() protoreflect.EnumType {
+	return &file_contracts_models_proto_enumTypes[1]
+}
+
+func (x TaskSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskSortField.Descriptor instead.
+func (TaskSortField) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{1}
+}
+
+type SortDirection int32
+
+const (
+	SortDirection_ASCENDING  SortDirection = 0
+	SortDirection_DESCENDING SortDirection = 1
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "ASCENDING",
+		1: "DESCENDING",
+	}
+	SortDirection_value = map[string]int32{
+		"ASCENDING":  0,
+		"DESCENDING": 1,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_models_proto_enumTypes[2].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_contracts_models_proto_enumTypes[2]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{2}
+}
+
+type AccessLevel int32
+
+const (
+	AccessLevel_READ       AccessLevel = 0
+	AccessLevel_READ_WRITE AccessLevel = 1
+)
+
+// Enum value maps for AccessLevel.
+var (
+	AccessLevel_name = map[int32]string{
+		0: "READ",
+		1: "READ_WRITE",
+	}
+	AccessLevel_value = map[string]int32{
+		"READ":       0,
+		"READ_WRITE": 1,
+	}
+)
+
+func (x AccessLevel) Enum() *AccessLevel {
+	p := new(AccessLevel)
+	*p = x
+	return p
+}
+
+func (x AccessLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_models_proto_enumTypes[3].Descriptor()
+}
+
+func (AccessLevel) Type() protoreflect.EnumType {
+	return &file_contracts_models_proto_enumTypes[3]
+}
+
+func (x AccessLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessLevel.Descriptor instead.
+func (AccessLevel) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{3}
+}
+
 // [START common]
 type UserContext struct {
 	state         protoimpl.MessageState
@@ -153,10 +306,18 @@ type CreateTaskCommand struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Title       string       `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description string       `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	SagaId      *string      `protobuf:"bytes,4,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	UserContext    *UserContext           `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	SagaId         *string                `protobuf:"bytes,4,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,5,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	DueDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=dueDate,proto3,oneof" json:"dueDate,omitempty"`
+	// parentId creates the task as a subtask of the task
+	ParentId  *string           `protobuf:"bytes,7,opt,name=parentId,proto3,oneof" json:"parentId,omitempty"`
+	RandomMap map[string]string `protobuf:"bytes,8,rep,name=randomMap,proto3" json:"randomMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// metadata is validated against the schema configured for the user type of
+	// the caller
+	Metadata *structpb.Struct `protobuf:"bytes,9,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
 }
 
 func (x *CreateTaskCommand) Reset() {
@@ -219,14 +380,51 @@ func (x *CreateTaskCommand) GetSagaId() string {
 	return ""
 }
 
+func (x *CreateTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateTaskCommand) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *CreateTaskCommand) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTaskCommand) GetRandomMap() map[string]string {
+	if x != nil {
+		return x.RandomMap
+	}
+	return nil
+}
+
+func (x *CreateTaskCommand) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type DeleteTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	SagaId      *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	UserContext     *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id              string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SagaId          *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey  *string      `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64      `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
 }
 
 func (x *DeleteTaskCommand) Reset() {
@@ -282,16 +480,43 @@ func (x *DeleteTaskCommand) GetSagaId() string {
 	return ""
 }
 
+func (x *DeleteTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeleteTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
 type UpdateTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Title       *string      `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Description *string      `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	SagaId      *string      `protobuf:"bytes,5,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	UserContext     *UserContext           `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id              string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Title           *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description     *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	SagaId          *string                `protobuf:"bytes,5,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey  *string                `protobuf:"bytes,6,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64                `protobuf:"varint,7,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	DueDate         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=dueDate,proto3,oneof" json:"dueDate,omitempty"`
+	// clearDueDate removes the due date of the task, dueDate must not be set
+	// along with it
+	ClearDueDate bool `protobuf:"varint,9,opt,name=clearDueDate,proto3" json:"clearDueDate,omitempty"`
+	// randomMap replaces the random map of the task when not empty
+	RandomMap map[string]string `protobuf:"bytes,10,rep,name=randomMap,proto3" json:"randomMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// clearRandomMap empties the random map of the task, randomMap must not be
+	// set along with it
+	ClearRandomMap bool `protobuf:"varint,11,opt,name=clearRandomMap,proto3" json:"clearRandomMap,omitempty"`
+	// metadata replaces the metadata of the task, an empty object clears it
+	Metadata *structpb.Struct `protobuf:"bytes,12,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
 }
 
 func (x *UpdateTaskCommand) Reset() {
@@ -361,14 +586,67 @@ func (x *UpdateTaskCommand) GetSagaId() string {
 	return ""
 }
 
+func (x *UpdateTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UpdateTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTaskCommand) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *UpdateTaskCommand) GetClearDueDate() bool {
+	if x != nil {
+		return x.ClearDueDate
+	}
+	return false
+}
+
+func (x *UpdateTaskCommand) GetRandomMap() map[string]string {
+	if x != nil {
+		return x.RandomMap
+	}
+	return nil
+}
+
+func (x *UpdateTaskCommand) GetClearRandomMap() bool {
+	if x != nil {
+		return x.ClearRandomMap
+	}
+	return false
+}
+
+func (x *UpdateTaskCommand) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// ProgressTaskCommand writes a "progressed" event, earlier versions wrote an
+// "updated" event so consumers of the event stream must handle both
 type ProgressTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	SagaId      *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	UserContext     *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id              string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SagaId          *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey  *string      `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64      `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
 }
 
 func (x *ProgressTaskCommand) Reset() {
@@ -424,14 +702,32 @@ func (x *ProgressTaskCommand) GetSagaId() string {
 	return ""
 }
 
+func (x *ProgressTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ProgressTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+// CompleteTaskCommand writes a "completed" event, earlier versions wrote an
+// "updated" event so consumers of the event stream must handle both
 type CompleteTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	SagaId      *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	UserContext     *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id              string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SagaId          *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey  *string      `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64      `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
 }
 
 func (x *CompleteTaskCommand) Reset() {
@@ -487,19 +783,37 @@ func (x *CompleteTaskCommand) GetSagaId() string {
 	return ""
 }
 
-// -- Queries
-type ListTasksQuery struct {
+func (x *CompleteTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CompleteTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type TransitionTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext  *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	PageNumber   uint32       `protobuf:"varint,2,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
-	CountPerPage uint32       `protobuf:"varint,3,opt,name=countPerPage,proto3" json:"countPerPage,omitempty"`
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// status the task is moved to, the workflow must allow moving to it from
+	// the current status of the task
+	Status          Status  `protobuf:"varint,3,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
+	SagaId          *string `protobuf:"bytes,4,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey  *string `protobuf:"bytes,5,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,6,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
 }
 
-func (x *ListTasksQuery) Reset() {
-	*x = ListTasksQuery{}
+func (x *TransitionTaskCommand) Reset() {
+	*x = TransitionTaskCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -507,13 +821,13 @@ func (x *ListTasksQuery) Reset() {
 	}
 }
 
-func (x *ListTasksQuery) String() string {
+func (x *TransitionTaskCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksQuery) ProtoMessage() {}
+func (*TransitionTaskCommand) ProtoMessage() {}
 
-func (x *ListTasksQuery) ProtoReflect() protoreflect.Message {
+func (x *TransitionTaskCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -525,47 +839,71 @@ func (x *ListTasksQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksQuery.ProtoReflect.Descriptor instead.
-func (*ListTasksQuery) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransitionTaskCommand.ProtoReflect.Descriptor instead.
+func (*TransitionTaskCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListTasksQuery) GetUserContext() *UserContext {
+func (x *TransitionTaskCommand) GetUserContext() *UserContext {
 	if x != nil {
 		return x.UserContext
 	}
 	return nil
 }
 
-func (x *ListTasksQuery) GetPageNumber() uint32 {
+func (x *TransitionTaskCommand) GetId() string {
 	if x != nil {
-		return x.PageNumber
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *ListTasksQuery) GetCountPerPage() uint32 {
+func (x *TransitionTaskCommand) GetStatus() Status {
 	if x != nil {
-		return x.CountPerPage
+		return x.Status
+	}
+	return Status_PENDING
+}
+
+func (x *TransitionTaskCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+func (x *TransitionTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *TransitionTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
 	}
 	return 0
 }
 
-// -- Data
-type TaskData struct {
+type ShareTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Title       *string           `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Description *string           `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Status      *Status           `protobuf:"varint,3,opt,name=status,proto3,enum=tasks.Status,oneof" json:"status,omitempty"`
-	RandomMap   map[string]string `protobuf:"bytes,4,rep,name=randomMap,proto3" json:"randomMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Metadata    *structpb.Struct  `protobuf:"bytes,5,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// the user or application the task is being shared with
+	UserType        string      `protobuf:"bytes,3,opt,name=userType,proto3" json:"userType,omitempty"`
+	UserId          string      `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	AccessLevel     AccessLevel `protobuf:"varint,5,opt,name=accessLevel,proto3,enum=tasks.AccessLevel" json:"accessLevel,omitempty"`
+	IdempotencyKey  *string     `protobuf:"bytes,6,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64     `protobuf:"varint,7,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string     `protobuf:"bytes,8,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
 }
 
-func (x *TaskData) Reset() {
-	*x = TaskData{}
+func (x *ShareTaskCommand) Reset() {
+	*x = ShareTaskCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -573,13 +911,13 @@ func (x *TaskData) Reset() {
 	}
 }
 
-func (x *TaskData) String() string {
+func (x *ShareTaskCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskData) ProtoMessage() {}
+func (*ShareTaskCommand) ProtoMessage() {}
 
-func (x *TaskData) ProtoReflect() protoreflect.Message {
+func (x *ShareTaskCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -591,63 +929,83 @@ func (x *TaskData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskData.ProtoReflect.Descriptor instead.
-func (*TaskData) Descriptor() ([]byte, []int) {
+// Deprecated: Use ShareTaskCommand.ProtoReflect.Descriptor instead.
+func (*ShareTaskCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *TaskData) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+func (x *ShareTaskCommand) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
 	}
-	return ""
+	return nil
 }
 
-func (x *TaskData) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+func (x *ShareTaskCommand) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *TaskData) GetStatus() Status {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *ShareTaskCommand) GetUserType() string {
+	if x != nil {
+		return x.UserType
 	}
-	return Status_PENDING
+	return ""
 }
 
-func (x *TaskData) GetRandomMap() map[string]string {
+func (x *ShareTaskCommand) GetUserId() string {
 	if x != nil {
-		return x.RandomMap
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskData) GetMetadata() *structpb.Struct {
+func (x *ShareTaskCommand) GetAccessLevel() AccessLevel {
 	if x != nil {
-		return x.Metadata
+		return x.AccessLevel
 	}
-	return nil
+	return AccessLevel_READ
 }
 
-type TaskEvent struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ShareTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
 
-	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	SagaId    *string                `protobuf:"bytes,2,opt,name=sagaId,proto3,oneof" json:"sagaId,omitempty"`
-	Stream    string                 `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
-	StreamId  string                 `protobuf:"bytes,4,opt,name=streamId,proto3" json:"streamId,omitempty"`
-	Version   uint64                 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
-	Event     string                 `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
-	EventTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
-	Data      *TaskData              `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+func (x *ShareTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
 }
 
-func (x *TaskEvent) Reset() {
-	*x = TaskEvent{}
+func (x *ShareTaskCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+type MoveTaskCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// id of the task the task is moved under, empty to move it to the top level
+	ParentId        string  `protobuf:"bytes,3,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	IdempotencyKey  *string `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string `protobuf:"bytes,6,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+}
+
+func (x *MoveTaskCommand) Reset() {
+	*x = MoveTaskCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -655,13 +1013,13 @@ func (x *TaskEvent) Reset() {
 	}
 }
 
-func (x *TaskEvent) String() string {
+func (x *MoveTaskCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskEvent) ProtoMessage() {}
+func (*MoveTaskCommand) ProtoMessage() {}
 
-func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+func (x *MoveTaskCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -673,83 +1031,69 @@ func (x *TaskEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
-func (*TaskEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use MoveTaskCommand.ProtoReflect.Descriptor instead.
+func (*MoveTaskCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *TaskEvent) GetId() uint64 {
+func (x *MoveTaskCommand) GetUserContext() *UserContext {
 	if x != nil {
-		return x.Id
+		return x.UserContext
 	}
-	return 0
+	return nil
 }
 
-func (x *TaskEvent) GetSagaId() string {
-	if x != nil && x.SagaId != nil {
-		return *x.SagaId
+func (x *MoveTaskCommand) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *TaskEvent) GetStream() string {
+func (x *MoveTaskCommand) GetParentId() string {
 	if x != nil {
-		return x.Stream
+		return x.ParentId
 	}
 	return ""
 }
 
-func (x *TaskEvent) GetStreamId() string {
-	if x != nil {
-		return x.StreamId
+func (x *MoveTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
 	}
 	return ""
 }
 
-func (x *TaskEvent) GetVersion() uint64 {
-	if x != nil {
-		return x.Version
+func (x *MoveTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
 	}
 	return 0
 }
 
-func (x *TaskEvent) GetEvent() string {
-	if x != nil {
-		return x.Event
+func (x *MoveTaskCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
 	}
 	return ""
 }
 
-func (x *TaskEvent) GetEventTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.EventTime
-	}
-	return nil
-}
-
-func (x *TaskEvent) GetData() *TaskData {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-type TaskEntity struct {
+type AssignTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Version         uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Status          Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
-	CreatedDateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdDateTime,proto3" json:"createdDateTime,omitempty"`
-	UpdatedDateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updatedDateTime,proto3" json:"updatedDateTime,omitempty"`
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// id of the user the task is assigned to, empty to unassign the task
+	Assignee        string  `protobuf:"bytes,3,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	IdempotencyKey  *string `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string `protobuf:"bytes,6,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
 }
 
-func (x *TaskEntity) Reset() {
-	*x = TaskEntity{}
+func (x *AssignTaskCommand) Reset() {
+	*x = AssignTaskCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -757,13 +1101,13 @@ func (x *TaskEntity) Reset() {
 	}
 }
 
-func (x *TaskEntity) String() string {
+func (x *AssignTaskCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskEntity) ProtoMessage() {}
+func (*AssignTaskCommand) ProtoMessage() {}
 
-func (x *TaskEntity) ProtoReflect() protoreflect.Message {
+func (x *AssignTaskCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,70 +1119,70 @@ func (x *TaskEntity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskEntity.ProtoReflect.Descriptor instead.
-func (*TaskEntity) Descriptor() ([]byte, []int) {
+// Deprecated: Use AssignTaskCommand.ProtoReflect.Descriptor instead.
+func (*AssignTaskCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *TaskEntity) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *TaskEntity) GetVersion() uint64 {
+func (x *AssignTaskCommand) GetUserContext() *UserContext {
 	if x != nil {
-		return x.Version
+		return x.UserContext
 	}
-	return 0
+	return nil
 }
 
-func (x *TaskEntity) GetTitle() string {
+func (x *AssignTaskCommand) GetId() string {
 	if x != nil {
-		return x.Title
+		return x.Id
 	}
 	return ""
 }
 
-func (x *TaskEntity) GetDescription() string {
+func (x *AssignTaskCommand) GetAssignee() string {
 	if x != nil {
-		return x.Description
+		return x.Assignee
 	}
 	return ""
 }
 
-func (x *TaskEntity) GetStatus() Status {
-	if x != nil {
-		return x.Status
+func (x *AssignTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
 	}
-	return Status_PENDING
+	return ""
 }
 
-func (x *TaskEntity) GetCreatedDateTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedDateTime
+func (x *AssignTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
 	}
-	return nil
+	return 0
 }
 
-func (x *TaskEntity) GetUpdatedDateTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedDateTime
+func (x *AssignTaskCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
 	}
-	return nil
+	return ""
 }
 
-type TaskEntityList struct {
+type UnshareTaskCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tasks []*TaskEntity `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// the user or application the task is being unshared from
+	UserType        string  `protobuf:"bytes,3,opt,name=userType,proto3" json:"userType,omitempty"`
+	UserId          string  `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	IdempotencyKey  *string `protobuf:"bytes,5,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,6,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string `protobuf:"bytes,7,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
 }
 
-func (x *TaskEntityList) Reset() {
-	*x = TaskEntityList{}
+func (x *UnshareTaskCommand) Reset() {
+	*x = UnshareTaskCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -846,13 +1190,13 @@ func (x *TaskEntityList) Reset() {
 	}
 }
 
-func (x *TaskEntityList) String() string {
+func (x *UnshareTaskCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskEntityList) ProtoMessage() {}
+func (*UnshareTaskCommand) ProtoMessage() {}
 
-func (x *TaskEntityList) ProtoReflect() protoreflect.Message {
+func (x *UnshareTaskCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -864,32 +1208,76 @@ func (x *TaskEntityList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskEntityList.ProtoReflect.Descriptor instead.
-func (*TaskEntityList) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnshareTaskCommand.ProtoReflect.Descriptor instead.
+func (*UnshareTaskCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *TaskEntityList) GetTasks() []*TaskEntity {
+func (x *UnshareTaskCommand) GetUserContext() *UserContext {
 	if x != nil {
-		return x.Tasks
+		return x.UserContext
 	}
 	return nil
 }
 
-// [START quote domain]
-// -- Commands
-type CreateQuoteCommand struct {
+func (x *UnshareTaskCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnshareTaskCommand) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+func (x *UnshareTaskCommand) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnshareTaskCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UnshareTaskCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UnshareTaskCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+type AddTaskDependencyCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
-	Quote       string       `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
-	SagaId      *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// id of the task that has to be completed before the task can be progressed
+	BlockerId       string  `protobuf:"bytes,3,opt,name=blockerId,proto3" json:"blockerId,omitempty"`
+	IdempotencyKey  *string `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64 `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string `protobuf:"bytes,6,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
 }
 
-func (x *CreateQuoteCommand) Reset() {
-	*x = CreateQuoteCommand{}
+func (x *AddTaskDependencyCommand) Reset() {
+	*x = AddTaskDependencyCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -897,13 +1285,13 @@ func (x *CreateQuoteCommand) Reset() {
 	}
 }
 
-func (x *CreateQuoteCommand) String() string {
+func (x *AddTaskDependencyCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateQuoteCommand) ProtoMessage() {}
+func (*AddTaskDependencyCommand) ProtoMessage() {}
 
-func (x *CreateQuoteCommand) ProtoReflect() protoreflect.Message {
+func (x *AddTaskDependencyCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -915,43 +1303,68 @@ func (x *CreateQuoteCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateQuoteCommand.ProtoReflect.Descriptor instead.
-func (*CreateQuoteCommand) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddTaskDependencyCommand.ProtoReflect.Descriptor instead.
+func (*AddTaskDependencyCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreateQuoteCommand) GetUserContext() *UserContext {
+func (x *AddTaskDependencyCommand) GetUserContext() *UserContext {
 	if x != nil {
 		return x.UserContext
 	}
 	return nil
 }
 
-func (x *CreateQuoteCommand) GetQuote() string {
+func (x *AddTaskDependencyCommand) GetId() string {
 	if x != nil {
-		return x.Quote
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateQuoteCommand) GetSagaId() string {
+func (x *AddTaskDependencyCommand) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+func (x *AddTaskDependencyCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AddTaskDependencyCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *AddTaskDependencyCommand) GetSagaId() string {
 	if x != nil && x.SagaId != nil {
 		return *x.SagaId
 	}
 	return ""
 }
 
-// -- Queries
-type GetQuoteQuery struct {
+type RemoveTaskDependencyCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	UserContext     *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id              string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	BlockerId       string       `protobuf:"bytes,3,opt,name=blockerId,proto3" json:"blockerId,omitempty"`
+	IdempotencyKey  *string      `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExpectedVersion *uint64      `protobuf:"varint,5,opt,name=expectedVersion,proto3,oneof" json:"expectedVersion,omitempty"`
+	SagaId          *string      `protobuf:"bytes,6,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
 }
 
-func (x *GetQuoteQuery) Reset() {
-	*x = GetQuoteQuery{}
+func (x *RemoveTaskDependencyCommand) Reset() {
+	*x = RemoveTaskDependencyCommand{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -959,13 +1372,13 @@ func (x *GetQuoteQuery) Reset() {
 	}
 }
 
-func (x *GetQuoteQuery) String() string {
+func (x *RemoveTaskDependencyCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQuoteQuery) ProtoMessage() {}
+func (*RemoveTaskDependencyCommand) ProtoMessage() {}
 
-func (x *GetQuoteQuery) ProtoReflect() protoreflect.Message {
+func (x *RemoveTaskDependencyCommand) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -977,29 +1390,101 @@ func (x *GetQuoteQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQuoteQuery.ProtoReflect.Descriptor instead.
-func (*GetQuoteQuery) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveTaskDependencyCommand.ProtoReflect.Descriptor instead.
+func (*RemoveTaskDependencyCommand) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetQuoteQuery) GetUserContext() *UserContext {
+func (x *RemoveTaskDependencyCommand) GetUserContext() *UserContext {
 	if x != nil {
 		return x.UserContext
 	}
 	return nil
 }
 
-// -- Data
-type QuoteData struct {
+func (x *RemoveTaskDependencyCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveTaskDependencyCommand) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+func (x *RemoveTaskDependencyCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RemoveTaskDependencyCommand) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *RemoveTaskDependencyCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+// -- Queries
+type ListTasksQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Quote *string `protobuf:"bytes,1,opt,name=quote,proto3,oneof" json:"quote,omitempty"`
+	UserContext  *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	PageNumber   uint32       `protobuf:"varint,2,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	CountPerPage uint32       `protobuf:"varint,3,opt,name=countPerPage,proto3" json:"countPerPage,omitempty"`
+	Status       *Status      `protobuf:"varint,4,opt,name=status,proto3,enum=tasks.Status,oneof" json:"status,omitempty"`
+	// created and updated ranges include the after time and exclude the before
+	// time
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAfter,proto3,oneof" json:"createdAfter,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdBefore,proto3,oneof" json:"createdBefore,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updatedAfter,proto3,oneof" json:"updatedAfter,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updatedBefore,proto3,oneof" json:"updatedBefore,omitempty"`
+	// search matches tasks with the text in the title or description ignoring
+	// case
+	Search        *string       `protobuf:"bytes,9,opt,name=search,proto3,oneof" json:"search,omitempty"`
+	SortBy        TaskSortField `protobuf:"varint,10,opt,name=sortBy,proto3,enum=tasks.TaskSortField" json:"sortBy,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,11,opt,name=sortDirection,proto3,enum=tasks.SortDirection" json:"sortDirection,omitempty"`
+	// allTasks lists every task instead of only the tasks the caller can read,
+	// only allowed for applications when enabled in the configuration
+	AllTasks bool `protobuf:"varint,12,opt,name=allTasks,proto3" json:"allTasks,omitempty"`
+	// pageToken continues the listing after the last task of the page the
+	// token was returned with, pageNumber must not be set along with it and the
+	// same filters and sorting must be used
+	PageToken *string `protobuf:"bytes,13,opt,name=pageToken,proto3,oneof" json:"pageToken,omitempty"`
+	// includeTotal counts the tasks matching the filters
+	IncludeTotal bool `protobuf:"varint,14,opt,name=includeTotal,proto3" json:"includeTotal,omitempty"`
+	// mine only lists the tasks assigned to the caller, only users can use it
+	Mine bool `protobuf:"varint,15,opt,name=mine,proto3" json:"mine,omitempty"`
+	// overdue only lists the tasks that are past their due date and aren't
+	// completed
+	Overdue bool `protobuf:"varint,16,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// parentId only lists the subtasks of the task, top level tasks are listed
+	// if empty
+	ParentId *string `protobuf:"bytes,17,opt,name=parentId,proto3,oneof" json:"parentId,omitempty"`
+	// metadata only lists the tasks with the top level metadata keys set to the
+	// values, numbers and booleans are compared by their json text and objects,
+	// arrays and nulls never match
+	Metadata map[string]string `protobuf:"bytes,18,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// metadataKeys only lists the tasks that have the top level metadata keys
+	MetadataKeys []string `protobuf:"bytes,19,rep,name=metadataKeys,proto3" json:"metadataKeys,omitempty"`
 }
 
-func (x *QuoteData) Reset() {
-	*x = QuoteData{}
+func (x *ListTasksQuery) Reset() {
+	*x = ListTasksQuery{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_contracts_models_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1007,13 +1492,13 @@ func (x *QuoteData) Reset() {
 	}
 }
 
-func (x *QuoteData) String() string {
+func (x *ListTasksQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuoteData) ProtoMessage() {}
+func (*ListTasksQuery) ProtoMessage() {}
 
-func (x *QuoteData) ProtoReflect() protoreflect.Message {
+func (x *ListTasksQuery) ProtoReflect() protoreflect.Message {
 	mi := &file_contracts_models_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1025,18 +1510,1484 @@ func (x *QuoteData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuoteData.ProtoReflect.Descriptor instead.
-func (*QuoteData) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTasksQuery.ProtoReflect.Descriptor instead.
+func (*ListTasksQuery) Descriptor() ([]byte, []int) {
 	return file_contracts_models_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *QuoteData) GetQuote() string {
-	if x != nil && x.Quote != nil {
-		return *x.Quote
+func (x *ListTasksQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetPageNumber() uint32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *ListTasksQuery) GetCountPerPage() uint32 {
+	if x != nil {
+		return x.CountPerPage
+	}
+	return 0
+}
+
+func (x *ListTasksQuery) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return Status_PENDING
+}
+
+func (x *ListTasksQuery) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetSearch() string {
+	if x != nil && x.Search != nil {
+		return *x.Search
+	}
+	return ""
+}
+
+func (x *ListTasksQuery) GetSortBy() TaskSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return TaskSortField_DATE_TIME_CREATED
+}
+
+func (x *ListTasksQuery) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_ASCENDING
+}
+
+func (x *ListTasksQuery) GetAllTasks() bool {
+	if x != nil {
+		return x.AllTasks
+	}
+	return false
+}
+
+func (x *ListTasksQuery) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTasksQuery) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *ListTasksQuery) GetMine() bool {
+	if x != nil {
+		return x.Mine
+	}
+	return false
+}
+
+func (x *ListTasksQuery) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *ListTasksQuery) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *ListTasksQuery) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListTasksQuery) GetMetadataKeys() []string {
+	if x != nil {
+		return x.MetadataKeys
+	}
+	return nil
+}
+
+type GetTaskQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetTaskQuery) Reset() {
+	*x = GetTaskQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskQuery) ProtoMessage() {}
+
+func (x *GetTaskQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskQuery.ProtoReflect.Descriptor instead.
+func (*GetTaskQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTaskQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *GetTaskQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTaskHistoryQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext  *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id           string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	PageNumber   uint32       `protobuf:"varint,3,opt,name=pageNumber,proto3" json:"pageNumber,omitempty"`
+	CountPerPage uint32       `protobuf:"varint,4,opt,name=countPerPage,proto3" json:"countPerPage,omitempty"`
+}
+
+func (x *GetTaskHistoryQuery) Reset() {
+	*x = GetTaskHistoryQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskHistoryQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryQuery) ProtoMessage() {}
+
+func (x *GetTaskHistoryQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryQuery.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTaskHistoryQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTaskHistoryQuery) GetPageNumber() uint32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryQuery) GetCountPerPage() uint32 {
+	if x != nil {
+		return x.CountPerPage
+	}
+	return 0
+}
+
+type SubscribeEventsQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	FromEventId uint64       `protobuf:"varint,2,opt,name=fromEventId,proto3" json:"fromEventId,omitempty"`
+}
+
+func (x *SubscribeEventsQuery) Reset() {
+	*x = SubscribeEventsQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEventsQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsQuery) ProtoMessage() {}
+
+func (x *SubscribeEventsQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsQuery.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubscribeEventsQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *SubscribeEventsQuery) GetFromEventId() uint64 {
+	if x != nil {
+		return x.FromEventId
+	}
+	return 0
+}
+
+type GetTaskAsOfQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext           `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version     *uint64                `protobuf:"varint,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	EventTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=eventTime,proto3,oneof" json:"eventTime,omitempty"`
+}
+
+func (x *GetTaskAsOfQuery) Reset() {
+	*x = GetTaskAsOfQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskAsOfQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskAsOfQuery) ProtoMessage() {}
+
+func (x *GetTaskAsOfQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskAsOfQuery.ProtoReflect.Descriptor instead.
+func (*GetTaskAsOfQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTaskAsOfQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *GetTaskAsOfQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTaskAsOfQuery) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *GetTaskAsOfQuery) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+type GetTaskDependenciesQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Id          string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetTaskDependenciesQuery) Reset() {
+	*x = GetTaskDependenciesQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskDependenciesQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskDependenciesQuery) ProtoMessage() {}
+
+func (x *GetTaskDependenciesQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskDependenciesQuery.ProtoReflect.Descriptor instead.
+func (*GetTaskDependenciesQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTaskDependenciesQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *GetTaskDependenciesQuery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// -- Data
+type TaskData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title       *string           `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description *string           `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status      *Status           `protobuf:"varint,3,opt,name=status,proto3,enum=tasks.Status,oneof" json:"status,omitempty"`
+	RandomMap   map[string]string `protobuf:"bytes,4,rep,name=randomMap,proto3" json:"randomMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Metadata    *structpb.Struct  `protobuf:"bytes,5,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	// access is only set on shared and unshared events and on assigned events
+	// granting the assignee access, the access level isn't set when unshared
+	Access   *TaskAccess `protobuf:"bytes,6,opt,name=access,proto3,oneof" json:"access,omitempty"`
+	Assignee *string     `protobuf:"bytes,7,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"`
+	// dueDate is the zero time (0001-01-01T00:00:00Z) when the due date was
+	// cleared
+	DueDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=dueDate,proto3,oneof" json:"dueDate,omitempty"`
+	// parentId is empty when the task was moved to the top level
+	ParentId *string `protobuf:"bytes,9,opt,name=parentId,proto3,oneof" json:"parentId,omitempty"`
+	// blockerId is only set on dependency added and removed events
+	BlockerId *string `protobuf:"bytes,10,opt,name=blockerId,proto3,oneof" json:"blockerId,omitempty"`
+}
+
+func (x *TaskData) Reset() {
+	*x = TaskData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskData) ProtoMessage() {}
+
+func (x *TaskData) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskData.ProtoReflect.Descriptor instead.
+func (*TaskData) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TaskData) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *TaskData) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *TaskData) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return Status_PENDING
+}
+
+func (x *TaskData) GetRandomMap() map[string]string {
+	if x != nil {
+		return x.RandomMap
+	}
+	return nil
+}
+
+func (x *TaskData) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *TaskData) GetAccess() *TaskAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+func (x *TaskData) GetAssignee() string {
+	if x != nil && x.Assignee != nil {
+		return *x.Assignee
+	}
+	return ""
+}
+
+func (x *TaskData) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *TaskData) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *TaskData) GetBlockerId() string {
+	if x != nil && x.BlockerId != nil {
+		return *x.BlockerId
+	}
+	return ""
+}
+
+type TaskAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserType    string       `protobuf:"bytes,1,opt,name=userType,proto3" json:"userType,omitempty"`
+	UserId      string       `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	AccessLevel *AccessLevel `protobuf:"varint,3,opt,name=accessLevel,proto3,enum=tasks.AccessLevel,oneof" json:"accessLevel,omitempty"`
+}
+
+func (x *TaskAccess) Reset() {
+	*x = TaskAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskAccess) ProtoMessage() {}
+
+func (x *TaskAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskAccess.ProtoReflect.Descriptor instead.
+func (*TaskAccess) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TaskAccess) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+func (x *TaskAccess) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TaskAccess) GetAccessLevel() AccessLevel {
+	if x != nil && x.AccessLevel != nil {
+		return *x.AccessLevel
+	}
+	return AccessLevel_READ
+}
+
+type TaskEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SagaId    *string                `protobuf:"bytes,2,opt,name=sagaId,proto3,oneof" json:"sagaId,omitempty"`
+	Stream    string                 `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	StreamId  string                 `protobuf:"bytes,4,opt,name=streamId,proto3" json:"streamId,omitempty"`
+	Version   uint64                 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Event     string                 `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
+	EventTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=eventTime,proto3" json:"eventTime,omitempty"`
+	Data      *TaskData              `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TaskEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetData() *TaskData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type TaskEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version         uint64                 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status          Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=tasks.Status" json:"status,omitempty"`
+	CreatedDateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdDateTime,proto3" json:"createdDateTime,omitempty"`
+	UpdatedDateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updatedDateTime,proto3" json:"updatedDateTime,omitempty"`
+	RandomMap       map[string]string      `protobuf:"bytes,8,rep,name=randomMap,proto3" json:"randomMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Metadata        *structpb.Struct       `protobuf:"bytes,9,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	// id of the user the task is assigned to, empty when unassigned
+	Assignee string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	DueDate  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=dueDate,proto3,oneof" json:"dueDate,omitempty"`
+	// id of the task this task is a subtask of, empty for top level tasks
+	ParentId string `protobuf:"bytes,12,opt,name=parentId,proto3" json:"parentId,omitempty"`
+}
+
+func (x *TaskEntity) Reset() {
+	*x = TaskEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEntity) ProtoMessage() {}
+
+func (x *TaskEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEntity.ProtoReflect.Descriptor instead.
+func (*TaskEntity) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TaskEntity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskEntity) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TaskEntity) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskEntity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskEntity) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_PENDING
+}
+
+func (x *TaskEntity) GetCreatedDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedDateTime
+	}
+	return nil
+}
+
+func (x *TaskEntity) GetUpdatedDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedDateTime
+	}
+	return nil
+}
+
+func (x *TaskEntity) GetRandomMap() map[string]string {
+	if x != nil {
+		return x.RandomMap
+	}
+	return nil
+}
+
+func (x *TaskEntity) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *TaskEntity) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *TaskEntity) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *TaskEntity) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type TaskEntityList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tasks []*TaskEntity `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// nextToken is set when the page is full, there may be more tasks to list
+	NextToken *string `protobuf:"bytes,2,opt,name=nextToken,proto3,oneof" json:"nextToken,omitempty"`
+	Total     *uint64 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+}
+
+func (x *TaskEntityList) Reset() {
+	*x = TaskEntityList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEntityList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEntityList) ProtoMessage() {}
+
+func (x *TaskEntityList) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEntityList.ProtoReflect.Descriptor instead.
+func (*TaskEntityList) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TaskEntityList) GetTasks() []*TaskEntity {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *TaskEntityList) GetNextToken() string {
+	if x != nil && x.NextToken != nil {
+		return *x.NextToken
+	}
+	return ""
+}
+
+func (x *TaskEntityList) GetTotal() uint64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+// TaskDependencyGraph the tasks a task is blocked by and the tasks blocked by
+// it, followed transitively
+type TaskDependencyGraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tasks of the graph the caller can read
+	Tasks []*TaskEntity `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// dependencies between the tasks of the graph, including the tasks the
+	// caller can't read
+	Dependencies []*TaskDependency `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+}
+
+func (x *TaskDependencyGraph) Reset() {
+	*x = TaskDependencyGraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskDependencyGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDependencyGraph) ProtoMessage() {}
+
+func (x *TaskDependencyGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDependencyGraph.ProtoReflect.Descriptor instead.
+func (*TaskDependencyGraph) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskDependencyGraph) GetTasks() []*TaskEntity {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *TaskDependencyGraph) GetDependencies() []*TaskDependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+type TaskDependency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId    string `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	BlockerId string `protobuf:"bytes,2,opt,name=blockerId,proto3" json:"blockerId,omitempty"`
+}
+
+func (x *TaskDependency) Reset() {
+	*x = TaskDependency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDependency) ProtoMessage() {}
+
+func (x *TaskDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDependency.ProtoReflect.Descriptor instead.
+func (*TaskDependency) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TaskDependency) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskDependency) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+type TaskEventList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*TaskEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *TaskEventList) Reset() {
+	*x = TaskEventList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEventList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEventList) ProtoMessage() {}
+
+func (x *TaskEventList) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEventList.ProtoReflect.Descriptor instead.
+func (*TaskEventList) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TaskEventList) GetEvents() []*TaskEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// [START quote domain]
+// -- Commands
+type CreateQuoteCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext    *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	Quote          string       `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	SagaId         *string      `protobuf:"bytes,3,opt,name=SagaId,proto3,oneof" json:"SagaId,omitempty"`
+	IdempotencyKey *string      `protobuf:"bytes,4,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+}
+
+func (x *CreateQuoteCommand) Reset() {
+	*x = CreateQuoteCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateQuoteCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuoteCommand) ProtoMessage() {}
+
+func (x *CreateQuoteCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuoteCommand.ProtoReflect.Descriptor instead.
+func (*CreateQuoteCommand) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateQuoteCommand) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *CreateQuoteCommand) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *CreateQuoteCommand) GetSagaId() string {
+	if x != nil && x.SagaId != nil {
+		return *x.SagaId
+	}
+	return ""
+}
+
+func (x *CreateQuoteCommand) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+// -- Queries
+type GetQuoteQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+}
+
+func (x *GetQuoteQuery) Reset() {
+	*x = GetQuoteQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuoteQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteQuery) ProtoMessage() {}
+
+func (x *GetQuoteQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteQuery.ProtoReflect.Descriptor instead.
+func (*GetQuoteQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetQuoteQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+// -- Data
+type QuoteData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote *string `protobuf:"bytes,1,opt,name=quote,proto3,oneof" json:"quote,omitempty"`
+}
+
+func (x *QuoteData) Reset() {
+	*x = QuoteData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteData) ProtoMessage() {}
+
+func (x *QuoteData) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteData.ProtoReflect.Descriptor instead.
+func (*QuoteData) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QuoteData) GetQuote() string {
+	if x != nil && x.Quote != nil {
+		return *x.Quote
+	}
+	return ""
+}
+
+// [START saga domain]
+// -- Commands
+type CompensateSagaCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	SagaId      string       `protobuf:"bytes,2,opt,name=sagaId,proto3" json:"sagaId,omitempty"`
+}
+
+func (x *CompensateSagaCommand) Reset() {
+	*x = CompensateSagaCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompensateSagaCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompensateSagaCommand) ProtoMessage() {}
+
+func (x *CompensateSagaCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompensateSagaCommand.ProtoReflect.Descriptor instead.
+func (*CompensateSagaCommand) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CompensateSagaCommand) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *CompensateSagaCommand) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+// -- Queries
+type GetSagaQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserContext *UserContext `protobuf:"bytes,1,opt,name=userContext,proto3" json:"userContext,omitempty"`
+	SagaId      string       `protobuf:"bytes,2,opt,name=sagaId,proto3" json:"sagaId,omitempty"`
+}
+
+func (x *GetSagaQuery) Reset() {
+	*x = GetSagaQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSagaQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSagaQuery) ProtoMessage() {}
+
+func (x *GetSagaQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSagaQuery.ProtoReflect.Descriptor instead.
+func (*GetSagaQuery) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetSagaQuery) GetUserContext() *UserContext {
+	if x != nil {
+		return x.UserContext
+	}
+	return nil
+}
+
+func (x *GetSagaQuery) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+// -- Data
+type SagaEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SagaId              string                 `protobuf:"bytes,1,opt,name=sagaId,proto3" json:"sagaId,omitempty"`
+	Status              string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	StepCount           uint64                 `protobuf:"varint,3,opt,name=stepCount,proto3" json:"stepCount,omitempty"`
+	LastEventId         uint64                 `protobuf:"varint,4,opt,name=lastEventId,proto3" json:"lastEventId,omitempty"`
+	CreatedDateTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdDateTime,proto3" json:"createdDateTime,omitempty"`
+	UpdatedDateTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedDateTime,proto3" json:"updatedDateTime,omitempty"`
+	CompensatedDateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=compensatedDateTime,proto3,oneof" json:"compensatedDateTime,omitempty"`
+}
+
+func (x *SagaEntity) Reset() {
+	*x = SagaEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contracts_models_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SagaEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SagaEntity) ProtoMessage() {}
+
+func (x *SagaEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_models_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SagaEntity.ProtoReflect.Descriptor instead.
+func (*SagaEntity) Descriptor() ([]byte, []int) {
+	return file_contracts_models_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SagaEntity) GetSagaId() string {
+	if x != nil {
+		return x.SagaId
+	}
+	return ""
+}
+
+func (x *SagaEntity) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
+func (x *SagaEntity) GetStepCount() uint64 {
+	if x != nil {
+		return x.StepCount
+	}
+	return 0
+}
+
+func (x *SagaEntity) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+func (x *SagaEntity) GetCreatedDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedDateTime
+	}
+	return nil
+}
+
+func (x *SagaEntity) GetUpdatedDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedDateTime
+	}
+	return nil
+}
+
+func (x *SagaEntity) GetCompensatedDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompensatedDateTime
+	}
+	return nil
+}
+
 var File_contracts_models_proto protoreflect.FileDescriptor
 
 var file_contracts_models_proto_rawDesc = []byte{
@@ -1054,7 +3005,7 @@ var file_contracts_models_proto_rawDesc = []byte{
 	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28,
 	0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
 	0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61,
+	0x72, 0x65, 0x73, 0x22, 0xaa, 0x04, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61,
 	0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65,
 	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65,
@@ -1064,138 +3015,574 @@ var file_contracts_models_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
 	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
 	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
-	0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22,
-	0x81, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x39, 0x0a, 0x07, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x02,
+	0x52, 0x07, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03,
+	0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x45, 0x0a,
+	0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x4d, 0x61, 0x70, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48,
+	0x04, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x1a, 0x3c,
+	0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x64,
+	0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x84, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x06,
+	0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
+	0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x02, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64,
+	0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xbf, 0x05, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12,
+	0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x48, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x39,
+	0x0a, 0x07, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x05, 0x52, 0x07, 0x64,
+	0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x44, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x44, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x45, 0x0a,
+	0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
+	0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x4d, 0x61, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x12, 0x38, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x06, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10,
+	0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x86, 0x02, 0x0a, 0x13, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x0f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01,
+	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x86, 0x02, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a,
+	0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61,
+	0x67, 0x61, 0x49, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xaf, 0x02, 0x0a, 0x15,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f,
 	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
 	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73,
 	0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b,
 	0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x53,
-	0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53,
-	0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67,
-	0x61, 0x49, 0x64, 0x22, 0xdd, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61,
-	0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xed, 0x02,
+	0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x0b,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12,
+	0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b,
+	0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02,
+	0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0x9e, 0x02,
+	0x0a, 0x0f, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01,
+	0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12,
+	0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x02, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f,
+	0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42,
+	0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0xa0,
+	0x02, 0x0a, 0x11, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b,
+	0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x02, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42,
+	0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b,
+	0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x22, 0xb9, 0x02, 0x0a, 0x12, 0x55, 0x6e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x54, 0x61, 0x73,
+	0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12,
+	0x2d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b,
+	0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02,
+	0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0xa9, 0x02,
+	0x0a, 0x18, 0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b,
+	0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61,
+	0x67, 0x61, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x06, 0x53, 0x61,
+	0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0xac, 0x02, 0x0a, 0x1b, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e,
+	0x63, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65,
 	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65,
 	0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
 	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x19, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01,
-	0x01, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x02, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67,
-	0x61, 0x49, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
-	0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75,
+	0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a,
+	0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67,
+	0x61, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x06, 0x53, 0x61, 0x67,
+	0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0xa0, 0x08, 0x0a, 0x0e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65,
+	0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x43, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x48, 0x01, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x45, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x02, 0x52, 0x0d, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a,
+	0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48,
+	0x03, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x45, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x04, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54,
+	0x61, 0x73, 0x6b, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x73, 0x6f,
+	0x72, 0x74, 0x42, 0x79, 0x12, 0x3a, 0x0a, 0x0d, 0x73, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0d, 0x73, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x61, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x06, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12,
+	0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x64,
+	0x75, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x75,
+	0x65, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x07, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x3f, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x12,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x54, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75,
 	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
 	0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
 	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09,
-	0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61, 0x67, 0x61, 0x49,
-	0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22,
-	0x8a, 0x01, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x51, 0x75, 0x65,
+	0x64, 0x22, 0x9f, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50,
+	0x61, 0x67, 0x65, 0x22, 0x6e, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x22, 0xd0, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x41,
+	0x73, 0x4f, 0x66, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48,
+	0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a,
+	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x01, 0x52, 0x09,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x60, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73,
+	0x6b, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x51, 0x75, 0x65,
 	0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e,
 	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61,
-	0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x50, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x22, 0xe0, 0x02, 0x0a,
-	0x08, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x69, 0x74,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61,
-	0x73, 0x6b, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x02, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f,
-	0x6d, 0x4d, 0x61, 0x70, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x61, 0x73,
-	0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x61, 0x6e, 0x64,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xef, 0x04, 0x0a, 0x08, 0x54, 0x61, 0x73,
+	0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x02, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54,
+	0x61, 0x73, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61,
+	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61,
+	0x70, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x03, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x06, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x04,
+	0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x61,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52,
+	0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x07,
+	0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x06, 0x52, 0x07, 0x64, 0x75, 0x65,
+	0x44, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x07, 0x52, 0x08, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x72, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x08, 0x52, 0x09, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x0a, 0x54,
+	0x61, 0x73, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a,
+	0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x86, 0x02, 0x0a, 0x09, 0x54, 0x61, 0x73,
+	0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x22, 0xe5, 0x04, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x44, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d,
+	0x61, 0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x61, 0x6e, 0x64,
 	0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x64,
 	0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x48, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x1a,
-	0x3c, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x08, 0x0a,
-	0x06, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x86, 0x02, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a,
-	0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x38,
-	0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54,
-	0x61, 0x73, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x09, 0x0a,
-	0x07, 0x5f, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x22, 0xa1, 0x02, 0x0a, 0x0a, 0x54, 0x61, 0x73,
-	0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x74, 0x61, 0x73, 0x6b,
-	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x44, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54,
-	0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x39, 0x0a, 0x0e,
-	0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x27,
-	0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34,
-	0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x06, 0x53, 0x61,
-	0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x53, 0x61,
-	0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61,
-	0x49, 0x64, 0x22, 0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x30, 0x0a, 0x09, 0x51, 0x75, 0x6f,
-	0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x88, 0x01,
-	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x2a, 0x32, 0x0a, 0x06, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
-	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x01,
-	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x42,
-	0x20, 0x5a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x74, 0x6f, 0x64, 0x6f, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x48, 0x00, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x64,
+	0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x01, 0x52, 0x07, 0x64, 0x75, 0x65, 0x44,
+	0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x4d, 0x61, 0x70, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x64, 0x75, 0x65, 0x44, 0x61, 0x74, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x0e, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x05,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x05,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x79, 0x0a, 0x13, 0x54,
+	0x61, 0x73, 0x6b, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x12, 0x27, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x39, 0x0a, 0x0c, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x0e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x73, 0x6b,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x22, 0x39,
+	0x0a, 0x0d, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x12, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x06,
+	0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
+	0x53, 0x61, 0x67, 0x61, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65,
+	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x61, 0x67, 0x61, 0x49,
+	0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x22, 0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73,
+	0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b,
+	0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x30, 0x0a, 0x09, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x65, 0x0a,
+	0x15, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x65, 0x53, 0x61, 0x67, 0x61, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x61,
+	0x67, 0x61, 0x49, 0x64, 0x22, 0x5c, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x61, 0x67, 0x61, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x73, 0x6b,
+	0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0b, 0x75,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x61,
+	0x67, 0x61, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x61, 0x67, 0x61,
+	0x49, 0x64, 0x22, 0xf3, 0x02, 0x0a, 0x0a, 0x53, 0x61, 0x67, 0x61, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x61, 0x67, 0x61, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x65, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x74, 0x65, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x44, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x51, 0x0a,
+	0x13, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x6e,
+	0x73, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01,
+	0x42, 0x16, 0x0a, 0x14, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x2a, 0x5a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12,
+	0x0c, 0x0a, 0x08, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
+	0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x56,
+	0x49, 0x45, 0x57, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c,
+	0x45, 0x44, 0x10, 0x05, 0x2a, 0x54, 0x0a, 0x0d, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x6f, 0x72, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x49,
+	0x4d, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
+	0x44, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x49, 0x54, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x03, 0x2a, 0x2e, 0x0a, 0x0d, 0x53, 0x6f,
+	0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x0a, 0x09, 0x41,
+	0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45,
+	0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x2a, 0x27, 0x0a, 0x0b, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41,
+	0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x57, 0x52, 0x49, 0x54,
+	0x45, 0x10, 0x01, 0x42, 0x39, 0x5a, 0x37, 0x74, 0x65, 0x63, 0x68, 0x75, 0x6e, 0x69, 0x63, 0x6f,
+	0x72, 0x6e, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x75, 0x64, 0x63, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x74, 0x6f, 0x64, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1210,51 +3597,120 @@ func file_contracts_models_proto_rawDescGZIP() []byte {
 	return file_contracts_models_proto_rawDescData
 }
 
-var file_contracts_models_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_contracts_models_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_contracts_models_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_contracts_models_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_contracts_models_proto_goTypes = []interface{}{
-	(Status)(0),                   // 0: tasks.Status
-	(*UserContext)(nil),           // 1: tasks.UserContext
-	(*CreateTaskCommand)(nil),     // 2: tasks.CreateTaskCommand
-	(*DeleteTaskCommand)(nil),     // 3: tasks.DeleteTaskCommand
-	(*UpdateTaskCommand)(nil),     // 4: tasks.UpdateTaskCommand
-	(*ProgressTaskCommand)(nil),   // 5: tasks.ProgressTaskCommand
-	(*CompleteTaskCommand)(nil),   // 6: tasks.CompleteTaskCommand
-	(*ListTasksQuery)(nil),        // 7: tasks.ListTasksQuery
-	(*TaskData)(nil),              // 8: tasks.TaskData
-	(*TaskEvent)(nil),             // 9: tasks.TaskEvent
-	(*TaskEntity)(nil),            // 10: tasks.TaskEntity
-	(*TaskEntityList)(nil),        // 11: tasks.TaskEntityList
-	(*CreateQuoteCommand)(nil),    // 12: tasks.CreateQuoteCommand
-	(*GetQuoteQuery)(nil),         // 13: tasks.GetQuoteQuery
-	(*QuoteData)(nil),             // 14: tasks.QuoteData
-	nil,                           // 15: tasks.TaskData.RandomMapEntry
-	(*structpb.Struct)(nil),       // 16: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(Status)(0),                         // 0: tasks.Status
+	(TaskSortField)(0),                  // 1: tasks.TaskSortField
+	(SortDirection)(0),                  // 2: tasks.SortDirection
+	(AccessLevel)(0),                    // 3: tasks.AccessLevel
+	(*UserContext)(nil),                 // 4: tasks.UserContext
+	(*CreateTaskCommand)(nil),           // 5: tasks.CreateTaskCommand
+	(*DeleteTaskCommand)(nil),           // 6: tasks.DeleteTaskCommand
+	(*UpdateTaskCommand)(nil),           // 7: tasks.UpdateTaskCommand
+	(*ProgressTaskCommand)(nil),         // 8: tasks.ProgressTaskCommand
+	(*CompleteTaskCommand)(nil),         // 9: tasks.CompleteTaskCommand
+	(*TransitionTaskCommand)(nil),       // 10: tasks.TransitionTaskCommand
+	(*ShareTaskCommand)(nil),            // 11: tasks.ShareTaskCommand
+	(*MoveTaskCommand)(nil),             // 12: tasks.MoveTaskCommand
+	(*AssignTaskCommand)(nil),           // 13: tasks.AssignTaskCommand
+	(*UnshareTaskCommand)(nil),          // 14: tasks.UnshareTaskCommand
+	(*AddTaskDependencyCommand)(nil),    // 15: tasks.AddTaskDependencyCommand
+	(*RemoveTaskDependencyCommand)(nil), // 16: tasks.RemoveTaskDependencyCommand
+	(*ListTasksQuery)(nil),              // 17: tasks.ListTasksQuery
+	(*GetTaskQuery)(nil),                // 18: tasks.GetTaskQuery
+	(*GetTaskHistoryQuery)(nil),         // 19: tasks.GetTaskHistoryQuery
+	(*SubscribeEventsQuery)(nil),        // 20: tasks.SubscribeEventsQuery
+	(*GetTaskAsOfQuery)(nil),            // 21: tasks.GetTaskAsOfQuery
+	(*GetTaskDependenciesQuery)(nil),    // 22: tasks.GetTaskDependenciesQuery
+	(*TaskData)(nil),                    // 23: tasks.TaskData
+	(*TaskAccess)(nil),                  // 24: tasks.TaskAccess
+	(*TaskEvent)(nil),                   // 25: tasks.TaskEvent
+	(*TaskEntity)(nil),                  // 26: tasks.TaskEntity
+	(*TaskEntityList)(nil),              // 27: tasks.TaskEntityList
+	(*TaskDependencyGraph)(nil),         // 28: tasks.TaskDependencyGraph
+	(*TaskDependency)(nil),              // 29: tasks.TaskDependency
+	(*TaskEventList)(nil),               // 30: tasks.TaskEventList
+	(*CreateQuoteCommand)(nil),          // 31: tasks.CreateQuoteCommand
+	(*GetQuoteQuery)(nil),               // 32: tasks.GetQuoteQuery
+	(*QuoteData)(nil),                   // 33: tasks.QuoteData
+	(*CompensateSagaCommand)(nil),       // 34: tasks.CompensateSagaCommand
+	(*GetSagaQuery)(nil),                // 35: tasks.GetSagaQuery
+	(*SagaEntity)(nil),                  // 36: tasks.SagaEntity
+	nil,                                 // 37: tasks.CreateTaskCommand.RandomMapEntry
+	nil,                                 // 38: tasks.UpdateTaskCommand.RandomMapEntry
+	nil,                                 // 39: tasks.ListTasksQuery.MetadataEntry
+	nil,                                 // 40: tasks.TaskData.RandomMapEntry
+	nil,                                 // 41: tasks.TaskEntity.RandomMapEntry
+	(*timestamppb.Timestamp)(nil),       // 42: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),             // 43: google.protobuf.Struct
 }
 var file_contracts_models_proto_depIdxs = []int32{
-	1,  // 0: tasks.CreateTaskCommand.userContext:type_name -> tasks.UserContext
-	1,  // 1: tasks.DeleteTaskCommand.userContext:type_name -> tasks.UserContext
-	1,  // 2: tasks.UpdateTaskCommand.userContext:type_name -> tasks.UserContext
-	1,  // 3: tasks.ProgressTaskCommand.userContext:type_name -> tasks.UserContext
-	1,  // 4: tasks.CompleteTaskCommand.userContext:type_name -> tasks.UserContext
-	1,  // 5: tasks.ListTasksQuery.userContext:type_name -> tasks.UserContext
-	0,  // 6: tasks.TaskData.status:type_name -> tasks.Status
-	15, // 7: tasks.TaskData.randomMap:type_name -> tasks.TaskData.RandomMapEntry
-	16, // 8: tasks.TaskData.metadata:type_name -> google.protobuf.Struct
-	17, // 9: tasks.TaskEvent.eventTime:type_name -> google.protobuf.Timestamp
-	8,  // 10: tasks.TaskEvent.data:type_name -> tasks.TaskData
-	0,  // 11: tasks.TaskEntity.status:type_name -> tasks.Status
-	17, // 12: tasks.TaskEntity.createdDateTime:type_name -> google.protobuf.Timestamp
-	17, // 13: tasks.TaskEntity.updatedDateTime:type_name -> google.protobuf.Timestamp
-	10, // 14: tasks.TaskEntityList.tasks:type_name -> tasks.TaskEntity
-	1,  // 15: tasks.CreateQuoteCommand.userContext:type_name -> tasks.UserContext
-	1,  // 16: tasks.GetQuoteQuery.userContext:type_name -> tasks.UserContext
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	4,  // 0: tasks.CreateTaskCommand.userContext:type_name -> tasks.UserContext
+	42, // 1: tasks.CreateTaskCommand.dueDate:type_name -> google.protobuf.Timestamp
+	37, // 2: tasks.CreateTaskCommand.randomMap:type_name -> tasks.CreateTaskCommand.RandomMapEntry
+	43, // 3: tasks.CreateTaskCommand.metadata:type_name -> google.protobuf.Struct
+	4,  // 4: tasks.DeleteTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 5: tasks.UpdateTaskCommand.userContext:type_name -> tasks.UserContext
+	42, // 6: tasks.UpdateTaskCommand.dueDate:type_name -> google.protobuf.Timestamp
+	38, // 7: tasks.UpdateTaskCommand.randomMap:type_name -> tasks.UpdateTaskCommand.RandomMapEntry
+	43, // 8: tasks.UpdateTaskCommand.metadata:type_name -> google.protobuf.Struct
+	4,  // 9: tasks.ProgressTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 10: tasks.CompleteTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 11: tasks.TransitionTaskCommand.userContext:type_name -> tasks.UserContext
+	0,  // 12: tasks.TransitionTaskCommand.status:type_name -> tasks.Status
+	4,  // 13: tasks.ShareTaskCommand.userContext:type_name -> tasks.UserContext
+	3,  // 14: tasks.ShareTaskCommand.accessLevel:type_name -> tasks.AccessLevel
+	4,  // 15: tasks.MoveTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 16: tasks.AssignTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 17: tasks.UnshareTaskCommand.userContext:type_name -> tasks.UserContext
+	4,  // 18: tasks.AddTaskDependencyCommand.userContext:type_name -> tasks.UserContext
+	4,  // 19: tasks.RemoveTaskDependencyCommand.userContext:type_name -> tasks.UserContext
+	4,  // 20: tasks.ListTasksQuery.userContext:type_name -> tasks.UserContext
+	0,  // 21: tasks.ListTasksQuery.status:type_name -> tasks.Status
+	42, // 22: tasks.ListTasksQuery.createdAfter:type_name -> google.protobuf.Timestamp
+	42, // 23: tasks.ListTasksQuery.createdBefore:type_name -> google.protobuf.Timestamp
+	42, // 24: tasks.ListTasksQuery.updatedAfter:type_name -> google.protobuf.Timestamp
+	42, // 25: tasks.ListTasksQuery.updatedBefore:type_name -> google.protobuf.Timestamp
+	1,  // 26: tasks.ListTasksQuery.sortBy:type_name -> tasks.TaskSortField
+	2,  // 27: tasks.ListTasksQuery.sortDirection:type_name -> tasks.SortDirection
+	39, // 28: tasks.ListTasksQuery.metadata:type_name -> tasks.ListTasksQuery.MetadataEntry
+	4,  // 29: tasks.GetTaskQuery.userContext:type_name -> tasks.UserContext
+	4,  // 30: tasks.GetTaskHistoryQuery.userContext:type_name -> tasks.UserContext
+	4,  // 31: tasks.SubscribeEventsQuery.userContext:type_name -> tasks.UserContext
+	4,  // 32: tasks.GetTaskAsOfQuery.userContext:type_name -> tasks.UserContext
+	42, // 33: tasks.GetTaskAsOfQuery.eventTime:type_name -> google.protobuf.Timestamp
+	4,  // 34: tasks.GetTaskDependenciesQuery.userContext:type_name -> tasks.UserContext
+	0,  // 35: tasks.TaskData.status:type_name -> tasks.Status
+	40, // 36: tasks.TaskData.randomMap:type_name -> tasks.TaskData.RandomMapEntry
+	43, // 37: tasks.TaskData.metadata:type_name -> google.protobuf.Struct
+	24, // 38: tasks.TaskData.access:type_name -> tasks.TaskAccess
+	42, // 39: tasks.TaskData.dueDate:type_name -> google.protobuf.Timestamp
+	3,  // 40: tasks.TaskAccess.accessLevel:type_name -> tasks.AccessLevel
+	42, // 41: tasks.TaskEvent.eventTime:type_name -> google.protobuf.Timestamp
+	23, // 42: tasks.TaskEvent.data:type_name -> tasks.TaskData
+	0,  // 43: tasks.TaskEntity.status:type_name -> tasks.Status
+	42, // 44: tasks.TaskEntity.createdDateTime:type_name -> google.protobuf.Timestamp
+	42, // 45: tasks.TaskEntity.updatedDateTime:type_name -> google.protobuf.Timestamp
+	41, // 46: tasks.TaskEntity.randomMap:type_name -> tasks.TaskEntity.RandomMapEntry
+	43, // 47: tasks.TaskEntity.metadata:type_name -> google.protobuf.Struct
+	42, // 48: tasks.TaskEntity.dueDate:type_name -> google.protobuf.Timestamp
+	26, // 49: tasks.TaskEntityList.tasks:type_name -> tasks.TaskEntity
+	26, // 50: tasks.TaskDependencyGraph.tasks:type_name -> tasks.TaskEntity
+	29, // 51: tasks.TaskDependencyGraph.dependencies:type_name -> tasks.TaskDependency
+	25, // 52: tasks.TaskEventList.events:type_name -> tasks.TaskEvent
+	4,  // 53: tasks.CreateQuoteCommand.userContext:type_name -> tasks.UserContext
+	4,  // 54: tasks.GetQuoteQuery.userContext:type_name -> tasks.UserContext
+	4,  // 55: tasks.CompensateSagaCommand.userContext:type_name -> tasks.UserContext
+	4,  // 56: tasks.GetSagaQuery.userContext:type_name -> tasks.UserContext
+	42, // 57: tasks.SagaEntity.createdDateTime:type_name -> google.protobuf.Timestamp
+	42, // 58: tasks.SagaEntity.updatedDateTime:type_name -> google.protobuf.Timestamp
+	42, // 59: tasks.SagaEntity.compensatedDateTime:type_name -> google.protobuf.Timestamp
+	60, // [60:60] is the sub-list for method output_type
+	60, // [60:60] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_contracts_models_proto_init() }
@@ -1336,7 +3792,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListTasksQuery); i {
+			switch v := v.(*TransitionTaskCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1348,7 +3804,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskData); i {
+			switch v := v.(*ShareTaskCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1360,7 +3816,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskEvent); i {
+			switch v := v.(*MoveTaskCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1372,7 +3828,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskEntity); i {
+			switch v := v.(*AssignTaskCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1384,7 +3840,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskEntityList); i {
+			switch v := v.(*UnshareTaskCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1396,7 +3852,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateQuoteCommand); i {
+			switch v := v.(*AddTaskDependencyCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1408,7 +3864,7 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetQuoteQuery); i {
+			switch v := v.(*RemoveTaskDependencyCommand); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1420,6 +3876,198 @@ func file_contracts_models_proto_init() {
 			}
 		}
 		file_contracts_models_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTasksQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskHistoryQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskAsOfQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskDependenciesQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskAccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEntityList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskDependencyGraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskDependency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEventList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateQuoteCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuoteQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*QuoteData); i {
 			case 0:
 				return &v.state
@@ -1431,23 +4079,72 @@ func file_contracts_models_proto_init() {
 				return nil
 			}
 		}
+		file_contracts_models_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompensateSagaCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSagaQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contracts_models_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SagaEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_contracts_models_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[2].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[6].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[7].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[10].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	file_contracts_models_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[17].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[21].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[29].OneofWrappers = []interface{}{}
+	file_contracts_models_proto_msgTypes[32].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_contracts_models_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   15,
+			NumEnums:      4,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -4,6 +4,7 @@ package domain
 import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
 
 	"github.com/google/wire"
 )
@@ -12,4 +13,5 @@ import (
 var DependencySet = wire.NewSet(
 	tasks.NewService,
 	quotes.NewService,
+	sagas.NewService,
 )
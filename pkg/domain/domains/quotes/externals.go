@@ -11,4 +11,10 @@ type IRepository interface {
 		sagaID *string,
 		quote string,
 	) (*QuoteEvent, error)
+	Delete(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+	) (*QuoteEvent, error)
 }
@@ -38,8 +38,8 @@ func (s *Service) GetRandomQuote(
 ) (res *contracts.QuoteData, err error) {
 	lgr := s.lgrf.Create(ctx)
 	q, err := s.repo.GetRandom(ctx)
-	if err == nil {
-		lgr.Error("failed to create quote", zap.Error(err))
+	if err != nil {
+		lgr.Error("failed to get quote", zap.Error(err))
 		return nil, err
 	}
 	res = q.ToContract()
@@ -70,3 +70,23 @@ func (s *Service) CreateQuote(
 	res = q.Data.ToContract()
 	return
 }
+
+// CompensateQuote undoes the creation of a quote by a saga, quotes can't be
+// changed after they are created so compensating a quote deletes it
+func (s *Service) CompensateQuote(
+	ctx context.Context,
+	sagaID string,
+	id string,
+	fromVersion uint64,
+) error {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("compensating quote", zap.String("id", id))
+	if fromVersion != 0 {
+		return nil
+	}
+	_, err := s.repo.Delete(ctx, id, &sagaID, fromVersion+1)
+	if err != nil {
+		lgr.Error("failed to delete quote", zap.Error(err))
+	}
+	return err
+}
@@ -2,8 +2,17 @@ package tasks
 
 import (
 	"context"
+	"time"
 )
 
+// IMetadataValidator validates the metadata set on tasks
+type IMetadataValidator interface {
+	// Validate checks the metadata against the schema configured for the user
+	// type, the metadata of user types without a schema isn't validated. The
+	// error describes the first violation found
+	Validate(userType string, metadata map[string]interface{}) error
+}
+
 // IRepository repo interface for handling tasks data
 type IRepository interface {
 	Create(
@@ -16,11 +25,20 @@ type IRepository interface {
 		ctx context.Context,
 		id string,
 	) (*Task, error)
+	// List gives a page of the tasks matching the filters of the options in
+	// the order of the options
 	List(
 		ctx context.Context,
+		opts ListOptions,
 		countPerPage int,
 		pageNumber int,
 	) ([]Task, error)
+	// Count counts the tasks matching the filters of the options, the After
+	// task of the options is ignored
+	Count(
+		ctx context.Context,
+		opts ListOptions,
+	) (uint64, error)
 	Delete(
 		ctx context.Context,
 		id string,
@@ -34,4 +52,150 @@ type IRepository interface {
 		version uint64,
 		data TaskData,
 	) (*TaskEvent, error)
+	// Transition writes the event of a transition of the workflow moving the
+	// task to the status
+	Transition(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		event string,
+		status string,
+	) (*TaskEvent, error)
+	// Assign writes an event assigning the task to a user, an empty assignee
+	// unassigns the task. The access is set when the assignee is granted
+	// access to the task
+	Assign(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		assignee string,
+		access *Access,
+	) (*TaskEvent, error)
+	// Move writes an event moving the task under another task, an empty
+	// parent moves the task to the top level
+	Move(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		parentID string,
+	) (*TaskEvent, error)
+	// LockHierarchy serializes the changes to the task hierarchy, the lock is
+	// held until the transaction of the context ends so the hierarchy read
+	// after taking it can't change before the changes are written
+	LockHierarchy(ctx context.Context) error
+	// LockDependencies serializes the changes to the task dependencies, the
+	// lock is held until the transaction of the context ends so the
+	// dependencies read after taking it can't change before the changes are
+	// written
+	LockDependencies(ctx context.Context) error
+	// AddDependency writes an event blocking the task by another task and
+	// records the dependency
+	AddDependency(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		blockerID string,
+	) (*TaskEvent, error)
+	// RemoveDependency writes an event unblocking the task from another task
+	// and removes the dependency
+	RemoveDependency(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		blockerID string,
+	) (*TaskEvent, error)
+	// ListBlockers lists the ids of the tasks blocking the task, the
+	// dependencies of a task are removed along with it
+	ListBlockers(
+		ctx context.Context,
+		id string,
+	) ([]string, error)
+	// ListBlocked lists the ids of the tasks blocked by the task
+	ListBlocked(
+		ctx context.Context,
+		id string,
+	) ([]string, error)
+	// Share writes an event granting or changing the access of a user, the
+	// data of the task is left as is
+	Share(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		access Access,
+	) (*TaskEvent, error)
+	// Unshare writes an event revoking the access of a user, the data of the
+	// task is left as is
+	Unshare(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		access Access,
+	) (*TaskEvent, error)
+	// FireSchedule writes the event of a schedule, the due date the schedule
+	// was made for is kept in the event data
+	FireSchedule(
+		ctx context.Context,
+		id string,
+		version uint64,
+		event string,
+		dueDate time.Time,
+	) (*TaskEvent, error)
+	// SetSchedules replaces the pending schedules of the task, the schedules
+	// are removed if none are given
+	SetSchedules(
+		ctx context.Context,
+		id string,
+		schedules []Schedule,
+	) error
+	// ClaimDueSchedule removes and gives the earliest schedule due at the
+	// time, nil if there are none, schedules claimed by other transactions
+	// that are still open are skipped so each schedule is only fired once
+	ClaimDueSchedule(
+		ctx context.Context,
+		now time.Time,
+	) (*Schedule, error)
+	// Compensate writes an event restoring the task to the given data
+	Compensate(
+		ctx context.Context,
+		id string,
+		sagaID *string,
+		version uint64,
+		data TaskData,
+	) (*TaskEvent, error)
+	ListEvents(
+		ctx context.Context,
+		id string,
+		countPerPage int,
+		pageNumber int,
+	) ([]TaskEvent, error)
+	// ListEventsAfter lists the events of all tasks that come after the event
+	// with the given id, events committed later must never be listed before
+	// the events already listed so none are skipped by subscribers
+	ListEventsAfter(
+		ctx context.Context,
+		afterID uint64,
+		count int,
+	) ([]TaskEvent, error)
+	ListEventsUntil(
+		ctx context.Context,
+		id string,
+		fromVersion uint64,
+		version *uint64,
+		eventTime *time.Time,
+	) ([]TaskEvent, error)
+	// GetSnapshot provides the latest snapshot of the task at or before the
+	// given version or event time, nil if there are no snapshots
+	GetSnapshot(
+		ctx context.Context,
+		id string,
+		version *uint64,
+		eventTime *time.Time,
+	) (*Task, error)
 }
@@ -1,9 +1,16 @@
 package tasks
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
@@ -16,6 +23,44 @@ type TaskData struct {
 	Status      *string
 	RandomMap   map[string]string
 	Metadata    map[string]interface{}
+	// Access is only set on shared and unshared events and on assigned events
+	// granting the assignee access
+	Access *Access
+	// Assignee is set to an empty string when the task is unassigned
+	Assignee *string
+	// DueDate is set to the zero time when the due date is cleared
+	DueDate *time.Time
+	// ParentID is set to an empty string when the task is moved to the top
+	// level
+	ParentID *string
+	// BlockerID is only set on dependency added and removed events
+	BlockerID *string
+}
+
+// Access the access of a user to a task, the permissions are the acl flags
+// granted and are zero when the access was revoked
+type Access struct {
+	UserType    string
+	UserID      string
+	Permissions int
+	// PreviousPermissions the permissions the user had before, kept so the
+	// change can be undone when compensating a saga
+	PreviousPermissions int
+}
+
+// ToContract maps the access to the contract, the access level is left unset
+// when there are no permissions
+func (a *Access) ToContract() *contracts.TaskAccess {
+	cntr := &contracts.TaskAccess{
+		UserType: a.UserType,
+		UserId:   a.UserID,
+	}
+	if a.Permissions&acl.Write != 0 {
+		cntr.AccessLevel = contracts.AccessLevel_READ_WRITE.Enum()
+	} else if a.Permissions&acl.Read != 0 {
+		cntr.AccessLevel = contracts.AccessLevel_READ.Enum()
+	}
+	return cntr
 }
 
 func (t *TaskData) ToContract() (*contracts.TaskData, error) {
@@ -23,6 +68,12 @@ func (t *TaskData) ToContract() (*contracts.TaskData, error) {
 		Title:       t.Title,
 		Description: t.Description,
 		RandomMap:   t.RandomMap,
+		Assignee:    t.Assignee,
+		ParentId:    t.ParentID,
+		BlockerId:   t.BlockerID,
+	}
+	if t.DueDate != nil {
+		cntr.DueDate = timestamppb.New(*t.DueDate)
 	}
 	if t.Status != nil {
 		if s, ok := contracts.Status_value[*t.Status]; ok {
@@ -39,6 +90,9 @@ func (t *TaskData) ToContract() (*contracts.TaskData, error) {
 			return nil, err
 		}
 	}
+	if t.Access != nil {
+		cntr.Access = t.Access.ToContract()
+	}
 	return cntr, nil
 }
 
@@ -54,6 +108,221 @@ func (*TaskData) ToContractSlice(in []TaskData) ([]*contracts.TaskData, error) {
 	return res, nil
 }
 
+// Options configuration of the tasks service
+type Options struct {
+	// AppsCanListAllTasks allows applications to list every task regardless of
+	// the acl when they explicitly ask for it
+	AppsCanListAllTasks bool
+	// ReminderLeadTime how long before the due date of a task the reminder is
+	// due, no reminders are scheduled if zero
+	ReminderLeadTime time.Duration
+	// SchedulerInterval how often the scheduler checks for due schedules
+	SchedulerInterval time.Duration
+	// SchedulerBatchSize maximum number of schedules fired per check
+	SchedulerBatchSize int
+	// SubtaskDeletePolicy what happens to the subtasks of a task being deleted
+	SubtaskDeletePolicy SubtaskDeletePolicy
+	// Workflow the statuses tasks can be moved between
+	Workflow Workflow
+}
+
+// SubtaskDeletePolicy policy for deleting tasks that have subtasks
+type SubtaskDeletePolicy string
+
+const (
+	// SubtaskDeleteBlock tasks can't be deleted until their subtasks are
+	SubtaskDeleteBlock SubtaskDeletePolicy = "block"
+	// SubtaskDeleteCascade the subtasks are deleted along with the task
+	SubtaskDeleteCascade SubtaskDeletePolicy = "cascade"
+)
+
+// Names of the guards known to the service, the transitions of a workflow can
+// only name these
+const (
+	// GuardBlockersCompleted passes once all the tasks blocking the task are
+	// in a terminal status
+	GuardBlockersCompleted = "blockersCompleted"
+	// GuardSubtasksCompleted passes once all the subtasks of the task are in a
+	// terminal status
+	GuardSubtasksCompleted = "subtasksCompleted"
+)
+
+// Transition a move between statuses allowed by the workflow, the guards have
+// to pass for the transition to be taken and the event is written on the task
+// stream when it is
+type Transition struct {
+	Event  string   `json:"event"`
+	From   []string `json:"from"`
+	To     string   `json:"to"`
+	Guards []string `json:"guards,omitempty"`
+}
+
+// Workflow the transitions allowed between the statuses of tasks, tasks are
+// always created as pending
+type Workflow struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+// DefaultWorkflow the workflow used when none is configured, tasks are
+// progressed once they aren't blocked and completed once their subtasks are
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Transitions: []Transition{
+			{
+				Event:  common.EventProgressed,
+				From:   []string{contracts.Status_PENDING.String()},
+				To:     contracts.Status_PROGRESS.String(),
+				Guards: []string{GuardBlockersCompleted},
+			},
+			{
+				Event:  common.EventCompleted,
+				From:   []string{contracts.Status_PROGRESS.String()},
+				To:     contracts.Status_COMPLETED.String(),
+				Guards: []string{GuardSubtasksCompleted},
+			},
+		},
+	}
+}
+
+// Find gives the transition moving tasks from a status to another, nil if
+// the workflow doesn't allow it
+func (w *Workflow) Find(from string, to string) *Transition {
+	for idx := range w.Transitions {
+		if w.Transitions[idx].To != to {
+			continue
+		}
+		for _, status := range w.Transitions[idx].From {
+			if status == from {
+				return &w.Transitions[idx]
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the workflow only uses known statuses and guards, that
+// there's at most one transition between two statuses and that the events of
+// the transitions are unique and don't clash with the other task events
+func (w *Workflow) Validate() error {
+	guards := map[string]bool{
+		GuardBlockersCompleted: true,
+		GuardSubtasksCompleted: true,
+	}
+	reserved := map[string]bool{
+		common.EventCreated:           true,
+		common.EventUpdated:           true,
+		common.EventDeleted:           true,
+		common.EventCompensated:       true,
+		common.EventShared:            true,
+		common.EventUnshared:          true,
+		common.EventAssigned:          true,
+		common.EventMoved:             true,
+		common.EventReminderDue:       true,
+		common.EventOverdue:           true,
+		common.EventDependencyAdded:   true,
+		common.EventDependencyRemoved: true,
+	}
+	if len(w.Transitions) == 0 {
+		return fmt.Errorf("workflow has no transitions")
+	}
+	for idx, trn := range w.Transitions {
+		if trn.Event == "" || reserved[trn.Event] {
+			return fmt.Errorf(
+				"transition %d has an invalid event %q",
+				idx,
+				trn.Event,
+			)
+		}
+		reserved[trn.Event] = true
+		if _, ok := contracts.Status_value[trn.To]; !ok {
+			return fmt.Errorf(
+				"transition %s has an unknown status %q",
+				trn.Event,
+				trn.To,
+			)
+		}
+		if len(trn.From) == 0 {
+			return fmt.Errorf(
+				"transition %s has no statuses to move from",
+				trn.Event,
+			)
+		}
+		for _, guard := range trn.Guards {
+			if !guards[guard] {
+				return fmt.Errorf(
+					"transition %s has an unknown guard %q",
+					trn.Event,
+					guard,
+				)
+			}
+		}
+		for _, from := range trn.From {
+			if _, ok := contracts.Status_value[from]; !ok {
+				return fmt.Errorf(
+					"transition %s has an unknown status %q",
+					trn.Event,
+					from,
+				)
+			}
+			if from == trn.To {
+				return fmt.Errorf(
+					"transition %s moves from %s to itself",
+					trn.Event,
+					from,
+				)
+			}
+			if w.Find(from, trn.To) != &w.Transitions[idx] {
+				return fmt.Errorf(
+					"multiple transitions move from %s to %s",
+					from,
+					trn.To,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// TerminalStatuses gives the statuses tasks are finished in, completed tasks
+// are always finished and so are tasks in a status the workflow has no
+// transitions out of, such as cancelled tasks
+func (w *Workflow) TerminalStatuses() []string {
+	terminal := []string{contracts.Status_COMPLETED.String()}
+	for _, trn := range w.Transitions {
+		if contains(terminal, trn.To) {
+			continue
+		}
+		final := true
+		for _, other := range w.Transitions {
+			if contains(other.From, trn.To) {
+				final = false
+				break
+			}
+		}
+		if final {
+			terminal = append(terminal, trn.To)
+		}
+	}
+	return terminal
+}
+
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// Schedule an event that is to be written on the task stream by the scheduler
+// once the fire time is reached
+type Schedule struct {
+	TaskID string
+	Event  string
+	FireAt time.Time
+}
+
 type Task struct {
 	Id              string
 	Title           string
@@ -61,6 +330,9 @@ type Task struct {
 	Status          string
 	RandomMap       map[string]string
 	Metadata        map[string]interface{}
+	Assignee        string
+	DueDate         *time.Time
+	ParentID        string
 	Version         uint64
 	DateTimeUpdated time.Time
 	DateTimeCreated time.Time
@@ -71,7 +343,7 @@ func (t *Task) ToContract() (*contracts.TaskEntity, error) {
 	if !ok {
 		return nil, common.NewInvalidTaskStatusError()
 	}
-	return &contracts.TaskEntity{
+	cntr := &contracts.TaskEntity{
 		Id:              t.Id,
 		Version:         t.Version,
 		Title:           t.Title,
@@ -79,7 +351,97 @@ func (t *Task) ToContract() (*contracts.TaskEntity, error) {
 		Status:          contracts.Status(s),
 		CreatedDateTime: timestamppb.New(t.DateTimeCreated),
 		UpdatedDateTime: timestamppb.New(t.DateTimeUpdated),
-	}, nil
+		RandomMap:       t.RandomMap,
+		Assignee:        t.Assignee,
+		ParentId:        t.ParentID,
+	}
+	if t.DueDate != nil {
+		cntr.DueDate = timestamppb.New(*t.DueDate)
+	}
+	if t.Metadata != nil {
+		var err error
+		cntr.Metadata, err = structpb.NewStruct(t.Metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cntr, nil
+}
+
+// Apply folds an event on to the task, fields of the event data that are set
+// are the fields that were changed by the event
+func (t *Task) Apply(evnt *TaskEvent) {
+	if evnt.Event == common.EventCreated {
+		*t = Task{
+			Id:              evnt.StreamId,
+			DateTimeCreated: evnt.EventTime,
+		}
+	}
+	if evnt.Data.Title != nil {
+		t.Title = *evnt.Data.Title
+	}
+	if evnt.Data.Description != nil {
+		t.Description = *evnt.Data.Description
+	}
+	if evnt.Data.Status != nil {
+		t.Status = *evnt.Data.Status
+	}
+	if evnt.Data.RandomMap != nil {
+		t.RandomMap = evnt.Data.RandomMap
+	}
+	if evnt.Data.Metadata != nil {
+		t.Metadata = evnt.Data.Metadata
+	}
+	if evnt.Data.Assignee != nil {
+		t.Assignee = *evnt.Data.Assignee
+	}
+	if evnt.Data.ParentID != nil {
+		t.ParentID = *evnt.Data.ParentID
+	}
+	if evnt.Data.DueDate != nil {
+		if evnt.Data.DueDate.IsZero() {
+			t.DueDate = nil
+		} else {
+			due := *evnt.Data.DueDate
+			t.DueDate = &due
+		}
+	}
+	t.Version = evnt.Version
+	t.DateTimeUpdated = evnt.EventTime
+}
+
+// IsOverdue checks if the task isn't in one of the terminal statuses and is
+// past it's due date at the given time
+func (t *Task) IsOverdue(at time.Time, terminal []string) bool {
+	return t.DueDate != nil &&
+		t.DueDate.Before(at) &&
+		!contains(terminal, t.Status)
+}
+
+// Schedules gives the schedules of the task, the reminder is left out if it
+// is no longer useful because the task is already overdue. Tasks in one of
+// the terminal statuses have no schedules
+func (t *Task) Schedules(
+	now time.Time,
+	leadTime time.Duration,
+	terminal []string,
+) []Schedule {
+	if t.DueDate == nil || contains(terminal, t.Status) {
+		return nil
+	}
+	schds := []Schedule{}
+	if leadTime > 0 && now.Before(*t.DueDate) {
+		schds = append(schds, Schedule{
+			TaskID: t.Id,
+			Event:  common.EventReminderDue,
+			FireAt: t.DueDate.Add(-leadTime),
+		})
+	}
+	return append(schds, Schedule{
+		TaskID: t.Id,
+		Event:  common.EventOverdue,
+		FireAt: *t.DueDate,
+	})
 }
 
 func (*Task) ToContractSlice(in []Task) ([]*contracts.TaskEntity, error) {
@@ -94,6 +456,239 @@ func (*Task) ToContractSlice(in []Task) ([]*contracts.TaskEntity, error) {
 	return res, nil
 }
 
+// SortField field the tasks are sorted by when listed
+type SortField string
+
+const (
+	SortByDateTimeCreated SortField = "dateTimeCreated"
+	SortByDateTimeUpdated SortField = "dateTimeUpdated"
+	SortByTitle           SortField = "title"
+	SortByStatus          SortField = "status"
+)
+
+// Reader user the listing of tasks is restricted to
+type Reader struct {
+	UserType string
+	UserID   string
+}
+
+// ListOptions filters and sorting of a task listing, filters that are nil are
+// not applied, ties in the sort field are broken by the id of the task so that
+// pages are stable
+type ListOptions struct {
+	// Reader only the tasks the reader has read access to are listed, every
+	// task is listed if nil
+	Reader *Reader
+	Status *string
+	// Assignee only the tasks assigned to the user are listed
+	Assignee *string
+	// ParentID only the subtasks of the task are listed, the top level tasks
+	// if empty
+	ParentID *string
+	// Metadata only the tasks with the top level metadata keys set to the
+	// values are listed, numbers and booleans are compared by their json text
+	// and other values never match
+	Metadata map[string]string
+	// MetadataKeys only the tasks that have the top level metadata keys are
+	// listed
+	MetadataKeys []string
+	// OverdueAt only the tasks that aren't in one of the terminal statuses
+	// with a due date before the time are listed
+	OverdueAt *time.Time
+	// TerminalStatuses the statuses of finished tasks, only used along with
+	// OverdueAt
+	TerminalStatuses []string
+	// CreatedAfter and UpdatedAfter are inclusive, CreatedBefore and
+	// UpdatedBefore are exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	// Search matches tasks with the text in the title or description ignoring
+	// case
+	Search     *string
+	SortBy     SortField
+	Descending bool
+	// After only the tasks after this task in the order are listed, only the
+	// id and the field being sorted by are used
+	After *Task
+}
+
+// Matches checks if the task passes the filters of the options, the access of
+// the reader isn't checked since the acl is kept by the repository
+func (o *ListOptions) Matches(t *Task) bool {
+	if o.Status != nil && t.Status != *o.Status {
+		return false
+	}
+	if o.Assignee != nil && t.Assignee != *o.Assignee {
+		return false
+	}
+	if o.ParentID != nil && t.ParentID != *o.ParentID {
+		return false
+	}
+	for key, val := range o.Metadata {
+		text, ok := MetadataText(t.Metadata[key])
+		if !ok || text != val {
+			return false
+		}
+	}
+	for _, key := range o.MetadataKeys {
+		if _, ok := t.Metadata[key]; !ok {
+			return false
+		}
+	}
+	if o.OverdueAt != nil && !t.IsOverdue(*o.OverdueAt, o.TerminalStatuses) {
+		return false
+	}
+	if o.CreatedAfter != nil && t.DateTimeCreated.Before(*o.CreatedAfter) {
+		return false
+	}
+	if o.CreatedBefore != nil && !t.DateTimeCreated.Before(*o.CreatedBefore) {
+		return false
+	}
+	if o.UpdatedAfter != nil && t.DateTimeUpdated.Before(*o.UpdatedAfter) {
+		return false
+	}
+	if o.UpdatedBefore != nil && !t.DateTimeUpdated.Before(*o.UpdatedBefore) {
+		return false
+	}
+	if o.Search != nil {
+		search := strings.ToLower(*o.Search)
+		if !strings.Contains(strings.ToLower(t.Title), search) &&
+			!strings.Contains(strings.ToLower(t.Description), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// metadataKeyPattern the top level metadata keys the list options can filter
+// on, kept simple so the keys are safe to use in json paths
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// MetadataText gives the text a metadata value is compared by when filtering,
+// strings are compared as they are and numbers and booleans by their json
+// text, other values can't be filtered on
+func MetadataText(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// MatchesAfter checks if the task comes after the After task of the options
+func (o *ListOptions) MatchesAfter(t *Task) bool {
+	return o.After == nil || o.Less(o.After, t)
+}
+
+// Less checks if task a comes before task b in the order of the options
+func (o *ListOptions) Less(a *Task, b *Task) bool {
+	cmp := 0
+	switch o.SortBy {
+	case SortByDateTimeUpdated:
+		cmp = compareTimes(a.DateTimeUpdated, b.DateTimeUpdated)
+	case SortByTitle:
+		cmp = strings.Compare(a.Title, b.Title)
+	case SortByStatus:
+		cmp = strings.Compare(a.Status, b.Status)
+	default:
+		cmp = compareTimes(a.DateTimeCreated, b.DateTimeCreated)
+	}
+	if cmp == 0 {
+		cmp = strings.Compare(a.Id, b.Id)
+	}
+	if o.Descending {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+func compareTimes(a time.Time, b time.Time) int {
+	if a.Before(b) {
+		return -1
+	}
+	if a.After(b) {
+		return 1
+	}
+	return 0
+}
+
+// pageToken position of the last task of a page, continuation tokens are the
+// base64 encoded json of it
+type pageToken struct {
+	SortBy     SortField  `json:"s"`
+	Descending bool       `json:"d"`
+	ID         string     `json:"i"`
+	Time       *time.Time `json:"t,omitempty"`
+	Text       *string    `json:"x,omitempty"`
+}
+
+// encodePageToken creates the token continuing the listing after the task
+func encodePageToken(opts *ListOptions, last *Task) (string, error) {
+	tkn := pageToken{
+		SortBy:     opts.SortBy,
+		Descending: opts.Descending,
+		ID:         last.Id,
+	}
+	switch opts.SortBy {
+	case SortByDateTimeUpdated:
+		tkn.Time = &last.DateTimeUpdated
+	case SortByTitle:
+		tkn.Text = &last.Title
+	case SortByStatus:
+		tkn.Text = &last.Status
+	default:
+		tkn.Time = &last.DateTimeCreated
+	}
+	raw, err := json.Marshal(&tkn)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodePageToken gets the task the listing continues after, the token must
+// have been created for the same sorting as the options
+func decodePageToken(token string, opts *ListOptions) (*Task, error) {
+	invalid := common.NewInvalidListTasksQueryError("invalid page token")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	tkn := pageToken{}
+	err = json.Unmarshal(raw, &tkn)
+	if err != nil {
+		return nil, invalid
+	}
+	if tkn.SortBy != opts.SortBy || tkn.Descending != opts.Descending {
+		return nil, common.NewInvalidListTasksQueryError(
+			"page token was created for a different sorting",
+		)
+	}
+
+	after := &Task{Id: tkn.ID}
+	switch opts.SortBy {
+	case SortByDateTimeUpdated, SortByDateTimeCreated:
+		if tkn.Time == nil {
+			return nil, invalid
+		}
+		after.DateTimeUpdated = *tkn.Time
+		after.DateTimeCreated = *tkn.Time
+	case SortByTitle, SortByStatus:
+		if tkn.Text == nil {
+			return nil, invalid
+		}
+		after.Title = *tkn.Text
+		after.Status = *tkn.Text
+	}
+	return after, nil
+}
+
 type TaskEvent struct {
 	events.EventEntity
 	Data TaskData `json:"data"`
@@ -4,36 +4,80 @@ package tasks
 
 import (
 	"context"
+	"fmt"
+	"time"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uids"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Service handles business logic and use cases around the tasks domain
 type Service struct {
+	opts *Options
 	repo IRepository
 	lgrf logger.IFactory
 	aclr acl.IRepository
 	uidr uids.IRepository
+	frgr foreigns.IRepository
+	ntfr events.INotifier
+	mdvl IMetadataValidator
+
+	guards map[string]Guard
+	// terminal the statuses of the workflow tasks are finished in
+	terminal []string
 }
 
+// Guard checks if a task can take a transition of the workflow, the
+// transition is rejected with the error returned
+type Guard func(ctx context.Context, task *Task, trn *Transition) error
+
 // NewService constructs a Service
 func NewService(
+	opts *Options,
 	repo IRepository,
 	lgrf logger.IFactory,
 	aclr acl.IRepository,
 	uidr uids.IRepository,
+	frgr foreigns.IRepository,
+	ntfr events.INotifier,
+	mdvl IMetadataValidator,
 ) *Service {
-	return &Service{
+	s := &Service{
+		opts: opts,
 		repo: repo,
 		lgrf: lgrf,
 		aclr: aclr,
 		uidr: uidr,
+		frgr: frgr,
+		ntfr: ntfr,
+		mdvl: mdvl,
+
+		terminal: opts.Workflow.TerminalStatuses(),
+	}
+	s.guards = map[string]Guard{
+		GuardBlockersCompleted: func(
+			ctx context.Context,
+			task *Task,
+			_ *Transition,
+		) error {
+			return s.checkBlockersCompleted(ctx, task.Id)
+		},
+		GuardSubtasksCompleted: func(
+			ctx context.Context,
+			task *Task,
+			_ *Transition,
+		) error {
+			return s.checkSubtasksCompleted(ctx, task.Id)
+		},
 	}
+	return s
 }
 
 // CreateTask creates a task applying all business logic
@@ -53,6 +97,28 @@ func (s *Service) CreateTask(
 
 	pending := contracts.Status(contracts.Status_PENDING).String()
 
+	due, err := dueDateFromCommand(cmd.DueDate, false)
+	if err != nil {
+		lgr.Error("invalid due date", zap.Error(err))
+		return nil, err
+	}
+
+	// tasks are always created with metadata so the schema can require keys
+	mdata := cmd.Metadata.AsMap()
+	err = s.mdvl.Validate(cmd.UserContext.UserType, mdata)
+	if err != nil {
+		lgr.Error("invalid task metadata", zap.Error(err))
+		return nil, err
+	}
+
+	if cmd.ParentId != nil {
+		err = s.checkParent(ctx, cmd.UserContext, nil, *cmd.ParentId)
+		if err != nil {
+			lgr.Error("invalid parent task", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	id, err := s.uidr.GetID(ctx)
 	if err != nil {
 		lgr.Error(
@@ -70,6 +136,10 @@ func (s *Service) CreateTask(
 			Title:       &cmd.Title,
 			Description: &cmd.Description,
 			Status:      &pending,
+			RandomMap:   cmd.RandomMap,
+			Metadata:    mdata,
+			DueDate:     due,
+			ParentID:    cmd.ParentId,
 		},
 	)
 	if err != nil {
@@ -80,6 +150,16 @@ func (s *Service) CreateTask(
 		return nil, err
 	}
 
+	if due != nil {
+		task := Task{}
+		task.Apply(evnt)
+		err = s.reschedule(ctx, &task)
+		if err != nil {
+			lgr.Error("failed to schedule task", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	res, err := evnt.ToContract()
 	if err != nil {
 		lgr.Error("failed to map to contract", zap.Error(err))
@@ -92,13 +172,21 @@ func (s *Service) CreateTask(
 		id,
 		cmd.UserContext.UserType,
 		cmd.UserContext.Id,
-		acl.Read|acl.Write,
+		acl.Read|acl.Write|acl.Share,
 	)
 	if err != nil {
 		lgr.Error("failed to create acl entry", zap.Error(err))
 		return nil, err
 	}
 
+	if cmd.ParentId != nil {
+		err = s.attachSubtask(ctx, *cmd.ParentId, id)
+		if err != nil {
+			lgr.Error("failed to attach subtask", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return res, err
 }
 
@@ -134,11 +222,18 @@ func (s *Service) DeleteTask(
 		return nil, err
 	}
 
-	evnt, err := s.repo.Delete(
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	evnt, err := s.deleteTask(
 		ctx,
-		cmd.Id,
 		cmd.SagaId,
-		task.Version+1,
+		task,
+		s.opts.SubtaskDeletePolicy == SubtaskDeleteCascade,
+		map[string]bool{},
 	)
 	if err != nil {
 		lgr.Error("failed to delete task", zap.Error(err))
@@ -150,27 +245,226 @@ func (s *Service) DeleteTask(
 		lgr.Error("failed to map to contract", zap.Error(err))
 		return nil, err
 	}
+	return res, err
+}
+
+// deleteTask deletes the task along with it's schedules and place in the
+// hierarchy, subtasks are deleted before their parent when cascading regardless
+// of the acl of the subtasks since they belong to the parent, seen holds the
+// tasks visited by the cascade so a hierarchy that loops is reported instead of
+// being followed forever
+func (s *Service) deleteTask(
+	ctx context.Context,
+	sagaID *string,
+	task *Task,
+	cascade bool,
+	seen map[string]bool,
+) (*TaskEvent, error) {
+	if seen[task.Id] {
+		return nil, common.NewTaskHierarchyCycleError(task.Id)
+	}
+	seen[task.Id] = true
+	children, err := s.listSubtasks(ctx, task.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) != 0 && !cascade {
+		return nil, common.NewTaskHasSubtasksError()
+	}
+	for _, childID := range children {
+		child, err := s.repo.Get(ctx, childID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.deleteTask(ctx, sagaID, child, cascade, seen)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	evnt, err := s.repo.Delete(ctx, task.Id, sagaID, task.Version+1)
+	if err != nil {
+		return nil, err
+	}
+	if task.DueDate != nil {
+		err = s.repo.SetSchedules(ctx, task.Id, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the acl entries are kept so the history of the task can still be read by
+	// the users that had access to it, commands fail since the task is missing
+	if task.ParentID != "" {
+		err = s.detachSubtask(ctx, task.ParentID, task.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return evnt, nil
+}
+
+// listSubtasks gives the ids of the subtasks of the task, the hierarchy is
+// kept as foreign constraints of the subtasks on their parent
+func (s *Service) listSubtasks(
+	ctx context.Context,
+	id string,
+) ([]string, error) {
+	objs, err := s.frgr.ListAssociatedObjects(ctx, common.TaskStreamName, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		if obj.Stream == common.TaskStreamName {
+			ids = append(ids, obj.StreamId)
+		}
+	}
+	return ids, nil
+}
 
-	err = s.aclr.DeleteACLEntries(
+// attachSubtask records the task as a subtask of the parent, the parent is
+// registered as a foreign item along with it's first subtask. Registering is
+// idempotent since concurrent first subtasks both register the parent
+func (s *Service) attachSubtask(
+	ctx context.Context,
+	parentID string,
+	id string,
+) error {
+	err := s.frgr.EnsureForeignItem(
 		ctx,
+		nil,
 		common.TaskStreamName,
-		cmd.Id,
+		parentID,
 	)
 	if err != nil {
-		lgr.Error("failed to delete acl entries", zap.Error(err))
-		return nil, err
+		return err
 	}
+	return s.frgr.RegisterConstraint(
+		ctx,
+		nil,
+		common.TaskStreamName,
+		parentID,
+		common.TaskStreamName,
+		id,
+	)
+}
 
-	return res, err
+// detachSubtask removes the task from the subtasks of the parent, the parent
+// is removed from the foreign items along with it's last subtask
+func (s *Service) detachSubtask(
+	ctx context.Context,
+	parentID string,
+	id string,
+) error {
+	err := s.frgr.RemoveConstraint(
+		ctx,
+		common.TaskStreamName,
+		parentID,
+		common.TaskStreamName,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	children, err := s.listSubtasks(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	if len(children) != 0 {
+		return nil
+	}
+	return s.frgr.RemoveForeignItem(ctx, common.TaskStreamName, parentID)
 }
 
-// UpdateTask updates a task applying all business logic
-func (s *Service) UpdateTask(
+// checkParent checks that the task can be placed under the parent, the caller
+// needs write access to the parent and the parent can't be the task or one of
+// it's subtasks. Open tasks can't be placed under finished tasks since the
+// parent can only be finished once all of it's subtasks are
+func (s *Service) checkParent(
 	ctx context.Context,
-	cmd *contracts.UpdateTaskCommand,
+	caller *contracts.UserContext,
+	task *Task,
+	parentID string,
+) error {
+	if parentID == "" {
+		return common.NewInvalidTaskParentError("parentId is required")
+	}
+	err := s.aclr.CanWrite(
+		ctx,
+		common.TaskStreamName,
+		[]string{parentID},
+		caller.UserType,
+		caller.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	parent, err := s.repo.Get(ctx, parentID)
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		return common.NewInvalidTaskParentError("parent task doesn't exist")
+	}
+	if err != nil {
+		return err
+	}
+	// finished parents only take subtasks that are finished as well
+	if contains(s.terminal, parent.Status) &&
+		(task == nil || !contains(s.terminal, task.Status)) {
+		return common.NewInvalidTaskParentError("parent task is finished")
+	}
+	if task == nil {
+		return nil
+	}
+
+	// the task being among the ancestors of the parent would create a cycle
+	cycle, err := s.isAncestor(ctx, task.Id, parent)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return common.NewInvalidTaskParentError(
+			"a task can't be moved under itself or it's subtasks",
+		)
+	}
+	return nil
+}
+
+// isAncestor reports whether the task is the given task or one of it's
+// ancestors by walking up from it, a hierarchy that loops is reported as an
+// error instead of being walked forever
+func (s *Service) isAncestor(
+	ctx context.Context,
+	id string,
+	task *Task,
+) (bool, error) {
+	seen := map[string]bool{}
+	for {
+		if task.Id == id {
+			return true, nil
+		}
+		if seen[task.Id] {
+			return false, common.NewTaskHierarchyCycleError(task.Id)
+		}
+		seen[task.Id] = true
+		if task.ParentID == "" {
+			return false, nil
+		}
+		var err error
+		task, err = s.repo.Get(ctx, task.ParentID)
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// MoveTask moves a task under another task or to the top level applying all
+// business logic
+func (s *Service) MoveTask(
+	ctx context.Context,
+	cmd *contracts.MoveTaskCommand,
 ) (*contracts.TaskEvent, error) {
 	lgr := s.lgrf.Create(ctx)
-	lgr.Info("updating task")
+	lgr.Info("moving task")
 
 	err := s.aclr.CanWrite(
 		ctx,
@@ -196,21 +490,58 @@ func (s *Service) UpdateTask(
 		return nil, err
 	}
 
-	evnt, err := s.repo.Update(
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	if task.ParentID == cmd.ParentId {
+		lgr.Error("task is already under the parent")
+		return nil, common.NewNoTaskUpdatesError()
+	}
+	if cmd.ParentId != "" {
+		// concurrent moves could otherwise each pass the check and loop the
+		// hierarchy together, the check is made while holding the lock
+		err = s.repo.LockHierarchy(ctx)
+		if err != nil {
+			lgr.Error("failed to lock task hierarchy", zap.Error(err))
+			return nil, err
+		}
+		err = s.checkParent(ctx, cmd.UserContext, task, cmd.ParentId)
+		if err != nil {
+			lgr.Error("invalid parent task", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	evnt, err := s.repo.Move(
 		ctx,
 		cmd.Id,
 		cmd.SagaId,
 		task.Version+1,
-		TaskData{
-			Title:       cmd.Title,
-			Description: cmd.Description,
-		},
+		cmd.ParentId,
 	)
 	if err != nil {
-		lgr.Error("failed to update task", zap.Error(err))
+		lgr.Error("failed to move task", zap.Error(err))
 		return nil, err
 	}
 
+	if task.ParentID != "" {
+		err = s.detachSubtask(ctx, task.ParentID, cmd.Id)
+		if err != nil {
+			lgr.Error("failed to detach subtask", zap.Error(err))
+			return nil, err
+		}
+	}
+	if cmd.ParentId != "" {
+		err = s.attachSubtask(ctx, cmd.ParentId, cmd.Id)
+		if err != nil {
+			lgr.Error("failed to attach subtask", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	res, err := evnt.ToContract()
 	if err != nil {
 		lgr.Error("failed to map to contract", zap.Error(err))
@@ -219,13 +550,14 @@ func (s *Service) UpdateTask(
 	return res, err
 }
 
-// ProgressTask updates task state to in progress applying all business logic
-func (s *Service) ProgressTask(
+// AddTaskDependency blocks a task by another task applying all business
+// logic, the task can't be progressed until the blocker is completed
+func (s *Service) AddTaskDependency(
 	ctx context.Context,
-	cmd *contracts.ProgressTaskCommand,
+	cmd *contracts.AddTaskDependencyCommand,
 ) (*contracts.TaskEvent, error) {
 	lgr := s.lgrf.Create(ctx)
-	lgr.Info("progressing task")
+	lgr.Info("adding task dependency")
 
 	err := s.aclr.CanWrite(
 		ctx,
@@ -251,25 +583,34 @@ func (s *Service) ProgressTask(
 		return nil, err
 	}
 
-	if task.Status != contracts.Status_PENDING.String() {
-		lgr.Error(
-			"can't progress task that isn't pending",
-		)
-		return nil, common.NewNotPendingTaskError()
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
 	}
 
-	progress := contracts.Status(contracts.Status_PROGRESS).String()
-	evnt, err := s.repo.Update(
+	// concurrent commands could otherwise each pass the cycle check and block
+	// two tasks by each other, the check is made while holding the lock
+	err = s.repo.LockDependencies(ctx)
+	if err != nil {
+		lgr.Error("failed to lock task dependencies", zap.Error(err))
+		return nil, err
+	}
+	err = s.checkBlocker(ctx, cmd.UserContext, task, cmd.BlockerId)
+	if err != nil {
+		lgr.Error("invalid blocking task", zap.Error(err))
+		return nil, err
+	}
+
+	evnt, err := s.repo.AddDependency(
 		ctx,
 		cmd.Id,
 		cmd.SagaId,
 		task.Version+1,
-		TaskData{
-			Status: &progress,
-		},
+		cmd.BlockerId,
 	)
 	if err != nil {
-		lgr.Error("failed to update task", zap.Error(err))
+		lgr.Error("failed to add task dependency", zap.Error(err))
 		return nil, err
 	}
 
@@ -281,13 +622,101 @@ func (s *Service) ProgressTask(
 	return res, err
 }
 
-// CompleteTask updates task state to completed applying all business logic
-func (s *Service) CompleteTask(
+// checkBlocker checks that the task can be blocked by the blocker, the caller
+// needs read access to the blocker and the blocker can't be the task or be
+// blocked by it, directly or through other tasks
+func (s *Service) checkBlocker(
 	ctx context.Context,
-	cmd *contracts.CompleteTaskCommand,
+	caller *contracts.UserContext,
+	task *Task,
+	blockerID string,
+) error {
+	if blockerID == "" {
+		return common.NewInvalidTaskDependencyError("blockerId is required")
+	}
+	if blockerID == task.Id {
+		return common.NewInvalidTaskDependencyError(
+			"a task can't be blocked by itself",
+		)
+	}
+	err := s.aclr.CanRead(
+		ctx,
+		common.TaskStreamName,
+		[]string{blockerID},
+		caller.UserType,
+		caller.Id,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.Get(ctx, blockerID)
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		return common.NewInvalidTaskDependencyError(
+			"blocking task doesn't exist",
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	blockers, err := s.repo.ListBlockers(ctx, task.Id)
+	if err != nil {
+		return err
+	}
+	for _, id := range blockers {
+		if id == blockerID {
+			return common.NewNoTaskUpdatesError()
+		}
+	}
+
+	cycle, err := s.blocks(ctx, task.Id, blockerID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return common.NewInvalidTaskDependencyError(
+			"a task can't be blocked by a task it blocks",
+		)
+	}
+	return nil
+}
+
+// blocks reports whether the task blocks the other task, directly or through
+// other tasks, by walking up the blockers of the other task
+func (s *Service) blocks(
+	ctx context.Context,
+	id string,
+	otherID string,
+) (bool, error) {
+	seen := map[string]bool{otherID: true}
+	queue := []string{otherID}
+	for len(queue) != 0 {
+		ids, err := s.repo.ListBlockers(ctx, queue[0])
+		if err != nil {
+			return false, err
+		}
+		queue = queue[1:]
+		for _, nid := range ids {
+			if nid == id {
+				return true, nil
+			}
+			if !seen[nid] {
+				seen[nid] = true
+				queue = append(queue, nid)
+			}
+		}
+	}
+	return false, nil
+}
+
+// RemoveTaskDependency unblocks a task from another task applying all
+// business logic
+func (s *Service) RemoveTaskDependency(
+	ctx context.Context,
+	cmd *contracts.RemoveTaskDependencyCommand,
 ) (*contracts.TaskEvent, error) {
 	lgr := s.lgrf.Create(ctx)
-	lgr.Info("completing task")
+	lgr.Info("removing task dependency")
 
 	err := s.aclr.CanWrite(
 		ctx,
@@ -313,25 +742,39 @@ func (s *Service) CompleteTask(
 		return nil, err
 	}
 
-	if task.Status != contracts.Status_PROGRESS.String() {
-		lgr.Error(
-			"can't complete task that isn't in progress",
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	blockers, err := s.repo.ListBlockers(ctx, cmd.Id)
+	if err != nil {
+		lgr.Error("failed to list blocking tasks", zap.Error(err))
+		return nil, err
+	}
+	blocked := false
+	for _, id := range blockers {
+		if id == cmd.BlockerId {
+			blocked = true
+		}
+	}
+	if !blocked {
+		lgr.Error("task isn't blocked by the blocker")
+		return nil, common.NewInvalidTaskDependencyError(
+			"task isn't blocked by the blocking task",
 		)
-		return nil, common.NewNotProgressTaskError()
 	}
 
-	completed := contracts.Status(contracts.Status_COMPLETED).String()
-	evnt, err := s.repo.Update(
+	evnt, err := s.repo.RemoveDependency(
 		ctx,
 		cmd.Id,
 		cmd.SagaId,
 		task.Version+1,
-		TaskData{
-			Status: &completed,
-		},
+		cmd.BlockerId,
 	)
 	if err != nil {
-		lgr.Error("failed to update task", zap.Error(err))
+		lgr.Error("failed to remove task dependency", zap.Error(err))
 		return nil, err
 	}
 
@@ -340,23 +783,33 @@ func (s *Service) CompleteTask(
 		lgr.Error("failed to map to contract", zap.Error(err))
 		return nil, err
 	}
-
 	return res, err
 }
 
-// QueryTask queries for tasks and appling all business logic and validations
-func (s *Service) QueryTask(
+// UpdateTask updates a task applying all business logic
+func (s *Service) UpdateTask(
 	ctx context.Context,
-	qry *contracts.ListTasksQuery,
-) (*contracts.TaskEntityList, error) {
+	cmd *contracts.UpdateTaskCommand,
+) (*contracts.TaskEvent, error) {
 	lgr := s.lgrf.Create(ctx)
-	lgr.Info("query tasks")
+	lgr.Info("updating task")
 
-	if qry.CountPerPage == 0 {
-		qry.CountPerPage = 100
+	err := s.aclr.CanWrite(
+		ctx,
+		common.TaskStreamName,
+		[]string{cmd.Id},
+		cmd.UserContext.UserType,
+		cmd.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
 	}
 
-	tasks, err := s.repo.List(ctx, int(qry.CountPerPage), int(qry.PageNumber))
+	task, err := s.repo.Get(ctx, cmd.Id)
 	if err != nil {
 		lgr.Error(
 			"failed to fetch task",
@@ -365,14 +818,1629 @@ func (s *Service) QueryTask(
 		return nil, err
 	}
 
-	tasksctr, err := (*Task)(nil).ToContractSlice(tasks)
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
 	if err != nil {
-		lgr.Error("failed to map to contract", zap.Error(err))
+		lgr.Error("task version conflict", zap.Error(err))
 		return nil, err
 	}
-	res := &contracts.TaskEntityList{
-		Tasks: tasksctr,
+
+	due, err := dueDateFromCommand(cmd.DueDate, cmd.ClearDueDate)
+	if err != nil {
+		lgr.Error("invalid due date", zap.Error(err))
+		return nil, err
+	}
+
+	rmap, err := randomMapFromCommand(cmd.RandomMap, cmd.ClearRandomMap)
+	if err != nil {
+		lgr.Error("invalid random map", zap.Error(err))
+		return nil, err
+	}
+
+	// the metadata is replaced as a whole so it's validated as a whole
+	var mdata map[string]interface{}
+	if cmd.Metadata != nil {
+		mdata = cmd.Metadata.AsMap()
+		err = s.mdvl.Validate(cmd.UserContext.UserType, mdata)
+		if err != nil {
+			lgr.Error("invalid task metadata", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	evnt, err := s.repo.Update(
+		ctx,
+		cmd.Id,
+		cmd.SagaId,
+		task.Version+1,
+		TaskData{
+			Title:       cmd.Title,
+			Description: cmd.Description,
+			RandomMap:   rmap,
+			Metadata:    mdata,
+			DueDate:     due,
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to update task", zap.Error(err))
+		return nil, err
+	}
+
+	// the schedules are only replaced when the due date changes so reminders
+	// that were already fired aren't fired again
+	if due != nil {
+		task.Apply(evnt)
+		err = s.reschedule(ctx, task)
+		if err != nil {
+			lgr.Error("failed to schedule task", zap.Error(err))
+			return nil, err
+		}
 	}
 
+	res, err := evnt.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
 	return res, err
 }
+
+// ProgressTask updates task state to in progress applying all business logic,
+// the workflow has to allow moving the task to in progress
+func (s *Service) ProgressTask(
+	ctx context.Context,
+	cmd *contracts.ProgressTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("progressing task")
+
+	res, err := s.transitionTask(
+		ctx,
+		cmd.UserContext,
+		cmd.Id,
+		cmd.SagaId,
+		cmd.ExpectedVersion,
+		contracts.Status_PROGRESS.String(),
+	)
+	if common.IsError(err, common.InvalidTaskTransitionErrorCode) {
+		return nil, common.NewNotPendingTaskError()
+	}
+	return res, err
+}
+
+// CompleteTask updates task state to completed applying all business logic,
+// the workflow has to allow moving the task to completed
+func (s *Service) CompleteTask(
+	ctx context.Context,
+	cmd *contracts.CompleteTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("completing task")
+
+	res, err := s.transitionTask(
+		ctx,
+		cmd.UserContext,
+		cmd.Id,
+		cmd.SagaId,
+		cmd.ExpectedVersion,
+		contracts.Status_COMPLETED.String(),
+	)
+	if common.IsError(err, common.InvalidTaskTransitionErrorCode) {
+		return nil, common.NewNotProgressTaskError()
+	}
+	return res, err
+}
+
+// TransitionTask moves a task to another status applying all business logic,
+// the workflow has to allow moving the task from it's current status
+func (s *Service) TransitionTask(
+	ctx context.Context,
+	cmd *contracts.TransitionTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("transitioning task")
+
+	return s.transitionTask(
+		ctx,
+		cmd.UserContext,
+		cmd.Id,
+		cmd.SagaId,
+		cmd.ExpectedVersion,
+		cmd.Status.String(),
+	)
+}
+
+// transitionTask takes the transition of the workflow moving the task to the
+// status once all of it's guards pass
+func (s *Service) transitionTask(
+	ctx context.Context,
+	caller *contracts.UserContext,
+	id string,
+	sagaID *string,
+	expectedVersion *uint64,
+	status string,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+
+	err := s.aclr.CanWrite(
+		ctx,
+		common.TaskStreamName,
+		[]string{id},
+		caller.UserType,
+		caller.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	task, err := s.repo.Get(ctx, id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkExpectedVersion(task, expectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	trn := s.opts.Workflow.Find(task.Status, status)
+	if trn == nil {
+		lgr.Error(
+			"workflow doesn't allow the transition",
+			zap.String("from", task.Status),
+			zap.String("to", status),
+		)
+		return nil, common.NewInvalidTaskTransitionError(task.Status, status)
+	}
+	for _, name := range trn.Guards {
+		guard, ok := s.guards[name]
+		if !ok {
+			lgr.Error(
+				"transition guard isn't registered",
+				zap.String("guard", name),
+			)
+			return nil, common.NewUnknownTransitionGuardError(name)
+		}
+		err = guard(ctx, task, trn)
+		if err != nil {
+			lgr.Error(
+				"transition rejected by guard",
+				zap.String("guard", name),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	evnt, err := s.repo.Transition(
+		ctx,
+		id,
+		sagaID,
+		task.Version+1,
+		trn.Event,
+		status,
+	)
+	if err != nil {
+		lgr.Error("failed to transition task", zap.Error(err))
+		return nil, err
+	}
+
+	next := *task
+	next.Apply(evnt)
+	if !s.sameSchedules(task, &next) {
+		err = s.reschedule(ctx, &next)
+		if err != nil {
+			lgr.Error("failed to schedule task", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	res, err := evnt.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, err
+}
+
+// checkSubtasksCompleted checks that every subtask of the task is finished,
+// in one of the terminal statuses of the workflow
+func (s *Service) checkSubtasksCompleted(ctx context.Context, id string) error {
+	children, err := s.listSubtasks(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, childID := range children {
+		child, err := s.repo.Get(ctx, childID)
+		if err != nil {
+			return err
+		}
+		if !contains(s.terminal, child.Status) {
+			return common.NewOpenSubtasksError()
+		}
+	}
+	return nil
+}
+
+// checkBlockersCompleted checks that every task blocking the task is
+// finished, in one of the terminal statuses of the workflow
+func (s *Service) checkBlockersCompleted(ctx context.Context, id string) error {
+	blockers, err := s.repo.ListBlockers(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, blockerID := range blockers {
+		blocker, err := s.repo.Get(ctx, blockerID)
+		if err != nil {
+			return err
+		}
+		if !contains(s.terminal, blocker.Status) {
+			return common.NewTaskBlockedError()
+		}
+	}
+	return nil
+}
+
+// AssignTask assigns a task to a user applying all business logic, the
+// assignee is given read and write access to the task if they don't have it
+// already which requires the caller to be able to share the task, previous
+// assignees keep their access
+func (s *Service) AssignTask(
+	ctx context.Context,
+	cmd *contracts.AssignTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("assigning task")
+
+	err := s.aclr.CanWrite(
+		ctx,
+		common.TaskStreamName,
+		[]string{cmd.Id},
+		cmd.UserContext.UserType,
+		cmd.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	task, err := s.repo.Get(ctx, cmd.Id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	var access *Access
+	if cmd.Assignee != "" {
+		access, err = s.assigneeAccess(ctx, cmd.Id, cmd.Assignee)
+		if err != nil {
+			lgr.Error("failed to get acl entry", zap.Error(err))
+			return nil, err
+		}
+	}
+	// granting the assignee access is sharing the task, so only callers that
+	// can share it can assign it to users without write access
+	if access != nil {
+		err = s.aclr.CanShare(
+			ctx,
+			common.TaskStreamName,
+			[]string{cmd.Id},
+			cmd.UserContext.UserType,
+			cmd.UserContext.Id,
+		)
+		if err != nil {
+			lgr.Error(
+				"failure while checking acl",
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	evnt, err := s.repo.Assign(
+		ctx,
+		cmd.Id,
+		cmd.SagaId,
+		task.Version+1,
+		cmd.Assignee,
+		access,
+	)
+	if err != nil {
+		lgr.Error("failed to assign task", zap.Error(err))
+		return nil, err
+	}
+
+	if access != nil {
+		err = s.grantAssigneeAccess(ctx, cmd.Id, access)
+		if err != nil {
+			lgr.Error("failed to grant assignee access", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	res, err := evnt.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, err
+}
+
+// assigneeAccess returns the access the assignee is granted, nil if they can
+// already write the task
+func (s *Service) assigneeAccess(
+	ctx context.Context,
+	id string,
+	assignee string,
+) (*Access, error) {
+	prev, err := s.aclr.GetPermissions(
+		ctx,
+		common.TaskStreamName,
+		id,
+		common.UserTypeUser,
+		assignee,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if prev&acl.Write != 0 {
+		return nil, nil
+	}
+	return &Access{
+		UserType:            common.UserTypeUser,
+		UserID:              assignee,
+		Permissions:         acl.Read | acl.Write,
+		PreviousPermissions: prev,
+	}, nil
+}
+
+// grantAssigneeAccess gives the assignee read and write access to the task,
+// an entry with only read access is replaced
+func (s *Service) grantAssigneeAccess(
+	ctx context.Context,
+	id string,
+	access *Access,
+) error {
+	err := s.aclr.DeleteACLEntry(
+		ctx,
+		common.TaskStreamName,
+		id,
+		access.UserType,
+		access.UserID,
+	)
+	if err != nil && !common.IsError(err, common.ACLEntryMissingErrorCode) {
+		return err
+	}
+	return s.aclr.CreateACLEntry(
+		ctx,
+		common.TaskStreamName,
+		id,
+		access.UserType,
+		access.UserID,
+		access.Permissions,
+	)
+}
+
+// ShareTask grants a user access to a task applying all business logic, the
+// access replaces any access the user already had
+func (s *Service) ShareTask(
+	ctx context.Context,
+	cmd *contracts.ShareTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("sharing task")
+
+	err := s.aclr.CanShare(
+		ctx,
+		common.TaskStreamName,
+		[]string{cmd.Id},
+		cmd.UserContext.UserType,
+		cmd.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkShareTarget(cmd.UserContext, cmd.UserType, cmd.UserId)
+	if err != nil {
+		lgr.Error("invalid share target", zap.Error(err))
+		return nil, err
+	}
+
+	task, err := s.repo.Get(ctx, cmd.Id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	perms := acl.Read
+	if cmd.AccessLevel == contracts.AccessLevel_READ_WRITE {
+		perms |= acl.Write
+	}
+
+	prev, err := s.aclr.GetPermissions(
+		ctx,
+		common.TaskStreamName,
+		cmd.Id,
+		cmd.UserType,
+		cmd.UserId,
+	)
+	if err != nil {
+		lgr.Error("failed to get acl entry", zap.Error(err))
+		return nil, err
+	}
+
+	evnt, err := s.repo.Share(
+		ctx,
+		cmd.Id,
+		cmd.SagaId,
+		task.Version+1,
+		Access{
+			UserType:            cmd.UserType,
+			UserID:              cmd.UserId,
+			Permissions:         perms,
+			PreviousPermissions: prev,
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to share task", zap.Error(err))
+		return nil, err
+	}
+
+	// the previous access is replaced so the level can be raised or lowered
+	err = s.aclr.DeleteACLEntry(
+		ctx,
+		common.TaskStreamName,
+		cmd.Id,
+		cmd.UserType,
+		cmd.UserId,
+	)
+	if err != nil && !common.IsError(err, common.ACLEntryMissingErrorCode) {
+		lgr.Error("failed to delete acl entry", zap.Error(err))
+		return nil, err
+	}
+	err = s.aclr.CreateACLEntry(
+		ctx,
+		common.TaskStreamName,
+		cmd.Id,
+		cmd.UserType,
+		cmd.UserId,
+		perms,
+	)
+	if err != nil {
+		lgr.Error("failed to create acl entry", zap.Error(err))
+		return nil, err
+	}
+
+	res, err := evnt.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, err
+}
+
+// UnshareTask revokes the access of a user to a task applying all business
+// logic
+func (s *Service) UnshareTask(
+	ctx context.Context,
+	cmd *contracts.UnshareTaskCommand,
+) (*contracts.TaskEvent, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("unsharing task")
+
+	err := s.aclr.CanShare(
+		ctx,
+		common.TaskStreamName,
+		[]string{cmd.Id},
+		cmd.UserContext.UserType,
+		cmd.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkShareTarget(cmd.UserContext, cmd.UserType, cmd.UserId)
+	if err != nil {
+		lgr.Error("invalid share target", zap.Error(err))
+		return nil, err
+	}
+
+	task, err := s.repo.Get(ctx, cmd.Id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	err = checkExpectedVersion(task, cmd.ExpectedVersion)
+	if err != nil {
+		lgr.Error("task version conflict", zap.Error(err))
+		return nil, err
+	}
+
+	prev, err := s.aclr.GetPermissions(
+		ctx,
+		common.TaskStreamName,
+		cmd.Id,
+		cmd.UserType,
+		cmd.UserId,
+	)
+	if err != nil {
+		lgr.Error("failed to get acl entry", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.aclr.DeleteACLEntry(
+		ctx,
+		common.TaskStreamName,
+		cmd.Id,
+		cmd.UserType,
+		cmd.UserId,
+	)
+	if err != nil {
+		lgr.Error("failed to delete acl entry", zap.Error(err))
+		return nil, err
+	}
+
+	evnt, err := s.repo.Unshare(
+		ctx,
+		cmd.Id,
+		cmd.SagaId,
+		task.Version+1,
+		Access{
+			UserType:            cmd.UserType,
+			UserID:              cmd.UserId,
+			PreviousPermissions: prev,
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to unshare task", zap.Error(err))
+		return nil, err
+	}
+
+	res, err := evnt.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, err
+}
+
+// checkShareTarget checks that the access of the target can be changed by
+// the caller, callers can't change their own access so the task can't be left
+// without anyone to share it
+func checkShareTarget(
+	caller *contracts.UserContext,
+	userType string,
+	userID string,
+) error {
+	if userType != common.UserTypeUser && userType != common.UserTypeApp {
+		return common.NewInvalidShareTargetError(
+			"userType must be " + common.UserTypeUser + " or " + common.UserTypeApp,
+		)
+	}
+	if userID == "" {
+		return common.NewInvalidShareTargetError("userId is required")
+	}
+	if userType == caller.UserType && userID == caller.Id {
+		return common.NewInvalidShareTargetError(
+			"the access of the caller can't be changed",
+		)
+	}
+	return nil
+}
+
+// GetTask gets a task by it's id applying all business logic and validations
+func (s *Service) GetTask(
+	ctx context.Context,
+	qry *contracts.GetTaskQuery,
+) (*contracts.TaskEntity, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("get task")
+
+	err := s.aclr.CanRead(
+		ctx,
+		common.TaskStreamName,
+		[]string{qry.Id},
+		qry.UserContext.UserType,
+		qry.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	task, err := s.repo.Get(ctx, qry.Id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	res, err := task.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTaskDependencies gets the dependency graph of a task, the tasks blocking
+// it and the tasks blocked by it are followed transitively. Only the tasks the
+// caller can read are given but the dependencies on the rest are kept so the
+// graph stays connected
+func (s *Service) GetTaskDependencies(
+	ctx context.Context,
+	qry *contracts.GetTaskDependenciesQuery,
+) (*contracts.TaskDependencyGraph, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("get task dependencies")
+
+	err := s.aclr.CanRead(
+		ctx,
+		common.TaskStreamName,
+		[]string{qry.Id},
+		qry.UserContext.UserType,
+		qry.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	_, err = s.repo.Get(ctx, qry.Id)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// the graph is acyclic so the blockers and the blocked tasks only share
+	// the task itself
+	graph := &contracts.TaskDependencyGraph{}
+	ids := []string{qry.Id}
+	seen := map[string]bool{qry.Id: true}
+	for _, up := range []bool{true, false} {
+		queue := []string{qry.Id}
+		for len(queue) != 0 {
+			id := queue[0]
+			queue = queue[1:]
+			var next []string
+			if up {
+				next, err = s.repo.ListBlockers(ctx, id)
+			} else {
+				next, err = s.repo.ListBlocked(ctx, id)
+			}
+			if err != nil {
+				lgr.Error("failed to list task dependencies", zap.Error(err))
+				return nil, err
+			}
+			for _, nid := range next {
+				dep := &contracts.TaskDependency{TaskId: nid, BlockerId: id}
+				if up {
+					dep = &contracts.TaskDependency{TaskId: id, BlockerId: nid}
+				}
+				graph.Dependencies = append(graph.Dependencies, dep)
+				if !seen[nid] {
+					seen[nid] = true
+					ids = append(ids, nid)
+					queue = append(queue, nid)
+				}
+			}
+		}
+	}
+
+	for _, id := range ids {
+		err = s.aclr.CanRead(
+			ctx,
+			common.TaskStreamName,
+			[]string{id},
+			qry.UserContext.UserType,
+			qry.UserContext.Id,
+		)
+		if common.IsError(err, common.UserACLCheckFailedErrorCode) {
+			continue
+		}
+		if err != nil {
+			lgr.Error("failure while checking acl", zap.Error(err))
+			return nil, err
+		}
+		task, err := s.repo.Get(ctx, id)
+		if err != nil {
+			lgr.Error("failed to fetch task", zap.Error(err))
+			return nil, err
+		}
+		cntr, err := task.ToContract()
+		if err != nil {
+			lgr.Error("failed to map to contract", zap.Error(err))
+			return nil, err
+		}
+		graph.Tasks = append(graph.Tasks, cntr)
+	}
+	return graph, nil
+}
+
+// QueryTask queries for tasks and appling all business logic and validations
+func (s *Service) QueryTask(
+	ctx context.Context,
+	qry *contracts.ListTasksQuery,
+) (*contracts.TaskEntityList, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("query tasks")
+
+	if qry.CountPerPage == 0 {
+		qry.CountPerPage = 100
+	}
+
+	opts, err := listOptionsFromQuery(qry)
+	if err != nil {
+		lgr.Error("invalid list options", zap.Error(err))
+		return nil, err
+	}
+
+	// tasks are only listed if the caller can read them unless an application
+	// explicitly asks for every task
+	if qry.AllTasks {
+		if qry.UserContext.UserType != common.UserTypeApp ||
+			!s.opts.AppsCanListAllTasks {
+			lgr.Error("caller isn't allowed to list all tasks")
+			return nil, common.NewUserACLCheckFailedError()
+		}
+	} else {
+		opts.Reader = &Reader{
+			UserType: qry.UserContext.UserType,
+			UserID:   qry.UserContext.Id,
+		}
+	}
+	// tasks are only assigned to users, so an application with the same id
+	// as a user mustn't see that user's tasks
+	if qry.Mine {
+		if qry.UserContext.UserType != common.UserTypeUser {
+			lgr.Error("mine used by a caller that isn't a user")
+			return nil, common.NewInvalidListTasksQueryError(
+				"mine can only be used by users",
+			)
+		}
+		opts.Assignee = &qry.UserContext.Id
+	}
+	if qry.Overdue {
+		now := time.Now()
+		opts.OverdueAt = &now
+		opts.TerminalStatuses = s.terminal
+	}
+
+	if qry.PageToken != nil {
+		if qry.PageNumber != 0 {
+			lgr.Error("page number and page token both set")
+			return nil, common.NewInvalidListTasksQueryError(
+				"pageNumber can't be used with pageToken",
+			)
+		}
+		opts.After, err = decodePageToken(*qry.PageToken, opts)
+		if err != nil {
+			lgr.Error("invalid page token", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	tasks, err := s.repo.List(
+		ctx,
+		*opts,
+		int(qry.CountPerPage),
+		int(qry.PageNumber),
+	)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tasksctr, err := (*Task)(nil).ToContractSlice(tasks)
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	res := &contracts.TaskEntityList{
+		Tasks: tasksctr,
+	}
+
+	if len(tasks) == int(qry.CountPerPage) {
+		token, err := encodePageToken(opts, &tasks[len(tasks)-1])
+		if err != nil {
+			lgr.Error("failed to create page token", zap.Error(err))
+			return nil, err
+		}
+		res.NextToken = &token
+	}
+
+	if qry.IncludeTotal {
+		total, err := s.repo.Count(ctx, *opts)
+		if err != nil {
+			lgr.Error("failed to count tasks", zap.Error(err))
+			return nil, err
+		}
+		res.Total = &total
+	}
+
+	return res, nil
+}
+
+// listOptionsFromQuery maps the filters and sorting of the query to the list
+// options of the repository
+func listOptionsFromQuery(
+	qry *contracts.ListTasksQuery,
+) (*ListOptions, error) {
+	opts := &ListOptions{
+		Search:       qry.Search,
+		ParentID:     qry.ParentId,
+		Metadata:     qry.Metadata,
+		MetadataKeys: qry.MetadataKeys,
+		Descending:   qry.SortDirection == contracts.SortDirection_DESCENDING,
+	}
+	for key := range qry.Metadata {
+		if !metadataKeyPattern.MatchString(key) {
+			return nil, common.NewInvalidListTasksQueryError(
+				"invalid metadata key " + key,
+			)
+		}
+	}
+	for _, key := range qry.MetadataKeys {
+		if !metadataKeyPattern.MatchString(key) {
+			return nil, common.NewInvalidListTasksQueryError(
+				"invalid metadata key " + key,
+			)
+		}
+	}
+	if qry.Status != nil {
+		status, ok := contracts.Status_name[int32(*qry.Status)]
+		if !ok {
+			return nil, common.NewInvalidTaskStatusError()
+		}
+		opts.Status = &status
+	}
+	if qry.CreatedAfter != nil {
+		t := qry.CreatedAfter.AsTime()
+		opts.CreatedAfter = &t
+	}
+	if qry.CreatedBefore != nil {
+		t := qry.CreatedBefore.AsTime()
+		opts.CreatedBefore = &t
+	}
+	if qry.UpdatedAfter != nil {
+		t := qry.UpdatedAfter.AsTime()
+		opts.UpdatedAfter = &t
+	}
+	if qry.UpdatedBefore != nil {
+		t := qry.UpdatedBefore.AsTime()
+		opts.UpdatedBefore = &t
+	}
+
+	switch qry.SortBy {
+	case contracts.TaskSortField_DATE_TIME_CREATED:
+		opts.SortBy = SortByDateTimeCreated
+	case contracts.TaskSortField_DATE_TIME_UPDATED:
+		opts.SortBy = SortByDateTimeUpdated
+	case contracts.TaskSortField_TITLE:
+		opts.SortBy = SortByTitle
+	case contracts.TaskSortField_STATUS:
+		opts.SortBy = SortByStatus
+	default:
+		return nil, common.NewInvalidListTasksQueryError("unknown sort field")
+	}
+	if qry.SortDirection != contracts.SortDirection_ASCENDING &&
+		qry.SortDirection != contracts.SortDirection_DESCENDING {
+		return nil, common.NewInvalidListTasksQueryError(
+			"unknown sort direction",
+		)
+	}
+	return opts, nil
+}
+
+// GetTaskHistory queries the ordered events of a task applying all business
+// logic and validations
+func (s *Service) GetTaskHistory(
+	ctx context.Context,
+	qry *contracts.GetTaskHistoryQuery,
+) (*contracts.TaskEventList, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("query task history")
+
+	err := s.aclr.CanRead(
+		ctx,
+		common.TaskStreamName,
+		[]string{qry.Id},
+		qry.UserContext.UserType,
+		qry.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if qry.CountPerPage == 0 {
+		qry.CountPerPage = 100
+	}
+
+	evnts, err := s.repo.ListEvents(
+		ctx,
+		qry.Id,
+		int(qry.CountPerPage),
+		int(qry.PageNumber),
+	)
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task events",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	evntsctr, err := (*TaskEvent)(nil).ToContractSlice(evnts)
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	res := &contracts.TaskEventList{
+		Events: evntsctr,
+	}
+
+	return res, err
+}
+
+// GetTaskAsOf queries a task as it was at a version or point in time by
+// folding it's events, applying all business logic and validations
+func (s *Service) GetTaskAsOf(
+	ctx context.Context,
+	qry *contracts.GetTaskAsOfQuery,
+) (*contracts.TaskEntity, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("query task as of")
+
+	if (qry.Version == nil) == (qry.EventTime == nil) {
+		lgr.Error("exactly one of version or event time required")
+		return nil, common.NewInvalidAsOfQueryError()
+	}
+
+	err := s.aclr.CanRead(
+		ctx,
+		common.TaskStreamName,
+		[]string{qry.Id},
+		qry.UserContext.UserType,
+		qry.UserContext.Id,
+	)
+	if err != nil {
+		lgr.Error(
+			"failure while checking acl",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	var evntTime *time.Time
+	if qry.EventTime != nil {
+		t := qry.EventTime.AsTime()
+		evntTime = &t
+	}
+	task, err := s.foldTask(ctx, qry.Id, qry.Version, evntTime)
+	if err != nil {
+		lgr.Error(
+			"failed to fold task",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	res, err := task.ToContract()
+	if err != nil {
+		lgr.Error("failed to map to contract", zap.Error(err))
+		return nil, err
+	}
+	return res, err
+}
+
+// foldTask folds the events of a task up to a version or event time starting
+// from the latest snapshot before it
+func (s *Service) foldTask(
+	ctx context.Context,
+	id string,
+	version *uint64,
+	evntTime *time.Time,
+) (*Task, error) {
+	snap, err := s.repo.GetSnapshot(ctx, id, version, evntTime)
+	if err != nil {
+		return nil, err
+	}
+	task := Task{}
+	var from uint64
+	if snap != nil {
+		task = *snap
+		from = snap.Version + 1
+	}
+
+	evnts, err := s.repo.ListEventsUntil(
+		ctx,
+		id,
+		from,
+		version,
+		evntTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range evnts {
+		task.Apply(&evnts[idx])
+	}
+	if (snap == nil && len(evnts) == 0) ||
+		(len(evnts) != 0 && evnts[len(evnts)-1].Event == common.EventDeleted) {
+		return nil, common.NewTaskMissingError()
+	}
+	return &task, nil
+}
+
+// CompensateTask undoes the changes made to a task by a saga, fromVersion is
+// the version of the first event the saga wrote to the task. Tasks created by
+// the saga are deleted, all other tasks are restored to the state they were in
+// before the saga
+func (s *Service) CompensateTask(
+	ctx context.Context,
+	sagaID string,
+	id string,
+	fromVersion uint64,
+) error {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("compensating task", zap.String("id", id))
+
+	task, err := s.repo.Get(ctx, id)
+	if err != nil && !common.IsError(err, common.TaskMissingErrorCode) {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return err
+	}
+	if task == nil {
+		// nothing to undo if the task created by the saga is already gone
+		if fromVersion == 0 {
+			return nil
+		}
+		// the events of a deleted task can't be folded back into the task
+		lgr.Error("task was deleted and can't be restored")
+		return common.NewSagaNotCompensatableError(
+			"task " + id + " has been deleted",
+		)
+	}
+
+	if fromVersion == 0 {
+		// subtasks created outside of the saga aren't deleted along with it
+		_, err = s.deleteTask(ctx, &sagaID, task, false, map[string]bool{})
+		if common.IsError(err, common.TaskHasSubtasksErrorCode) {
+			lgr.Error("task has subtasks and can't be deleted")
+			return common.NewSagaNotCompensatableError(
+				"task " + id + " has subtasks",
+			)
+		}
+		if err != nil {
+			lgr.Error("failed to delete task", zap.Error(err))
+		}
+		return err
+	}
+
+	before := fromVersion - 1
+	prev, err := s.foldTask(ctx, id, &before, nil)
+	if err != nil {
+		lgr.Error(
+			"failed to fold task",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// the compensating event holds the complete state so that folding it
+	// overwrites every field the saga changed
+	data := TaskData{
+		Title:       &prev.Title,
+		Description: &prev.Description,
+		Status:      &prev.Status,
+		RandomMap:   prev.RandomMap,
+		Metadata:    prev.Metadata,
+		Assignee:    &prev.Assignee,
+		ParentID:    &prev.ParentID,
+	}
+	if data.RandomMap == nil {
+		data.RandomMap = map[string]string{}
+	}
+	if data.Metadata == nil {
+		data.Metadata = map[string]interface{}{}
+	}
+	data.DueDate = &time.Time{}
+	if prev.DueDate != nil {
+		data.DueDate = prev.DueDate
+	}
+	evnt, err := s.repo.Compensate(ctx, id, &sagaID, task.Version+1, data)
+	if err != nil {
+		lgr.Error("failed to compensate task", zap.Error(err))
+		return err
+	}
+
+	err = s.restoreAccess(ctx, sagaID, id, fromVersion)
+	if err != nil {
+		lgr.Error("failed to restore task access", zap.Error(err))
+		return err
+	}
+
+	err = s.restoreDependencies(ctx, sagaID, id, fromVersion, evnt.Version)
+	if err != nil {
+		lgr.Error("failed to restore task dependencies", zap.Error(err))
+		return err
+	}
+
+	if prev.ParentID != task.ParentID {
+		err = s.restoreParent(ctx, task, prev)
+		if err != nil {
+			lgr.Error("failed to restore parent task", zap.Error(err))
+			return err
+		}
+	}
+
+	if !s.sameSchedules(task, prev) {
+		err = s.reschedule(ctx, prev)
+		if err != nil {
+			lgr.Error("failed to schedule task", zap.Error(err))
+		}
+	}
+	return err
+}
+
+// restoreAccess undoes the access changes the saga made to the task, latest
+// first so every user ends up with the access they had before the saga
+func (s *Service) restoreAccess(
+	ctx context.Context,
+	sagaID string,
+	id string,
+	fromVersion uint64,
+) error {
+	evnts, err := s.repo.ListEventsUntil(ctx, id, fromVersion, nil, nil)
+	if err != nil {
+		return err
+	}
+	for idx := len(evnts) - 1; idx >= 0; idx-- {
+		access := evnts[idx].Data.Access
+		saga := evnts[idx].SagaId
+		if access == nil || saga == nil || *saga != sagaID {
+			continue
+		}
+		err = s.aclr.DeleteACLEntry(
+			ctx,
+			common.TaskStreamName,
+			id,
+			access.UserType,
+			access.UserID,
+		)
+		if err != nil && !common.IsError(err, common.ACLEntryMissingErrorCode) {
+			return err
+		}
+		if access.PreviousPermissions == 0 {
+			continue
+		}
+		err = s.aclr.CreateACLEntry(
+			ctx,
+			common.TaskStreamName,
+			id,
+			access.UserType,
+			access.UserID,
+			access.PreviousPermissions,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreDependencies undoes the dependencies the saga added to or removed
+// from the task, latest first. The dependency events are written after the
+// compensating event at the version it was written at
+func (s *Service) restoreDependencies(
+	ctx context.Context,
+	sagaID string,
+	id string,
+	fromVersion uint64,
+	version uint64,
+) error {
+	evnts, err := s.repo.ListEventsUntil(ctx, id, fromVersion, nil, nil)
+	if err != nil {
+		return err
+	}
+	ids, err := s.repo.ListBlockers(ctx, id)
+	if err != nil {
+		return err
+	}
+	blockers := make(map[string]bool, len(ids))
+	for _, blockerID := range ids {
+		blockers[blockerID] = true
+	}
+
+	for idx := len(evnts) - 1; idx >= 0; idx-- {
+		blockerID := evnts[idx].Data.BlockerID
+		saga := evnts[idx].SagaId
+		if blockerID == nil || saga == nil || *saga != sagaID {
+			continue
+		}
+		switch evnts[idx].Event {
+		case common.EventDependencyAdded:
+			if !blockers[*blockerID] {
+				continue
+			}
+			_, err = s.repo.RemoveDependency(
+				ctx,
+				id,
+				&sagaID,
+				version+1,
+				*blockerID,
+			)
+			delete(blockers, *blockerID)
+		case common.EventDependencyRemoved:
+			if blockers[*blockerID] {
+				continue
+			}
+			err = s.checkRestoredBlocker(ctx, id, *blockerID)
+			if err != nil {
+				return err
+			}
+			_, err = s.repo.AddDependency(
+				ctx,
+				id,
+				&sagaID,
+				version+1,
+				*blockerID,
+			)
+			blockers[*blockerID] = true
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		version++
+	}
+	return nil
+}
+
+// checkRestoredBlocker checks that a dependency removed by a saga can be added
+// back, the blocker has to still exist and can't have been blocked by the
+// task since. The check is made while holding the dependencies lock
+func (s *Service) checkRestoredBlocker(
+	ctx context.Context,
+	id string,
+	blockerID string,
+) error {
+	err := s.repo.LockDependencies(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.Get(ctx, blockerID)
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		return common.NewSagaNotCompensatableError(
+			"blocking task " + blockerID + " has been deleted",
+		)
+	}
+	if err != nil {
+		return err
+	}
+	cycle, err := s.blocks(ctx, id, blockerID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return common.NewSagaNotCompensatableError(
+			"task " + id + " now blocks task " + blockerID,
+		)
+	}
+	return nil
+}
+
+// restoreParent moves the task back under the parent it had before a saga
+func (s *Service) restoreParent(
+	ctx context.Context,
+	task *Task,
+	prev *Task,
+) error {
+	if task.ParentID != "" {
+		err := s.detachSubtask(ctx, task.ParentID, task.Id)
+		if err != nil {
+			return err
+		}
+	}
+	if prev.ParentID == "" {
+		return nil
+	}
+	err := s.repo.LockHierarchy(ctx)
+	if err != nil {
+		return err
+	}
+	parent, err := s.repo.Get(ctx, prev.ParentID)
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		return common.NewSagaNotCompensatableError(
+			"parent task " + prev.ParentID + " has been deleted",
+		)
+	}
+	if err != nil {
+		return err
+	}
+	cycle, err := s.isAncestor(ctx, task.Id, parent)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return common.NewSagaNotCompensatableError(
+			"task " + task.Id + " is now above task " + prev.ParentID,
+		)
+	}
+	return s.attachSubtask(ctx, prev.ParentID, task.Id)
+}
+
+// reschedule replaces the schedules of the task with the schedules for it's
+// current due date and status
+func (s *Service) reschedule(ctx context.Context, task *Task) error {
+	return s.repo.SetSchedules(
+		ctx,
+		task.Id,
+		task.Schedules(time.Now(), s.opts.ReminderLeadTime, s.terminal),
+	)
+}
+
+// sameSchedules checks if both states of a task would have the same
+// schedules, the schedules are kept as is when they do since replacing them
+// fires reminders again
+func (s *Service) sameSchedules(a *Task, b *Task) bool {
+	if contains(s.terminal, a.Status) != contains(s.terminal, b.Status) {
+		return false
+	}
+	if a.DueDate == nil || b.DueDate == nil {
+		return a.DueDate == nil && b.DueDate == nil
+	}
+	return a.DueDate.Equal(*b.DueDate)
+}
+
+// FireDueSchedule fires the earliest schedule that is due writing it's event
+// on the task stream, false is given if no schedules are due. The schedule is
+// claimed in the transaction of the context so it's fired again later if the
+// transaction is rolled back
+func (s *Service) FireDueSchedule(
+	ctx context.Context,
+	now time.Time,
+) (bool, error) {
+	lgr := s.lgrf.Create(ctx)
+
+	schd, err := s.repo.ClaimDueSchedule(ctx, now)
+	if err != nil {
+		lgr.Error("failed to claim due schedule", zap.Error(err))
+		return false, err
+	}
+	if schd == nil {
+		return false, nil
+	}
+	lgr.Info(
+		"firing task schedule",
+		zap.String("id", schd.TaskID),
+		zap.String("event", schd.Event),
+	)
+
+	task, err := s.repo.Get(ctx, schd.TaskID)
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		lgr.Warn("task of schedule is missing, dropping schedule")
+		return true, nil
+	}
+	if err != nil {
+		lgr.Error(
+			"failed to fetch task",
+			zap.Error(err),
+		)
+		return false, err
+	}
+	if task.DueDate == nil || contains(s.terminal, task.Status) {
+		lgr.Warn("task of schedule no longer has a due date, dropping schedule")
+		return true, nil
+	}
+
+	_, err = s.repo.FireSchedule(
+		ctx,
+		task.Id,
+		task.Version+1,
+		schd.Event,
+		*task.DueDate,
+	)
+	if err != nil {
+		lgr.Error("failed to fire task schedule", zap.Error(err))
+		return false, err
+	}
+	return true, nil
+}
+
+// SubscribeEvents streams task events starting from an event id, events that
+// have already been committed are sent first after which the subscription
+// waits for new events until the context is done, only events of tasks the
+// user can read are sent. The acl entries of deleted tasks are kept so their
+// readers are still sent the delete
+func (s *Service) SubscribeEvents(
+	ctx context.Context,
+	qry *contracts.SubscribeEventsQuery,
+	send func(*contracts.TaskEvent) error,
+) error {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("subscribing to task events")
+
+	// subscribing before catching up so no commits are missed in between
+	ntfy, unsubscribe := s.ntfr.Subscribe(common.TaskStreamName)
+	defer unsubscribe()
+
+	var after uint64
+	if qry.FromEventId > 0 {
+		after = qry.FromEventId - 1
+	}
+	for {
+		evnts, err := s.repo.ListEventsAfter(ctx, after, 100)
+		if err != nil {
+			// the subscription ended while the events were being fetched
+			if ctx.Err() != nil {
+				return nil
+			}
+			lgr.Error(
+				"failed to fetch task events",
+				zap.Error(err),
+			)
+			return err
+		}
+
+		if len(evnts) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ntfy:
+			}
+			continue
+		}
+
+		readable := map[string]bool{}
+		for idx := range evnts {
+			id := evnts[idx].StreamId
+			if _, ok := readable[id]; !ok {
+				readable[id] = s.aclr.CanRead(
+					ctx,
+					common.TaskStreamName,
+					[]string{id},
+					qry.UserContext.UserType,
+					qry.UserContext.Id,
+				) == nil
+			}
+			if !readable[id] {
+				continue
+			}
+
+			evnt, err := evnts[idx].ToContract()
+			if err != nil {
+				lgr.Error("failed to map to contract", zap.Error(err))
+				return err
+			}
+			err = send(evnt)
+			if err != nil {
+				lgr.Warn("failed to send event", zap.Error(err))
+				return err
+			}
+		}
+		after = evnts[len(evnts)-1].Id
+	}
+}
+
+// randomMapFromCommand gives the random map an update sets, nil when it's left
+// as is and an empty map when it's cleared
+func randomMapFromCommand(
+	randomMap map[string]string,
+	clear bool,
+) (map[string]string, error) {
+	if clear {
+		if len(randomMap) != 0 {
+			return nil, common.NewInvalidTaskRandomMapError(
+				"randomMap can't be set along with clearRandomMap",
+			)
+		}
+		return map[string]string{}, nil
+	}
+	if len(randomMap) == 0 {
+		return nil, nil
+	}
+	return randomMap, nil
+}
+
+// dueDateFromCommand gives the due date to set in the event data, nil if it
+// isn't being changed and the zero time if it's being cleared
+func dueDateFromCommand(
+	dueDate *timestamppb.Timestamp,
+	clear bool,
+) (*time.Time, error) {
+	if clear {
+		if dueDate != nil {
+			return nil, common.NewInvalidDueDateError(
+				"dueDate can't be set along with clearDueDate",
+			)
+		}
+		return &time.Time{}, nil
+	}
+	if dueDate == nil {
+		return nil, nil
+	}
+	if dueDate.CheckValid() != nil || dueDate.AsTime().IsZero() {
+		return nil, common.NewInvalidDueDateError("dueDate isn't a valid time")
+	}
+	due := dueDate.AsTime()
+	return &due, nil
+}
+
+// checkExpectedVersion rejects commands that expect a version of the task that
+// isn't the current one, commands without an expected version aren't checked
+func checkExpectedVersion(task *Task, expected *uint64) error {
+	if expected == nil || *expected == task.Version {
+		return nil
+	}
+	return common.NewVersionConflictError(fmt.Sprintf(
+		"expected version %d but task is at version %d",
+		*expected,
+		task.Version,
+	))
+}
@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+)
+
+func TestListOptionsFromQueryMetadata(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata map[string]string
+		keys     []string
+		invalid  bool
+	}{
+		{name: "none"},
+		{
+			name:     "values",
+			metadata: map[string]string{"ref": "a", "Team_1.x-y": "b"},
+		},
+		{name: "keys", keys: []string{"ref", "Team_1.x-y"}},
+		{
+			name:     "values and keys",
+			metadata: map[string]string{"ref": ""},
+			keys:     []string{"team"},
+		},
+		{name: "empty value key", metadata: map[string]string{"": "a"}, invalid: true},
+		{name: "quoted value key", metadata: map[string]string{`a"b`: "a"}, invalid: true},
+		{name: "path value key", metadata: map[string]string{"$.a": "a"}, invalid: true},
+		{name: "spaced value key", metadata: map[string]string{"a b": "a"}, invalid: true},
+		{name: "empty key", keys: []string{""}, invalid: true},
+		{name: "quoted key", keys: []string{"ref", `a"b`}, invalid: true},
+		{name: "nested key", keys: []string{"a[0]"}, invalid: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			opts, err := listOptionsFromQuery(&contracts.ListTasksQuery{
+				Metadata:     c.metadata,
+				MetadataKeys: c.keys,
+			})
+			if c.invalid {
+				if !common.IsError(err, common.InvalidListTasksQueryErrorCode) {
+					t.Fatalf("expected invalid query error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(opts.Metadata, c.metadata) ||
+				!reflect.DeepEqual(opts.MetadataKeys, c.keys) {
+				t.Fatalf(
+					"expected metadata %v and keys %v, got %v and %v",
+					c.metadata,
+					c.keys,
+					opts.Metadata,
+					opts.MetadataKeys,
+				)
+			}
+		})
+	}
+}
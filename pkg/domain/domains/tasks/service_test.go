@@ -0,0 +1,1197 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+
+	"github.com/bwmarrin/snowflake"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// harness a tasks service backed by the in memory repositories, every call
+// runs in it's own committed transaction like a request would
+type harness struct {
+	t    *testing.T
+	svc  *tasks.Service
+	repo tasks.IRepository
+	frgr foreigns.IRepository
+	ctxf cntxt.IFactory
+}
+
+func newHarness(t *testing.T, opts *tasks.Options) *harness {
+	t.Helper()
+	return newWrappedHarness(
+		t,
+		opts,
+		func(repo tasks.IRepository) tasks.IRepository { return repo },
+	)
+}
+
+// newWrappedHarness creates a harness with the service using the tasks
+// repository given by wrap
+func newWrappedHarness(
+	t *testing.T,
+	opts *tasks.Options,
+	wrap func(repo tasks.IRepository) tasks.IRepository,
+) *harness {
+	t.Helper()
+	lgrf, err := lgr.NewLoggerFactory()
+	if err != nil {
+		t.Fatalf("failed to create logger factory: %v", err)
+	}
+	sf, err := snowflake.NewNode(1)
+	if err != nil {
+		t.Fatalf("failed to create snowflake node: %v", err)
+	}
+	mdvl, err := jsonschema.NewValidator(&jsonschema.Options{})
+	if err != nil {
+		t.Fatalf("failed to create metadata validator: %v", err)
+	}
+	if len(opts.Workflow.Transitions) == 0 {
+		opts.Workflow = tasks.DefaultWorkflow()
+	}
+
+	hub := eventhub.NewHub(&eventhub.Options{PollInterval: time.Minute})
+	store := repos.NewMemoryStore()
+	repo := repos.NewTasksRepository(store)
+	frgr := repos.NewForeignsRepository(store)
+	return &harness{
+		t: t,
+		svc: tasks.NewService(
+			opts,
+			wrap(repo),
+			lgrf,
+			repos.NewACLRepository(store),
+			repos.NewUIDRepository(sf),
+			frgr,
+			hub,
+			mdvl,
+		),
+		repo: repo,
+		frgr: frgr,
+		ctxf: repos.NewContextFactory(lgrf, hub),
+	}
+}
+
+// run runs fn in a new context, the transaction is committed if fn succeeds
+func (h *harness) run(fn func(ctx cntxt.IContext) error) error {
+	ctx := h.ctxf.Create("")
+	defer ctx.Cancel()
+	err := fn(ctx)
+	if err != nil {
+		ctx.RollbackTransaction()
+		return err
+	}
+	return ctx.CommitTransaction()
+}
+
+func user(id string) *contracts.UserContext {
+	return &contracts.UserContext{UserType: common.UserTypeUser, Id: id}
+}
+
+// create creates a task owned by the caller, under the parent if it's set
+func (h *harness) create(caller *contracts.UserContext, parentID string) string {
+	h.t.Helper()
+	cmd := &contracts.CreateTaskCommand{
+		UserContext: caller,
+		Title:       "task",
+		Description: "task",
+	}
+	if parentID != "" {
+		cmd.ParentId = &parentID
+	}
+	var id string
+	err := h.run(func(ctx cntxt.IContext) error {
+		evnt, err := h.svc.CreateTask(ctx, cmd)
+		if err == nil {
+			id = evnt.StreamId
+		}
+		return err
+	})
+	if err != nil {
+		h.t.Fatalf("failed to create task: %v", err)
+	}
+	return id
+}
+
+// exists checks the repository directly since queries of the task can fail on
+// the acl of the caller
+func (h *harness) exists(id string) bool {
+	h.t.Helper()
+	err := h.run(func(ctx cntxt.IContext) error {
+		_, err := h.repo.Get(ctx, id)
+		return err
+	})
+	if common.IsError(err, common.TaskMissingErrorCode) {
+		return false
+	}
+	if err != nil {
+		h.t.Fatalf("failed to get task: %v", err)
+	}
+	return true
+}
+
+func (h *harness) move(caller *contracts.UserContext, id, parentID string) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.MoveTask(ctx, &contracts.MoveTaskCommand{
+			UserContext: caller,
+			Id:          id,
+			ParentId:    parentID,
+		})
+		return err
+	})
+}
+
+func (h *harness) progress(caller *contracts.UserContext, id string) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.ProgressTask(ctx, &contracts.ProgressTaskCommand{
+			UserContext: caller,
+			Id:          id,
+		})
+		return err
+	})
+}
+
+func (h *harness) complete(caller *contracts.UserContext, id string) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.CompleteTask(ctx, &contracts.CompleteTaskCommand{
+			UserContext: caller,
+			Id:          id,
+		})
+		return err
+	})
+}
+
+func (h *harness) transition(
+	caller *contracts.UserContext,
+	id string,
+	status contracts.Status,
+) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.TransitionTask(ctx, &contracts.TransitionTaskCommand{
+			UserContext: caller,
+			Id:          id,
+			Status:      status,
+		})
+		return err
+	})
+}
+
+func expectError(t *testing.T, err error, code int) {
+	t.Helper()
+	if !common.IsError(err, code) {
+		t.Fatalf("expected error %d, got %v", code, err)
+	}
+}
+
+func TestMoveTaskCycle(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	root := h.create(owner, "")
+	child := h.create(owner, root)
+	grandchild := h.create(owner, child)
+
+	cases := []struct {
+		name   string
+		parent string
+	}{
+		{name: "itself", parent: root},
+		{name: "subtask", parent: child},
+		{name: "nested subtask", parent: grandchild},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := h.move(owner, root, c.parent)
+			expectError(t, err, common.InvalidTaskParentErrorCode)
+		})
+	}
+
+	err := h.move(owner, grandchild, root)
+	if err != nil {
+		t.Fatalf("failed to move subtask under it's ancestor: %v", err)
+	}
+	err = h.move(owner, child, grandchild)
+	if err != nil {
+		t.Fatalf("failed to move task under a former subtask: %v", err)
+	}
+}
+
+// slowGetRepository holds back the tasks it gets so concurrent parent checks
+// both act on what they read before the other wrote
+type slowGetRepository struct {
+	tasks.IRepository
+}
+
+func (r *slowGetRepository) Get(
+	ctx context.Context,
+	id string,
+) (*tasks.Task, error) {
+	task, err := r.IRepository.Get(ctx, id)
+	time.Sleep(5 * time.Millisecond)
+	return task, err
+}
+
+// TestMoveTaskConcurrent tasks moved under each other concurrently can't both
+// be moved since the parent check is made holding the lock
+func TestMoveTaskConcurrent(t *testing.T) {
+	h := newWrappedHarness(
+		t,
+		&tasks.Options{},
+		func(repo tasks.IRepository) tasks.IRepository {
+			return &slowGetRepository{IRepository: repo}
+		},
+	)
+	owner := user("owner")
+	for round := 0; round < 5; round++ {
+		a := h.create(owner, "")
+		b := h.create(owner, "")
+
+		errs := make(chan error, 2)
+		go func() { errs <- h.move(owner, a, b) }()
+		go func() { errs <- h.move(owner, b, a) }()
+		moved := 0
+		for idx := 0; idx < 2; idx++ {
+			err := <-errs
+			if err == nil {
+				moved++
+				continue
+			}
+			expectError(t, err, common.InvalidTaskParentErrorCode)
+		}
+		if moved != 1 {
+			t.Fatalf("expected a single task to be moved, got %d", moved)
+		}
+	}
+}
+
+// TestLoopingHierarchy a hierarchy that loops, as concurrent moves could leave
+// it without the hierarchy lock, fails the walks over it instead of hanging
+func TestLoopingHierarchy(t *testing.T) {
+	h := newHarness(t, &tasks.Options{
+		SubtaskDeletePolicy: tasks.SubtaskDeleteCascade,
+	})
+	owner := user("owner")
+	root := h.create(owner, "")
+	child := h.create(owner, root)
+	other := h.create(owner, "")
+
+	// placing the root under it's child behind the back of the service
+	err := h.run(func(ctx cntxt.IContext) error {
+		_, err := h.repo.Move(ctx, root, nil, 1, child)
+		if err != nil {
+			return err
+		}
+		err = h.frgr.EnsureForeignItem(ctx, nil, common.TaskStreamName, child)
+		if err != nil {
+			return err
+		}
+		return h.frgr.RegisterConstraint(
+			ctx,
+			nil,
+			common.TaskStreamName,
+			child,
+			common.TaskStreamName,
+			root,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to loop hierarchy: %v", err)
+	}
+
+	err = h.move(owner, other, child)
+	expectError(t, err, common.TaskHierarchyCycleErrorCode)
+
+	err = h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.DeleteTask(ctx, &contracts.DeleteTaskCommand{
+			UserContext: owner,
+			Id:          root,
+		})
+		return err
+	})
+	expectError(t, err, common.TaskHierarchyCycleErrorCode)
+	if !h.exists(root) || !h.exists(child) {
+		t.Fatalf("expected failed delete to be rolled back")
+	}
+}
+
+func TestDeleteTaskSubtaskPolicy(t *testing.T) {
+	cases := []struct {
+		policy tasks.SubtaskDeletePolicy
+		code   int
+	}{
+		{policy: tasks.SubtaskDeleteBlock, code: common.TaskHasSubtasksErrorCode},
+		{policy: tasks.SubtaskDeleteCascade},
+	}
+	for _, c := range cases {
+		t.Run(string(c.policy), func(t *testing.T) {
+			h := newHarness(t, &tasks.Options{SubtaskDeletePolicy: c.policy})
+			owner := user("owner")
+			root := h.create(owner, "")
+			child := h.create(owner, root)
+			grandchild := h.create(owner, child)
+
+			err := h.run(func(ctx cntxt.IContext) error {
+				_, err := h.svc.DeleteTask(ctx, &contracts.DeleteTaskCommand{
+					UserContext: owner,
+					Id:          root,
+				})
+				return err
+			})
+			if c.code != 0 {
+				expectError(t, err, c.code)
+			} else if err != nil {
+				t.Fatalf("failed to delete task: %v", err)
+			}
+
+			for _, id := range []string{root, child, grandchild} {
+				if h.exists(id) != (c.code != 0) {
+					t.Fatalf("unexpected existence of task %s", id)
+				}
+			}
+		})
+	}
+}
+
+func TestCompleteTaskOpenSubtasks(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	root := h.create(owner, "")
+	child := h.create(owner, root)
+
+	err := h.progress(owner, root)
+	if err != nil {
+		t.Fatalf("failed to progress task: %v", err)
+	}
+	err = h.complete(owner, root)
+	expectError(t, err, common.OpenSubtasksErrorCode)
+
+	err = h.progress(owner, child)
+	if err != nil {
+		t.Fatalf("failed to progress subtask: %v", err)
+	}
+	err = h.complete(owner, root)
+	expectError(t, err, common.OpenSubtasksErrorCode)
+
+	err = h.complete(owner, child)
+	if err != nil {
+		t.Fatalf("failed to complete subtask: %v", err)
+	}
+	err = h.complete(owner, root)
+	if err != nil {
+		t.Fatalf("failed to complete task: %v", err)
+	}
+
+	// an open task can't be placed under the completed task
+	open := h.create(owner, "")
+	err = h.move(owner, open, root)
+	expectError(t, err, common.InvalidTaskParentErrorCode)
+}
+
+func (h *harness) share(
+	caller *contracts.UserContext,
+	id string,
+	userID string,
+	level contracts.AccessLevel,
+) {
+	h.t.Helper()
+	err := h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.ShareTask(ctx, &contracts.ShareTaskCommand{
+			UserContext: caller,
+			Id:          id,
+			UserType:    common.UserTypeUser,
+			UserId:      userID,
+			AccessLevel: level,
+		})
+		return err
+	})
+	if err != nil {
+		h.t.Fatalf("failed to share task: %v", err)
+	}
+}
+
+func (h *harness) block(
+	caller *contracts.UserContext,
+	id string,
+	blockerID string,
+	sagaID *string,
+) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.AddTaskDependency(
+			ctx,
+			&contracts.AddTaskDependencyCommand{
+				UserContext: caller,
+				Id:          id,
+				BlockerId:   blockerID,
+				SagaId:      sagaID,
+			},
+		)
+		return err
+	})
+}
+
+func (h *harness) unblock(
+	caller *contracts.UserContext,
+	id string,
+	blockerID string,
+	sagaID *string,
+) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.RemoveTaskDependency(
+			ctx,
+			&contracts.RemoveTaskDependencyCommand{
+				UserContext: caller,
+				Id:          id,
+				BlockerId:   blockerID,
+				SagaId:      sagaID,
+			},
+		)
+		return err
+	})
+}
+
+// graph gives the readable tasks and the dependencies of the graph of the
+// task, the dependencies are given as "task<-blocker"
+func (h *harness) graph(
+	caller *contracts.UserContext,
+	id string,
+) (map[string]bool, map[string]bool) {
+	h.t.Helper()
+	var graph *contracts.TaskDependencyGraph
+	err := h.run(func(ctx cntxt.IContext) error {
+		var err error
+		graph, err = h.svc.GetTaskDependencies(
+			ctx,
+			&contracts.GetTaskDependenciesQuery{UserContext: caller, Id: id},
+		)
+		return err
+	})
+	if err != nil {
+		h.t.Fatalf("failed to get task dependencies: %v", err)
+	}
+	tsks := map[string]bool{}
+	for _, task := range graph.Tasks {
+		tsks[task.Id] = true
+	}
+	deps := map[string]bool{}
+	for _, dep := range graph.Dependencies {
+		key := dep.TaskId + "<-" + dep.BlockerId
+		if deps[key] {
+			h.t.Fatalf("dependency %s given more than once", key)
+		}
+		deps[key] = true
+	}
+	return tsks, deps
+}
+
+func TestAddTaskDependencyRejected(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	a, b, c := h.create(owner, ""), h.create(owner, ""), h.create(owner, "")
+	stranger := h.create(user("stranger"), "")
+
+	// a is blocked by b which is blocked by c
+	for _, dep := range [][2]string{{a, b}, {b, c}} {
+		err := h.block(owner, dep[0], dep[1], nil)
+		if err != nil {
+			t.Fatalf("failed to add dependency: %v", err)
+		}
+	}
+
+	cases := []struct {
+		name    string
+		id      string
+		blocker string
+		code    int
+	}{
+		{name: "self", id: a, blocker: a, code: common.InvalidTaskDependencyErrorCode},
+		{name: "direct cycle", id: b, blocker: a, code: common.InvalidTaskDependencyErrorCode},
+		{name: "transitive cycle", id: c, blocker: a, code: common.InvalidTaskDependencyErrorCode},
+		{name: "duplicate", id: a, blocker: b, code: common.NoTaskUpdatesErrorCode},
+		{name: "missing blocker", id: a, blocker: "missing", code: common.UserACLCheckFailedErrorCode},
+		{name: "unreadable blocker", id: a, blocker: stranger, code: common.UserACLCheckFailedErrorCode},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := h.block(owner, c.id, c.blocker, nil)
+			expectError(t, err, c.code)
+		})
+	}
+
+	// a transitive blocker can still block directly
+	err := h.block(owner, a, c, nil)
+	if err != nil {
+		t.Fatalf("failed to add dependency on transitive blocker: %v", err)
+	}
+}
+
+// slowBlockersRepository holds back the blockers it lists so concurrent cycle
+// checks both act on what they read before the other wrote
+type slowBlockersRepository struct {
+	tasks.IRepository
+}
+
+func (r *slowBlockersRepository) ListBlockers(
+	ctx context.Context,
+	id string,
+) ([]string, error) {
+	ids, err := r.IRepository.ListBlockers(ctx, id)
+	time.Sleep(5 * time.Millisecond)
+	return ids, err
+}
+
+// TestAddTaskDependencyConcurrent tasks blocking each other concurrently
+// can't both be added since the cycle check is made holding the lock
+func TestAddTaskDependencyConcurrent(t *testing.T) {
+	h := newWrappedHarness(
+		t,
+		&tasks.Options{},
+		func(repo tasks.IRepository) tasks.IRepository {
+			return &slowBlockersRepository{IRepository: repo}
+		},
+	)
+	owner := user("owner")
+	for round := 0; round < 5; round++ {
+		a := h.create(owner, "")
+		b := h.create(owner, "")
+
+		errs := make(chan error, 2)
+		go func() { errs <- h.block(owner, a, b, nil) }()
+		go func() { errs <- h.block(owner, b, a, nil) }()
+		added := 0
+		for idx := 0; idx < 2; idx++ {
+			err := <-errs
+			if err == nil {
+				added++
+				continue
+			}
+			expectError(t, err, common.InvalidTaskDependencyErrorCode)
+		}
+		if added != 1 {
+			t.Fatalf("expected a single dependency to be added, got %d", added)
+		}
+	}
+}
+
+func TestGetTaskDependencies(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	other := user("other")
+
+	// a chain d <- c <- b <- a with e also blocked by c, c is owned by another
+	// user so the owner can't read it
+	a, b := h.create(owner, ""), h.create(owner, "")
+	c := h.create(other, "")
+	d, e := h.create(owner, ""), h.create(owner, "")
+	h.share(owner, b, other.Id, contracts.AccessLevel_READ)
+	h.share(owner, d, other.Id, contracts.AccessLevel_READ_WRITE)
+	h.share(owner, e, other.Id, contracts.AccessLevel_READ_WRITE)
+	deps := [][3]string{
+		{b, a, owner.Id},
+		{c, b, other.Id},
+		{d, c, other.Id},
+		{e, c, other.Id},
+	}
+	for _, dep := range deps {
+		err := h.block(user(dep[2]), dep[0], dep[1], nil)
+		if err != nil {
+			t.Fatalf("failed to add dependency: %v", err)
+		}
+	}
+
+	all := map[string]bool{
+		b + "<-" + a: true,
+		c + "<-" + b: true,
+		d + "<-" + c: true,
+		e + "<-" + c: true,
+	}
+	cases := []struct {
+		name   string
+		caller *contracts.UserContext
+		id     string
+		tasks  []string
+		deps   map[string]bool
+	}{
+		{
+			name:   "from the first blocker",
+			caller: owner,
+			id:     a,
+			tasks:  []string{a, b, d, e},
+			deps:   all,
+		},
+		{
+			name:   "from the last blocked",
+			caller: owner,
+			id:     d,
+			tasks:  []string{a, b, d},
+			deps: map[string]bool{
+				b + "<-" + a: true,
+				c + "<-" + b: true,
+				d + "<-" + c: true,
+			},
+		},
+		{
+			name:   "from the middle",
+			caller: other,
+			id:     c,
+			tasks:  []string{b, c, d, e},
+			deps:   all,
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			tsks, gdeps := h.graph(cs.caller, cs.id)
+			if len(tsks) != len(cs.tasks) {
+				t.Fatalf("expected tasks %v, got %v", cs.tasks, tsks)
+			}
+			for _, id := range cs.tasks {
+				if !tsks[id] {
+					t.Fatalf("expected tasks %v, got %v", cs.tasks, tsks)
+				}
+			}
+			if len(gdeps) != len(cs.deps) {
+				t.Fatalf("expected dependencies %v, got %v", cs.deps, gdeps)
+			}
+			for dep := range cs.deps {
+				if !gdeps[dep] {
+					t.Fatalf("expected dependencies %v, got %v", cs.deps, gdeps)
+				}
+			}
+		})
+	}
+}
+
+func TestCompensateTaskDependencies(t *testing.T) {
+	owner := user("owner")
+	saga := "saga"
+	compensate := func(h *harness, id string) error {
+		return h.run(func(ctx cntxt.IContext) error {
+			return h.svc.CompensateTask(ctx, saga, id, 2)
+		})
+	}
+
+	// a is blocked by b at version 1, the saga replaces b with c
+	setup := func(t *testing.T) (*harness, string, string, string) {
+		h := newHarness(t, &tasks.Options{})
+		a, b, c := h.create(owner, ""), h.create(owner, ""), h.create(owner, "")
+		err := h.block(owner, a, b, nil)
+		if err == nil {
+			err = h.unblock(owner, a, b, &saga)
+		}
+		if err == nil {
+			err = h.block(owner, a, c, &saga)
+		}
+		if err != nil {
+			t.Fatalf("failed to change dependencies: %v", err)
+		}
+		return h, a, b, c
+	}
+
+	t.Run("restored", func(t *testing.T) {
+		h, a, b, _ := setup(t)
+		err := compensate(h, a)
+		if err != nil {
+			t.Fatalf("failed to compensate task: %v", err)
+		}
+		_, deps := h.graph(owner, a)
+		if len(deps) != 1 || !deps[a+"<-"+b] {
+			t.Fatalf("unexpected dependencies %v", deps)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		h, a, b, _ := setup(t)
+		err := h.block(owner, b, a, nil)
+		if err != nil {
+			t.Fatalf("failed to add dependency: %v", err)
+		}
+		err = compensate(h, a)
+		expectError(t, err, common.SagaNotCompensatableErrorCode)
+	})
+}
+
+func TestWorkflowTerminalStatuses(t *testing.T) {
+	pending := contracts.Status_PENDING.String()
+	progress := contracts.Status_PROGRESS.String()
+	review := contracts.Status_REVIEW.String()
+	completed := contracts.Status_COMPLETED.String()
+	cancelled := contracts.Status_CANCELLED.String()
+
+	cases := []struct {
+		name     string
+		workflow tasks.Workflow
+		terminal []string
+	}{
+		{
+			name:     "default",
+			workflow: tasks.DefaultWorkflow(),
+			terminal: []string{completed},
+		},
+		{
+			name: "cancellable",
+			workflow: tasks.Workflow{Transitions: []tasks.Transition{
+				{Event: "started", From: []string{pending}, To: review},
+				{Event: "approved", From: []string{review}, To: completed},
+				{Event: "cancelled", From: []string{pending, review}, To: cancelled},
+			}},
+			terminal: []string{completed, cancelled},
+		},
+		{
+			name: "reopenable",
+			workflow: tasks.Workflow{Transitions: []tasks.Transition{
+				{Event: "started", From: []string{pending}, To: progress},
+				{Event: "finished", From: []string{progress}, To: completed},
+				{Event: "reopened", From: []string{completed}, To: progress},
+			}},
+			terminal: []string{completed},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			terminal := c.workflow.TerminalStatuses()
+			if len(terminal) != len(c.terminal) {
+				t.Fatalf("expected %v, got %v", c.terminal, terminal)
+			}
+			for idx := range terminal {
+				if terminal[idx] != c.terminal[idx] {
+					t.Fatalf("expected %v, got %v", c.terminal, terminal)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkflowValidateGuards(t *testing.T) {
+	pending := contracts.Status_PENDING.String()
+	completed := contracts.Status_COMPLETED.String()
+
+	cases := []struct {
+		name   string
+		guards []string
+		valid  bool
+	}{
+		{name: "none", valid: true},
+		{
+			name: "built in",
+			guards: []string{
+				tasks.GuardBlockersCompleted,
+				tasks.GuardSubtasksCompleted,
+			},
+			valid: true,
+		},
+		{name: "unknown", guards: []string{"approved"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wrkf := tasks.Workflow{Transitions: []tasks.Transition{{
+				Event:  "finished",
+				From:   []string{pending},
+				To:     completed,
+				Guards: c.guards,
+			}}}
+			err := wrkf.Validate()
+			if c.valid && err != nil {
+				t.Fatalf("expected workflow to be valid, got %v", err)
+			}
+			if !c.valid && err == nil {
+				t.Fatalf("expected workflow to be invalid")
+			}
+		})
+	}
+	dflt := tasks.DefaultWorkflow()
+	if err := dflt.Validate(); err != nil {
+		t.Fatalf("expected default workflow to be valid, got %v", err)
+	}
+}
+
+// TestWorkflowTerminalChecks tasks in any terminal status of the workflow
+// count as finished for the guards and the parent check
+func TestWorkflowTerminalChecks(t *testing.T) {
+	pending := contracts.Status_PENDING.String()
+	progress := contracts.Status_PROGRESS.String()
+	h := newHarness(t, &tasks.Options{
+		Workflow: tasks.Workflow{Transitions: []tasks.Transition{
+			{Event: "started", From: []string{pending}, To: progress},
+			{
+				Event: "finished",
+				From:  []string{progress},
+				To:    contracts.Status_COMPLETED.String(),
+				Guards: []string{
+					tasks.GuardSubtasksCompleted,
+					tasks.GuardBlockersCompleted,
+				},
+			},
+			{
+				Event: "cancelled",
+				From:  []string{pending, progress},
+				To:    contracts.Status_CANCELLED.String(),
+			},
+		}},
+	})
+	owner := user("owner")
+	root := h.create(owner, "")
+	child := h.create(owner, root)
+	blocker := h.create(owner, "")
+	err := h.block(owner, root, blocker, nil)
+	if err != nil {
+		t.Fatalf("failed to add dependency: %v", err)
+	}
+	err = h.transition(owner, root, contracts.Status_PROGRESS)
+	if err != nil {
+		t.Fatalf("failed to start task: %v", err)
+	}
+
+	err = h.transition(owner, root, contracts.Status_COMPLETED)
+	expectError(t, err, common.OpenSubtasksErrorCode)
+	err = h.transition(owner, child, contracts.Status_CANCELLED)
+	if err != nil {
+		t.Fatalf("failed to cancel subtask: %v", err)
+	}
+
+	err = h.transition(owner, root, contracts.Status_COMPLETED)
+	expectError(t, err, common.TaskBlockedErrorCode)
+	err = h.transition(owner, blocker, contracts.Status_CANCELLED)
+	if err != nil {
+		t.Fatalf("failed to cancel blocker: %v", err)
+	}
+
+	err = h.transition(owner, root, contracts.Status_COMPLETED)
+	if err != nil {
+		t.Fatalf("failed to complete task: %v", err)
+	}
+
+	// finished tasks can be placed under finished tasks, open tasks can't
+	err = h.move(owner, blocker, child)
+	if err != nil {
+		t.Fatalf("failed to move cancelled task: %v", err)
+	}
+	open := h.create(owner, "")
+	err = h.move(owner, open, child)
+	expectError(t, err, common.InvalidTaskParentErrorCode)
+}
+
+func TestQueryTaskOverdueTerminal(t *testing.T) {
+	pending := contracts.Status_PENDING.String()
+	h := newHarness(t, &tasks.Options{
+		Workflow: tasks.Workflow{Transitions: []tasks.Transition{
+			{
+				Event: "cancelled",
+				From:  []string{pending},
+				To:    contracts.Status_CANCELLED.String(),
+			},
+		}},
+	})
+	owner := user("owner")
+	due := timestamppb.New(time.Now().Add(-time.Hour))
+	ids := make([]string, 2)
+	for idx := range ids {
+		err := h.run(func(ctx cntxt.IContext) error {
+			evnt, err := h.svc.CreateTask(ctx, &contracts.CreateTaskCommand{
+				UserContext: owner,
+				Title:       "task",
+				Description: "task",
+				DueDate:     due,
+			})
+			if err == nil {
+				ids[idx] = evnt.StreamId
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+	err := h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.TransitionTask(ctx, &contracts.TransitionTaskCommand{
+			UserContext: owner,
+			Id:          ids[1],
+			Status:      contracts.Status_CANCELLED,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to cancel task: %v", err)
+	}
+
+	var list *contracts.TaskEntityList
+	err = h.run(func(ctx cntxt.IContext) error {
+		var err error
+		list, err = h.svc.QueryTask(ctx, &contracts.ListTasksQuery{
+			UserContext: owner,
+			Overdue:     true,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].Id != ids[0] {
+		t.Fatalf("expected only task %s to be overdue, got %v", ids[0], list.Tasks)
+	}
+}
+
+func TestDeletedTaskHistory(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	id := h.create(owner, "")
+	h.share(owner, id, "reader", contracts.AccessLevel_READ)
+
+	err := h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.DeleteTask(ctx, &contracts.DeleteTaskCommand{
+			UserContext: owner,
+			Id:          id,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+
+	for _, caller := range []*contracts.UserContext{owner, user("reader")} {
+		var hist *contracts.TaskEventList
+		err = h.run(func(ctx cntxt.IContext) error {
+			var err error
+			hist, err = h.svc.GetTaskHistory(ctx, &contracts.GetTaskHistoryQuery{
+				UserContext: caller,
+				Id:          id,
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("failed to get history of deleted task: %v", err)
+		}
+		last := hist.Events[len(hist.Events)-1]
+		if last.Event != common.EventDeleted {
+			t.Fatalf("expected history to end with the delete, got %s", last.Event)
+		}
+
+		version := last.Version - 1
+		err = h.run(func(ctx cntxt.IContext) error {
+			_, err := h.svc.GetTaskAsOf(ctx, &contracts.GetTaskAsOfQuery{
+				UserContext: caller,
+				Id:          id,
+				Version:     &version,
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("failed to get deleted task as of %d: %v", version, err)
+		}
+	}
+
+	// the task can't be written or read as it is now
+	err = h.progress(owner, id)
+	expectError(t, err, common.TaskMissingErrorCode)
+	err = h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.GetTaskHistory(ctx, &contracts.GetTaskHistoryQuery{
+			UserContext: user("stranger"),
+			Id:          id,
+		})
+		return err
+	})
+	expectError(t, err, common.UserACLCheckFailedErrorCode)
+}
+
+// subscribe subscribes the caller to task events from the event id, the
+// subscription is stopped along with the test
+func (h *harness) subscribe(
+	caller *contracts.UserContext,
+	from uint64,
+) <-chan *contracts.TaskEvent {
+	h.t.Helper()
+	ch := make(chan *contracts.TaskEvent, 100)
+	done := make(chan error, 1)
+	ctx := h.ctxf.Create("")
+	go func() {
+		done <- h.svc.SubscribeEvents(
+			ctx,
+			&contracts.SubscribeEventsQuery{
+				UserContext: caller,
+				FromEventId: from,
+			},
+			func(evnt *contracts.TaskEvent) error {
+				ch <- evnt
+				return nil
+			},
+		)
+	}()
+	h.t.Cleanup(func() {
+		ctx.Cancel()
+		if err := <-done; err != nil {
+			h.t.Errorf("subscription failed: %v", err)
+		}
+	})
+	return ch
+}
+
+// expectEvents checks the next events received are the events of the tasks
+// in order, given as task id and event name pairs
+func expectEvents(
+	t *testing.T,
+	ch <-chan *contracts.TaskEvent,
+	expected ...string,
+) []*contracts.TaskEvent {
+	t.Helper()
+	res := make([]*contracts.TaskEvent, 0, len(expected)/2)
+	for idx := 0; idx < len(expected); idx += 2 {
+		select {
+		case evnt := <-ch:
+			if evnt.StreamId != expected[idx] || evnt.Event != expected[idx+1] {
+				t.Fatalf(
+					"expected %s of task %s, got %s of task %s",
+					expected[idx+1],
+					expected[idx],
+					evnt.Event,
+					evnt.StreamId,
+				)
+			}
+			res = append(res, evnt)
+		case <-time.After(time.Second):
+			t.Fatalf(
+				"expected %s of task %s, got nothing",
+				expected[idx+1],
+				expected[idx],
+			)
+		}
+	}
+	return res
+}
+
+// expectNoEvents checks that no event is received for a while
+func expectNoEvents(t *testing.T, ch <-chan *contracts.TaskEvent) {
+	t.Helper()
+	select {
+	case evnt := <-ch:
+		t.Fatalf(
+			"expected no events, got %s of task %s",
+			evnt.Event,
+			evnt.StreamId,
+		)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	other := user("other")
+	first := h.create(owner, "")
+	hidden := h.create(other, "")
+	shared := h.create(other, "")
+	h.share(other, shared, "owner", contracts.AccessLevel_READ)
+
+	// catching up with the committed events, only readable tasks are sent
+	ch := h.subscribe(owner, 0)
+	expectEvents(
+		t,
+		ch,
+		first, common.EventCreated,
+		shared, common.EventCreated,
+		shared, common.EventShared,
+	)
+
+	// new events are sent once they're committed
+	err := h.progress(other, hidden)
+	if err != nil {
+		t.Fatalf("failed to progress task: %v", err)
+	}
+	err = h.progress(owner, first)
+	if err != nil {
+		t.Fatalf("failed to progress task: %v", err)
+	}
+	second := h.create(owner, "")
+	expectEvents(
+		t,
+		ch,
+		first, common.EventProgressed,
+		second, common.EventCreated,
+	)
+
+	// the delete is sent to the readers even though the task is gone
+	err = h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.DeleteTask(ctx, &contracts.DeleteTaskCommand{
+			UserContext: other,
+			Id:          shared,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	deleted := expectEvents(t, ch, shared, common.EventDeleted)
+
+	// catching up from an event id along with the events of deleted tasks
+	expectEvents(
+		t,
+		h.subscribe(owner, deleted[0].Id),
+		shared, common.EventDeleted,
+	)
+	expectNoEvents(t, h.subscribe(user("reader"), 0))
+	expectEvents(
+		t,
+		h.subscribe(other, 0),
+		hidden, common.EventCreated,
+		shared, common.EventCreated,
+		shared, common.EventShared,
+		hidden, common.EventProgressed,
+		shared, common.EventDeleted,
+	)
+}
+
+func (h *harness) assign(
+	caller *contracts.UserContext,
+	id string,
+	assignee string,
+) error {
+	return h.run(func(ctx cntxt.IContext) error {
+		_, err := h.svc.AssignTask(ctx, &contracts.AssignTaskCommand{
+			UserContext: caller,
+			Id:          id,
+			Assignee:    assignee,
+		})
+		return err
+	})
+}
+
+func TestAssignTaskAccess(t *testing.T) {
+	h := newHarness(t, &tasks.Options{})
+	owner := user("owner")
+	writer := user("writer")
+	id := h.create(owner, "")
+	h.share(owner, id, "writer", contracts.AccessLevel_READ_WRITE)
+	h.share(owner, id, "colleague", contracts.AccessLevel_READ_WRITE)
+
+	// assigning without write access would share the task
+	err := h.assign(writer, id, "mallory")
+	expectError(t, err, common.UserACLCheckFailedErrorCode)
+	err = h.progress(user("mallory"), id)
+	expectError(t, err, common.UserACLCheckFailedErrorCode)
+
+	err = h.assign(writer, id, "colleague")
+	if err != nil {
+		t.Fatalf("failed to assign task to a user with write access: %v", err)
+	}
+	err = h.assign(writer, id, "")
+	if err != nil {
+		t.Fatalf("failed to unassign task: %v", err)
+	}
+
+	err = h.assign(owner, id, "mallory")
+	if err != nil {
+		t.Fatalf("failed to assign task: %v", err)
+	}
+	err = h.progress(user("mallory"), id)
+	if err != nil {
+		t.Fatalf("failed to progress task as the assignee: %v", err)
+	}
+}
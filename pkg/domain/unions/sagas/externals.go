@@ -0,0 +1,24 @@
+package sagas
+
+import (
+	"context"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+)
+
+// IRepository repo interface for the state of sagas, saga state is recorded by
+// the implementation as entities are written under a saga
+type IRepository interface {
+	Get(
+		ctx context.Context,
+		sagaID string,
+	) (*Saga, error)
+	// ListEvents lists all events written under a saga ordered by event id
+	ListEvents(
+		ctx context.Context,
+		sagaID string,
+	) ([]events.EventEntity, error)
+	MarkCompensated(
+		ctx context.Context,
+		sagaID string,
+	) (*Saga, error)
+}
@@ -0,0 +1,33 @@
+package sagas
+
+import (
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Saga struct {
+	SagaId              string
+	Status              string
+	StepCount           uint64
+	LastEventId         uint64
+	DateTimeCreated     time.Time
+	DateTimeUpdated     time.Time
+	DateTimeCompensated *time.Time
+}
+
+func (s *Saga) ToContract() *contracts.SagaEntity {
+	res := &contracts.SagaEntity{
+		SagaId:          s.SagaId,
+		Status:          s.Status,
+		StepCount:       s.StepCount,
+		LastEventId:     s.LastEventId,
+		CreatedDateTime: timestamppb.New(s.DateTimeCreated),
+		UpdatedDateTime: timestamppb.New(s.DateTimeUpdated),
+	}
+	if s.DateTimeCompensated != nil {
+		res.CompensatedDateTime = timestamppb.New(*s.DateTimeCompensated)
+	}
+	return res
+}
@@ -0,0 +1,172 @@
+// Package sagas contains the business logic around sagas, writes made across
+// the domains under a saga can be compensated together
+package sagas
+
+import (
+	"context"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+
+	"go.uber.org/zap"
+)
+
+// Service handles business logic and use cases around sagas
+type Service struct {
+	repo IRepository
+	lgrf logger.IFactory
+	unqr uniques.IRepository
+	frgr foreigns.IRepository
+	tsks *tasks.Service
+	qots *quotes.Service
+}
+
+// NewService constructs a Service
+func NewService(
+	repo IRepository,
+	lgrf logger.IFactory,
+	unqr uniques.IRepository,
+	frgr foreigns.IRepository,
+	tsks *tasks.Service,
+	qots *quotes.Service,
+) *Service {
+	return &Service{
+		repo: repo,
+		lgrf: lgrf,
+		unqr: unqr,
+		frgr: frgr,
+		tsks: tsks,
+		qots: qots,
+	}
+}
+
+// entityRef an entity written to under a saga along with the version of the
+// first event the saga wrote to it
+type entityRef struct {
+	stream      string
+	id          string
+	fromVersion uint64
+}
+
+// CompensateSaga undoes everything written under a saga, compensating events
+// are written to every entity the saga changed (in reverse order of when the
+// saga first changed them) and the constraints registered under the saga are
+// removed
+func (s *Service) CompensateSaga(
+	ctx context.Context,
+	cmd *contracts.CompensateSagaCommand,
+) (*contracts.SagaEntity, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("compensating saga", zap.String("sagaId", cmd.SagaId))
+
+	if cmd.UserContext.UserType != common.UserTypeApp {
+		lgr.Error("only applications allowed to compensate sagas")
+		return nil, common.NewInvalidUserTypeForSagaError()
+	}
+
+	saga, err := s.repo.Get(ctx, cmd.SagaId)
+	if err != nil {
+		lgr.Error("failed to fetch saga", zap.Error(err))
+		return nil, err
+	}
+	if saga.Status == common.SagaStatusCompensated {
+		lgr.Error("saga already compensated")
+		return nil, common.NewSagaCompensatedError()
+	}
+
+	evnts, err := s.repo.ListEvents(ctx, cmd.SagaId)
+	if err != nil {
+		lgr.Error("failed to fetch saga events", zap.Error(err))
+		return nil, err
+	}
+	refs := []entityRef{}
+	seen := map[string]struct{}{}
+	for idx := range evnts {
+		key := evnts[idx].Stream + ":" + evnts[idx].StreamId
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		refs = append(refs, entityRef{
+			stream:      evnts[idx].Stream,
+			id:          evnts[idx].StreamId,
+			fromVersion: evnts[idx].Version,
+		})
+	}
+
+	for idx := len(refs) - 1; idx >= 0; idx-- {
+		switch refs[idx].stream {
+		case common.TaskStreamName:
+			err = s.tsks.CompensateTask(
+				ctx,
+				cmd.SagaId,
+				refs[idx].id,
+				refs[idx].fromVersion,
+			)
+		case common.QuoteStreamName:
+			err = s.qots.CompensateQuote(
+				ctx,
+				cmd.SagaId,
+				refs[idx].id,
+				refs[idx].fromVersion,
+			)
+		default:
+			lgr.Warn(
+				"skipping entity of unknown stream",
+				zap.String("stream", refs[idx].stream),
+			)
+		}
+		if err != nil {
+			lgr.Error(
+				"failed to compensate entity",
+				zap.String("stream", refs[idx].stream),
+				zap.String("id", refs[idx].id),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	err = s.frgr.RemoveSagaEntries(ctx, cmd.SagaId)
+	if err != nil {
+		lgr.Error("failed to remove foreign entries", zap.Error(err))
+		return nil, err
+	}
+	err = s.unqr.RemoveSagaConstraints(ctx, cmd.SagaId)
+	if err != nil {
+		lgr.Error("failed to remove unique constraints", zap.Error(err))
+		return nil, err
+	}
+
+	saga, err = s.repo.MarkCompensated(ctx, cmd.SagaId)
+	if err != nil {
+		lgr.Error("failed to mark saga compensated", zap.Error(err))
+		return nil, err
+	}
+	return saga.ToContract(), nil
+}
+
+// GetSaga gets the state of a saga
+func (s *Service) GetSaga(
+	ctx context.Context,
+	qry *contracts.GetSagaQuery,
+) (*contracts.SagaEntity, error) {
+	lgr := s.lgrf.Create(ctx)
+	lgr.Info("fetching saga", zap.String("sagaId", qry.SagaId))
+
+	if qry.UserContext.UserType != common.UserTypeApp {
+		lgr.Error("only applications allowed to fetch sagas")
+		return nil, common.NewInvalidUserTypeForSagaError()
+	}
+
+	saga, err := s.repo.Get(ctx, qry.SagaId)
+	if err != nil {
+		lgr.Error("failed to fetch saga", zap.Error(err))
+		return nil, err
+	}
+	return saga.ToContract(), nil
+}
@@ -3,16 +3,26 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/cassdb"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/outbox"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/replay"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+	inmemrepos "techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/redisdb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/snowflake"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/sqlitedb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/appinsights"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/jaeger"
@@ -124,6 +134,116 @@ func NewSnowflakeOptions(_ *Initializer) *snowflake.Options {
 	}
 }
 
+// NewOutboxOptions provides outbox relay options
+func NewOutboxOptions(c *Initializer) *outbox.Options {
+	lgr := c.lgrf.Create(context.Background())
+	intrvl, err := strconv.Atoi(os.Getenv("OutboxPollIntervalMs"))
+	if err != nil || intrvl <= 0 {
+		intrvl = 1000
+		lgr.Warn("no valid outbox poll interval was provided, using default")
+	}
+	bsize, err := strconv.Atoi(os.Getenv("OutboxBatchSize"))
+	if err != nil || bsize <= 0 {
+		bsize = 100
+		lgr.Warn("no valid outbox batch size was provided, using default")
+	}
+	maxatt, err := strconv.Atoi(os.Getenv("OutboxMaxAttempts"))
+	if err != nil || maxatt <= 0 {
+		maxatt = 10
+		lgr.Warn("no valid outbox max attempts was provided, using default")
+	}
+
+	return &outbox.Options{
+		PollInterval: time.Duration(intrvl) * time.Millisecond,
+		BatchSize:    bsize,
+		MaxAttempts:  maxatt,
+	}
+}
+
+// NewEventHubOptions provides event hub options
+func NewEventHubOptions(c *Initializer) *eventhub.Options {
+	intrvl, err := strconv.Atoi(os.Getenv("EventPollIntervalMs"))
+	if err != nil || intrvl <= 0 {
+		intrvl = 1000
+		lgr := c.lgrf.Create(context.Background())
+		lgr.Warn("no valid event poll interval was provided, using default")
+	}
+
+	return &eventhub.Options{
+		PollInterval: time.Duration(intrvl) * time.Millisecond,
+	}
+}
+
+// NewReplayOptions provides read model replay options
+func NewReplayOptions(c *Initializer) *replay.Options {
+	bsize, err := strconv.Atoi(os.Getenv("ReplayBatchSize"))
+	if err != nil || bsize <= 0 {
+		bsize = 500
+		lgr := c.lgrf.Create(context.Background())
+		lgr.Warn("no valid replay batch size was provided, using default")
+	}
+
+	return &replay.Options{
+		BatchSize: bsize,
+	}
+}
+
+// NewSnapshotOptions provides the snapshot intervals of the streams, the
+// interval of a stream can be overridden with SnapshotInterval_<stream>
+func NewSnapshotOptions(c *Initializer) *repos.SnapshotOptions {
+	defaults := map[string]uint64{
+		common.TaskStreamName:  common.TaskSnapshotInterval,
+		common.QuoteStreamName: common.QuoteSnapshotInterval,
+	}
+	intrvls := make(map[string]uint64, len(defaults))
+	for stream, dflt := range defaults {
+		intrvls[stream] = dflt
+		raw := os.Getenv("SnapshotInterval_" + stream)
+		if raw == "" {
+			continue
+		}
+		intrvl, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			lgr := c.lgrf.Create(context.Background())
+			lgr.Warn(
+				"invalid snapshot interval, using default",
+				zap.String("stream", stream),
+			)
+			continue
+		}
+		intrvls[stream] = intrvl
+	}
+
+	return &repos.SnapshotOptions{
+		Intervals: intrvls,
+	}
+}
+
+// NewIdempotencyOptions provides how long idempotency keys are kept for
+func NewIdempotencyOptions(c *Initializer) *repos.IdempotencyOptions {
+	return &repos.IdempotencyOptions{
+		Expiry: idempotencyKeyExpiry(c),
+	}
+}
+
+// NewInMemIdempotencyOptions provides how long idempotency keys are kept for by
+// the in memory implementation
+func NewInMemIdempotencyOptions(c *Initializer) *inmemrepos.IdempotencyOptions {
+	return &inmemrepos.IdempotencyOptions{
+		Expiry: idempotencyKeyExpiry(c),
+	}
+}
+
+func idempotencyKeyExpiry(c *Initializer) time.Duration {
+	expiry, err := strconv.Atoi(os.Getenv("IdempotencyKeyExpiryMinutes"))
+	if err != nil || expiry <= 0 {
+		expiry = 24 * 60
+		lgr := c.lgrf.Create(context.Background())
+		lgr.Warn("no valid idempotency key expiry was provided, using default")
+	}
+	return time.Duration(expiry) * time.Minute
+}
+
 // NewPSQLDBOptions provides psqldb options
 func NewPSQLDBOptions(_ *Initializer) *psqldb.DatabaseOptions {
 	cons := os.Getenv("DatabaseConnectionString")
@@ -144,6 +264,90 @@ func NewPSQLDBOptions(_ *Initializer) *psqldb.DatabaseOptions {
 	}
 }
 
+// NewTasksOptions provides the options of the tasks service, an invalid
+// workflow fails startup rather than silently falling back to the default
+func NewTasksOptions(c *Initializer) (*tasks.Options, error) {
+	lgr := c.lgrf.Create(context.Background())
+	lead, err := strconv.Atoi(os.Getenv("TaskReminderLeadMinutes"))
+	if err != nil || lead < 0 {
+		lead = 60
+		lgr.Warn("no valid task reminder lead time was provided, using default")
+	}
+	intrvl, err := strconv.Atoi(os.Getenv("TaskSchedulerIntervalMs"))
+	if err != nil || intrvl <= 0 {
+		intrvl = 1000
+		lgr.Warn("no valid task scheduler interval was provided, using default")
+	}
+	bsize, err := strconv.Atoi(os.Getenv("TaskSchedulerBatchSize"))
+	if err != nil || bsize <= 0 {
+		bsize = 100
+		lgr.Warn("no valid task scheduler batch size was provided, using default")
+	}
+
+	policy := tasks.SubtaskDeletePolicy(os.Getenv("TaskSubtaskDeletePolicy"))
+	if policy != tasks.SubtaskDeleteBlock && policy != tasks.SubtaskDeleteCascade {
+		policy = tasks.SubtaskDeleteBlock
+		lgr.Warn("no valid subtask delete policy was provided, using default")
+	}
+
+	// the workflow is given as json, for example
+	// {"transitions":[{"event":"progressed","from":["PENDING"],"to":"PROGRESS"}]}
+	wrkf := tasks.DefaultWorkflow()
+	if raw := os.Getenv("TaskWorkflow"); raw != "" {
+		wrkf = tasks.Workflow{}
+		err = json.Unmarshal([]byte(raw), &wrkf)
+		if err == nil {
+			err = wrkf.Validate()
+		}
+		if err != nil {
+			lgr.Error("invalid task workflow was provided", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	return &tasks.Options{
+		AppsCanListAllTasks: os.Getenv("AppsCanListAllTasks") == "true",
+		ReminderLeadTime:    time.Duration(lead) * time.Minute,
+		SchedulerInterval:   time.Duration(intrvl) * time.Millisecond,
+		SchedulerBatchSize:  bsize,
+		SubtaskDeletePolicy: policy,
+		Workflow:            wrkf,
+	}, nil
+}
+
+// NewMetadataSchemaOptions provides the task metadata schemas, given as a json
+// object of the schema of each user type, for example
+// {"user":{"type":"object","properties":{"ref":{"type":"string"}}}}
+func NewMetadataSchemaOptions(_ *Initializer) (*jsonschema.Options, error) {
+	schemas := map[string]json.RawMessage{}
+	if raw := os.Getenv("TaskMetadataSchemas"); raw != "" {
+		err := json.Unmarshal([]byte(raw), &schemas)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &jsonschema.Options{
+		Schemas: schemas,
+	}, nil
+}
+
+// NewSQLiteDBOptions provides sqlitedb options
+func NewSQLiteDBOptions(c *Initializer) *sqlitedb.DatabaseOptions {
+	path := os.Getenv("SQLiteDatabasePath")
+	if path == "" {
+		path = "./prototodo.db"
+		lgr := c.lgrf.Create(context.Background())
+		lgr.Warn(
+			"no sqlite database path was provided, using default",
+			zap.String("path", path),
+		)
+	}
+	return &sqlitedb.DatabaseOptions{
+		Path:                path,
+		DatabaseServiceName: "sqlite-database",
+	}
+}
+
 // NewAppInsightsExporterOptions provides app insights exporter options
 func NewAppInsightsExporterOptions(
 	c *Initializer,
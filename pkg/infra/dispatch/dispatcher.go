@@ -0,0 +1,60 @@
+// Package dispatch defines how committed events are published to consumers
+// outside of the service
+package dispatch
+
+import (
+	"context"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+
+	"go.uber.org/zap"
+)
+
+// Event a committed event that is ready to be dispatched, Data holds the
+// domain DTO of the event's payload (tasks.TaskData, quotes.QuoteData etc.)
+type Event struct {
+	events.EventEntity
+	Data interface{}
+}
+
+// IEventDispatcher publishes committed events, delivery is at-least-once so
+// consumers should be able to handle an event being received more than once
+// (the event id and stream version can be used for deduplication)
+type IEventDispatcher interface {
+	DispatchEvent(ctx context.Context, evnt *Event) error
+}
+
+// LogDispatcher dispatcher that only logs the events being dispatched, used
+// when no message broker has been configured
+type LogDispatcher struct {
+	lgrf logger.IFactory
+}
+
+var _ IEventDispatcher = (*LogDispatcher)(nil)
+
+// NewLogDispatcher constructs a new log dispatcher
+func NewLogDispatcher(
+	lgrf logger.IFactory,
+) *LogDispatcher {
+	return &LogDispatcher{
+		lgrf: lgrf,
+	}
+}
+
+// DispatchEvent logs the event
+func (d *LogDispatcher) DispatchEvent(
+	ctx context.Context,
+	evnt *Event,
+) error {
+	lgr := d.lgrf.Create(ctx)
+	lgr.Info(
+		"dispatching event",
+		zap.Uint64("id", evnt.Id),
+		zap.String("stream", evnt.Stream),
+		zap.String("streamId", evnt.StreamId),
+		zap.String("event", evnt.Event),
+		zap.Uint64("version", evnt.Version),
+	)
+	return nil
+}
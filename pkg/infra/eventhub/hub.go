@@ -0,0 +1,112 @@
+// Package eventhub notifies in process subscribers of committed events
+package eventhub
+
+import (
+	"sync"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+)
+
+// Hub fans out commit notifications to subscribers of a stream, subscribers
+// are also notified periodically as events may be committed by other
+// instances of the service
+type Hub struct {
+	mtx  sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+	optn *Options
+
+	stop    chan struct{}
+	done    chan struct{}
+	runmtx  sync.Mutex
+	running bool
+}
+
+var _ events.INotifier = (*Hub)(nil)
+
+// NewHub constructs a new hub
+func NewHub(optn *Options) *Hub {
+	return &Hub{
+		subs: map[string]map[chan struct{}]struct{}{},
+		optn: optn,
+	}
+}
+
+// Start starts notifying the subscribers periodically in the background
+func (h *Hub) Start() {
+	h.runmtx.Lock()
+	defer h.runmtx.Unlock()
+	if h.running {
+		return
+	}
+	h.running = true
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	go h.poll()
+}
+
+// Stop stops the periodic notifications
+func (h *Hub) Stop() {
+	h.runmtx.Lock()
+	defer h.runmtx.Unlock()
+	if !h.running {
+		return
+	}
+	h.running = false
+	close(h.stop)
+	<-h.done
+}
+
+// Subscribe subscribes to notifications of a stream
+func (h *Hub) Subscribe(stream string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if _, ok := h.subs[stream]; !ok {
+		h.subs[stream] = map[chan struct{}]struct{}{}
+	}
+	h.subs[stream][ch] = struct{}{}
+
+	return ch, func() {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		delete(h.subs[stream], ch)
+	}
+}
+
+// Publish notifies the subscribers of a stream, subscribers that already have
+// a pending notification are skipped
+func (h *Hub) Publish(stream string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for ch := range h.subs[stream] {
+		notify(ch)
+	}
+}
+
+func (h *Hub) poll() {
+	defer close(h.done)
+	tckr := time.NewTicker(h.optn.PollInterval)
+	defer tckr.Stop()
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-tckr.C:
+		}
+		h.mtx.Lock()
+		for _, subs := range h.subs {
+			for ch := range subs {
+				notify(ch)
+			}
+		}
+		h.mtx.Unlock()
+	}
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,10 @@
+package eventhub
+
+import "time"
+
+// Options options for the event hub
+type Options struct {
+	// PollInterval interval at which subscribers are notified regardless of
+	// local commits, picks up events committed by other instances
+	PollInterval time.Duration
+}
@@ -0,0 +1,236 @@
+package conformance
+
+import (
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+)
+
+const (
+	testStream   = "conformance"
+	testUserType = "tester"
+)
+
+func (s *suite) testACL(t *testing.T) {
+	t.Run("Permissions", s.testACLPermissions)
+	t.Run("MultipleEntities", s.testACLMultipleEntities)
+	t.Run("Share", s.testACLShare)
+	t.Run("Delete", s.testACLDelete)
+	t.Run("Rollback", s.testACLRollback)
+}
+
+func (s *suite) createACLEntry(
+	t *testing.T,
+	streamID string,
+	userID string,
+	perm int,
+) {
+	err := s.commit(func(ctx cntxt.IContext) error {
+		return s.ACL.CreateACLEntry(
+			ctx,
+			testStream,
+			streamID,
+			testUserType,
+			userID,
+			perm,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to create acl entry: %v", err)
+	}
+}
+
+// checkACL checks the read and write permissions of a user on the entities
+func (s *suite) checkACL(
+	t *testing.T,
+	streamIDs []string,
+	userID string,
+	read bool,
+	write bool,
+) {
+	t.Helper()
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+
+	err := s.ACL.CanRead(ctx, testStream, streamIDs, testUserType, userID)
+	if read && err != nil {
+		t.Fatalf("expected read access to %v, got %v", streamIDs, err)
+	}
+	if !read && !domcom.IsError(err, domcom.UserACLCheckFailedErrorCode) {
+		t.Fatalf("expected no read access to %v, got %v", streamIDs, err)
+	}
+
+	err = s.ACL.CanWrite(ctx, testStream, streamIDs, testUserType, userID)
+	if write && err != nil {
+		t.Fatalf("expected write access to %v, got %v", streamIDs, err)
+	}
+	if !write && !domcom.IsError(err, domcom.UserACLCheckFailedErrorCode) {
+		t.Fatalf("expected no write access to %v, got %v", streamIDs, err)
+	}
+}
+
+func (s *suite) testACLPermissions(t *testing.T) {
+	ro, wo, rw := s.id(), s.id(), s.id()
+	user := s.id()
+	s.createACLEntry(t, ro, user, acl.Read)
+	s.createACLEntry(t, wo, user, acl.Write)
+	s.createACLEntry(t, rw, user, acl.Read|acl.Write)
+
+	s.checkACL(t, []string{ro}, user, true, false)
+	s.checkACL(t, []string{wo}, user, false, true)
+	s.checkACL(t, []string{rw}, user, true, true)
+	s.checkACL(t, []string{rw}, s.id(), false, false)
+	s.checkACL(t, []string{s.id()}, user, false, false)
+
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	for id, want := range map[string]int{
+		rw:     acl.Read | acl.Write,
+		s.id(): 0,
+	} {
+		perm, err := s.ACL.GetPermissions(ctx, testStream, id, testUserType, user)
+		if err != nil || perm != want {
+			t.Fatalf("expected permissions %d, got %d, %v", want, perm, err)
+		}
+	}
+
+	err := s.commit(func(ctx cntxt.IContext) error {
+		return s.ACL.CreateACLEntry(
+			ctx,
+			testStream,
+			ro,
+			testUserType,
+			user,
+			acl.Write,
+		)
+	})
+	if err == nil {
+		t.Fatalf("expected duplicate acl entry to be rejected")
+	}
+	s.checkACL(t, []string{ro}, user, true, false)
+}
+
+// testACLMultipleEntities access to multiple entities is only given if the
+// user has the permission on every one of them
+func (s *suite) testACLMultipleEntities(t *testing.T) {
+	ro, rw := s.id(), s.id()
+	user := s.id()
+	s.createACLEntry(t, ro, user, acl.Read)
+	s.createACLEntry(t, rw, user, acl.Read|acl.Write)
+
+	s.checkACL(t, []string{ro, rw}, user, true, false)
+	s.checkACL(t, []string{rw, s.id()}, user, false, false)
+}
+
+// testACLShare only entries with the share flag allow sharing, the flag
+// doesn't imply read or write access
+func (s *suite) testACLShare(t *testing.T) {
+	owned, rw, so := s.id(), s.id(), s.id()
+	user := s.id()
+	s.createACLEntry(t, owned, user, acl.Read|acl.Write|acl.Share)
+	s.createACLEntry(t, rw, user, acl.Read|acl.Write)
+	s.createACLEntry(t, so, user, acl.Share)
+
+	s.checkACL(t, []string{owned}, user, true, true)
+	s.checkACL(t, []string{so}, user, false, false)
+
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	for _, ids := range [][]string{{owned}, {so}, {owned, so}} {
+		err := s.ACL.CanShare(ctx, testStream, ids, testUserType, user)
+		if err != nil {
+			t.Fatalf("expected share access to %v, got %v", ids, err)
+		}
+	}
+	for _, ids := range [][]string{{rw}, {owned, rw}, {s.id()}} {
+		err := s.ACL.CanShare(ctx, testStream, ids, testUserType, user)
+		if !domcom.IsError(err, domcom.UserACLCheckFailedErrorCode) {
+			t.Fatalf("expected no share access to %v, got %v", ids, err)
+		}
+	}
+}
+
+func (s *suite) testACLDelete(t *testing.T) {
+	first, second := s.id(), s.id()
+	user, other := s.id(), s.id()
+	s.createACLEntry(t, first, user, acl.Read|acl.Write)
+	s.createACLEntry(t, first, other, acl.Read)
+	s.createACLEntry(t, second, user, acl.Read)
+
+	// populating any caches before deleting
+	s.checkACL(t, []string{first}, user, true, true)
+
+	err := s.commit(func(ctx cntxt.IContext) error {
+		return s.ACL.DeleteACLEntry(ctx, testStream, first, testUserType, user)
+	})
+	if err != nil {
+		t.Fatalf("failed to delete acl entry: %v", err)
+	}
+	s.checkACL(t, []string{first}, user, false, false)
+	s.checkACL(t, []string{first}, other, true, false)
+	s.checkACL(t, []string{second}, user, true, false)
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.ACL.DeleteACLEntry(ctx, testStream, first, testUserType, user)
+	})
+	if !domcom.IsError(err, domcom.ACLEntryMissingErrorCode) {
+		t.Fatalf("expected acl entry missing error, got %v", err)
+	}
+
+	// the entry can be replaced with different permissions in a transaction
+	replaced := s.id()
+	s.createACLEntry(t, replaced, user, acl.Read)
+	s.checkACL(t, []string{replaced}, user, true, false)
+	err = s.commit(func(ctx cntxt.IContext) error {
+		err := s.ACL.DeleteACLEntry(ctx, testStream, replaced, testUserType, user)
+		if err != nil {
+			return err
+		}
+		return s.ACL.CreateACLEntry(
+			ctx,
+			testStream,
+			replaced,
+			testUserType,
+			user,
+			acl.Read|acl.Write,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to replace acl entry: %v", err)
+	}
+	s.checkACL(t, []string{replaced}, user, true, true)
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.ACL.DeleteACLEntries(ctx, testStream, first)
+	})
+	if err != nil {
+		t.Fatalf("failed to delete acl entries: %v", err)
+	}
+	s.checkACL(t, []string{first}, other, false, false)
+	s.checkACL(t, []string{second}, user, true, false)
+}
+
+func (s *suite) testACLRollback(t *testing.T) {
+	id := s.id()
+	user := s.id()
+	err := s.rollback(func(ctx cntxt.IContext) error {
+		return s.ACL.CreateACLEntry(
+			ctx,
+			testStream,
+			id,
+			testUserType,
+			user,
+			acl.Read|acl.Write,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to create acl entry: %v", err)
+	}
+	s.checkACL(t, []string{id}, user, false, false)
+
+	// the rolled back entry doesn't block creating it again
+	s.createACLEntry(t, id, user, acl.Read)
+	s.checkACL(t, []string{id}, user, true, false)
+}
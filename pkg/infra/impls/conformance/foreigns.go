@@ -0,0 +1,197 @@
+package conformance
+
+import (
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+)
+
+const testForeignStream = "conformance-foreign"
+
+func (s *suite) testForeigns(t *testing.T) {
+	t.Run("Constraints", s.testForeignConstraints)
+	t.Run("Ensure", s.testForeignEnsure)
+	t.Run("Saga", s.testForeignSaga)
+	t.Run("Rollback", s.testForeignRollback)
+}
+
+func (s *suite) registerForeignItem(sagaID *string, id string) error {
+	return s.commit(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RegisterForeignItem(
+			ctx,
+			sagaID,
+			testForeignStream,
+			id,
+		)
+	})
+}
+
+func (s *suite) registerForeignConstraint(
+	sagaID *string,
+	foreignID string,
+	id string,
+) error {
+	return s.commit(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RegisterConstraint(
+			ctx,
+			sagaID,
+			testForeignStream,
+			foreignID,
+			testStream,
+			id,
+		)
+	})
+}
+
+func (s *suite) removeForeignItem(id string) error {
+	return s.commit(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RemoveForeignItem(ctx, testForeignStream, id)
+	})
+}
+
+// countAssociated counts the objects tied to a foreign item
+func (s *suite) countAssociated(t *testing.T, foreignID string) int {
+	t.Helper()
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	objs, err := s.Foreigns.ListAssociatedObjects(
+		ctx,
+		testForeignStream,
+		foreignID,
+	)
+	if err != nil {
+		t.Fatalf("failed to list associated objects: %v", err)
+	}
+	return len(objs)
+}
+
+func (s *suite) testForeignConstraints(t *testing.T) {
+	foreignID, id := s.id(), s.id()
+
+	err := s.registerForeignConstraint(nil, foreignID, id)
+	if err == nil {
+		t.Fatalf("expected constraint on missing foreign item to be rejected")
+	}
+
+	err = s.registerForeignItem(nil, foreignID)
+	if err != nil {
+		t.Fatalf("failed to register foreign item: %v", err)
+	}
+	err = s.registerForeignItem(nil, foreignID)
+	if err == nil {
+		t.Fatalf("expected duplicate foreign item to be rejected")
+	}
+	err = s.registerForeignConstraint(nil, foreignID, id)
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+	if cnt := s.countAssociated(t, foreignID); cnt != 1 {
+		t.Fatalf("expected 1 associated object, got %d", cnt)
+	}
+
+	err = s.removeForeignItem(foreignID)
+	if err == nil {
+		t.Fatalf("expected removing foreign item in use to be rejected")
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RemoveConstraint(
+			ctx,
+			testForeignStream,
+			foreignID,
+			testStream,
+			id,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to remove constraint: %v", err)
+	}
+	if cnt := s.countAssociated(t, foreignID); cnt != 0 {
+		t.Fatalf("expected no associated objects, got %d", cnt)
+	}
+	err = s.removeForeignItem(foreignID)
+	if err != nil {
+		t.Fatalf("failed to remove foreign item: %v", err)
+	}
+}
+
+// testForeignEnsure ensuring an item that's already registered succeeds and
+// keeps the item
+func (s *suite) testForeignEnsure(t *testing.T) {
+	foreignID := s.id()
+	ensure := func() error {
+		return s.commit(func(ctx cntxt.IContext) error {
+			return s.Foreigns.EnsureForeignItem(
+				ctx,
+				nil,
+				testForeignStream,
+				foreignID,
+			)
+		})
+	}
+
+	err := ensure()
+	if err != nil {
+		t.Fatalf("failed to ensure foreign item: %v", err)
+	}
+	err = s.registerForeignConstraint(nil, foreignID, s.id())
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+	err = ensure()
+	if err != nil {
+		t.Fatalf("failed to ensure existing foreign item: %v", err)
+	}
+	if cnt := s.countAssociated(t, foreignID); cnt != 1 {
+		t.Fatalf("expected 1 associated object, got %d", cnt)
+	}
+	err = s.registerForeignItem(nil, foreignID)
+	if err == nil {
+		t.Fatalf("expected registering an ensured foreign item to be rejected")
+	}
+}
+
+func (s *suite) testForeignSaga(t *testing.T) {
+	sagaID, foreignID := s.id(), s.id()
+	err := s.registerForeignItem(&sagaID, foreignID)
+	if err != nil {
+		t.Fatalf("failed to register foreign item: %v", err)
+	}
+	err = s.registerForeignConstraint(&sagaID, foreignID, s.id())
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RemoveSagaEntries(ctx, sagaID)
+	})
+	if err != nil {
+		t.Fatalf("failed to remove saga entries: %v", err)
+	}
+	if cnt := s.countAssociated(t, foreignID); cnt != 0 {
+		t.Fatalf("expected no associated objects, got %d", cnt)
+	}
+	err = s.registerForeignItem(nil, foreignID)
+	if err != nil {
+		t.Fatalf("expected foreign item of removed saga to be free, got %v", err)
+	}
+}
+
+func (s *suite) testForeignRollback(t *testing.T) {
+	foreignID := s.id()
+	err := s.rollback(func(ctx cntxt.IContext) error {
+		return s.Foreigns.RegisterForeignItem(
+			ctx,
+			nil,
+			testForeignStream,
+			foreignID,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to register foreign item: %v", err)
+	}
+	err = s.registerForeignConstraint(nil, foreignID, s.id())
+	if err == nil {
+		t.Fatalf("expected constraint on rolled back foreign item to be rejected")
+	}
+}
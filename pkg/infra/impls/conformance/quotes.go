@@ -0,0 +1,47 @@
+package conformance
+
+import (
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+)
+
+func (s *suite) testQuotes(t *testing.T) {
+	t.Run("VersionConflict", s.testQuoteVersionConflict)
+}
+
+func (s *suite) testQuoteVersionConflict(t *testing.T) {
+	id := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Quotes.Create(ctx, id, nil, "quote")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create quote: %v", err)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Quotes.Create(ctx, id, nil, "quote")
+		return err
+	})
+	if !domcom.IsError(err, domcom.VersionConflictErrorCode) {
+		t.Fatalf("expected version conflict on create, got %v", err)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Quotes.Delete(ctx, id, nil, 0)
+		return err
+	})
+	if !domcom.IsError(err, domcom.VersionConflictErrorCode) {
+		t.Fatalf("expected version conflict on delete, got %v", err)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Quotes.Delete(ctx, id, nil, 1)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to delete quote: %v", err)
+	}
+}
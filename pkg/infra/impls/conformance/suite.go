@@ -0,0 +1,78 @@
+// Package conformance a test suite for the repositories of the domain layer,
+// every implementation of the domain layer is expected to pass it so that the
+// implementations can be swapped without changing the behaviour of the service
+package conformance
+
+import (
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+
+	"github.com/bwmarrin/snowflake"
+)
+
+// Subject the repositories of the implementation under test along with the
+// context factory of the implementation
+type Subject struct {
+	Contexts cntxt.IFactory
+	Tasks    tasks.IRepository
+	Quotes   quotes.IRepository
+	ACL      acl.IRepository
+	Uniques  uniques.IRepository
+	Foreigns foreigns.IRepository
+}
+
+// Run runs the suite against the subject, the subject is expected to be backed
+// by a store that may already contain data so every test works on entities
+// with newly generated ids
+func Run(t *testing.T, sub *Subject) {
+	sf, err := snowflake.NewNode(1)
+	if err != nil {
+		t.Fatalf("failed to create snowflake: %v", err)
+	}
+	s := &suite{Subject: sub, sf: sf}
+
+	t.Run("Tasks", s.testTasks)
+	t.Run("Quotes", s.testQuotes)
+	t.Run("ACL", s.testACL)
+	t.Run("Uniques", s.testUniques)
+	t.Run("Foreigns", s.testForeigns)
+}
+
+type suite struct {
+	*Subject
+	sf *snowflake.Node
+}
+
+// id generates a new id for an entity
+func (s *suite) id() string {
+	return s.sf.Generate().String()
+}
+
+// commit runs fn in a new context, the transaction is commited if fn succeeds
+// and rolled back otherwise
+func (s *suite) commit(fn func(ctx cntxt.IContext) error) error {
+	ctx := s.Contexts.Create("")
+	err := fn(ctx)
+	if err != nil {
+		ctx.RollbackTransaction()
+		return err
+	}
+	return ctx.CommitTransaction()
+}
+
+// rollback runs fn in a new context and always rolls the transaction back
+func (s *suite) rollback(fn func(ctx cntxt.IContext) error) error {
+	ctx := s.Contexts.Create("")
+	defer ctx.RollbackTransaction()
+	return fn(ctx)
+}
+
+func pointerify[T any](in T) *T {
+	return &in
+}
@@ -0,0 +1,999 @@
+package conformance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/contracts"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+)
+
+func (s *suite) testTasks(t *testing.T) {
+	t.Run("CreateGet", s.testTaskCreateGet)
+	t.Run("VersionConflict", s.testTaskVersionConflict)
+	t.Run("Rollback", s.testTaskRollback)
+	t.Run("List", s.testTaskList)
+	t.Run("ListReadable", s.testTaskListReadable)
+	t.Run("ListAfter", s.testTaskListAfter)
+	t.Run("Share", s.testTaskShare)
+	t.Run("Transition", s.testTaskTransition)
+	t.Run("Assign", s.testTaskAssign)
+	t.Run("Move", s.testTaskMove)
+	t.Run("LockHierarchy", s.testTaskLockHierarchy)
+	t.Run("Dependencies", s.testTaskDependencies)
+	t.Run("LockDependencies", s.testTaskLockDependencies)
+	t.Run("Metadata", s.testTaskMetadata)
+	t.Run("DueDate", s.testTaskDueDate)
+	t.Run("Schedules", s.testTaskSchedules)
+}
+
+// createTask creates and commits a new task
+func (s *suite) createTask(t *testing.T, title string) string {
+	id := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Create(ctx, id, nil, tasks.TaskData{
+			Title:       pointerify(title),
+			Description: pointerify("description"),
+			Status:      pointerify(contracts.Status_PENDING.String()),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	return id
+}
+
+func (s *suite) updateTask(
+	id string,
+	version uint64,
+	title string,
+) error {
+	return s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, id, nil, version, tasks.TaskData{
+			Title: pointerify(title),
+		})
+		return err
+	})
+}
+
+func (s *suite) getTask(id string) (*tasks.Task, error) {
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	return s.Tasks.Get(ctx, id)
+}
+
+func (s *suite) testTaskCreateGet(t *testing.T) {
+	id := s.createTask(t, "title")
+
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Id != id || task.Title != "title" || task.Version != 0 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	_, err = s.getTask(s.id())
+	if !domcom.IsError(err, domcom.TaskMissingErrorCode) {
+		t.Fatalf("expected task missing error, got %v", err)
+	}
+}
+
+func (s *suite) testTaskVersionConflict(t *testing.T) {
+	id := s.createTask(t, "title")
+
+	err := s.updateTask(id, 1, "first")
+	if err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	err = s.updateTask(id, 1, "second")
+	if !domcom.IsError(err, domcom.VersionConflictErrorCode) {
+		t.Fatalf("expected version conflict on update, got %v", err)
+	}
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Delete(ctx, id, nil, 1)
+		return err
+	})
+	if !domcom.IsError(err, domcom.VersionConflictErrorCode) {
+		t.Fatalf("expected version conflict on delete, got %v", err)
+	}
+
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Title != "first" || task.Version != 1 {
+		t.Fatalf("conflicting write changed the task %+v", task)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Delete(ctx, id, nil, 2)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	_, err = s.getTask(id)
+	if !domcom.IsError(err, domcom.TaskMissingErrorCode) {
+		t.Fatalf("expected deleted task to be missing, got %v", err)
+	}
+
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	evnts, err := s.Tasks.ListEvents(ctx, id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(evnts) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(evnts))
+	}
+	for idx := range evnts {
+		if evnts[idx].Version != uint64(idx) {
+			t.Fatalf("unexpected version order %d at %d", evnts[idx].Version, idx)
+		}
+	}
+}
+
+func (s *suite) testTaskRollback(t *testing.T) {
+	id := s.id()
+	err := s.rollback(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Create(ctx, id, nil, tasks.TaskData{
+			Title:  pointerify("title"),
+			Status: pointerify(contracts.Status_PENDING.String()),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	_, err = s.getTask(id)
+	if !domcom.IsError(err, domcom.TaskMissingErrorCode) {
+		t.Fatalf("expected rolled back task to be missing, got %v", err)
+	}
+
+	id = s.createTask(t, "title")
+	err = s.rollback(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, id, nil, 1, tasks.TaskData{
+			Title: pointerify("rolled back"),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Title != "title" || task.Version != 0 {
+		t.Fatalf("rolled back update changed the task %+v", task)
+	}
+
+	// the version that was rolled back is free to be written again
+	err = s.updateTask(id, 1, "first")
+	if err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+}
+
+// listTasks lists the tasks and gives their titles in the order listed
+func (s *suite) listTasks(
+	t *testing.T,
+	opts tasks.ListOptions,
+	countPerPage int,
+	pageNumber int,
+) []string {
+	t.Helper()
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	res, err := s.Tasks.List(ctx, opts, countPerPage, pageNumber)
+	if err != nil {
+		t.Fatalf("failed to list tasks: %v", err)
+	}
+	titles := make([]string, len(res))
+	for idx := range res {
+		titles[idx] = res[idx].Title
+	}
+	return titles
+}
+
+func expectTitles(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected tasks %v, got %v", want, got)
+	}
+}
+
+// testTaskList the tasks are only listed by the filters of the options so
+// that tasks created by other tests don't show up
+func (s *suite) testTaskList(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	s.createTask(t, prefix+"c")
+	a := s.createTask(t, prefix+"a")
+	b := s.createTask(t, prefix+"b")
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, b, nil, 1, tasks.TaskData{
+			Status: pointerify(contracts.Status_PROGRESS.String()),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	search := pointerify(strings.ToLower(prefix))
+
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{Search: search}, 10, 0),
+		prefix+"c", prefix+"a", prefix+"b",
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search:     search,
+			SortBy:     tasks.SortByTitle,
+			Descending: true,
+		}, 10, 0),
+		prefix+"c", prefix+"b", prefix+"a",
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search: search,
+			SortBy: tasks.SortByTitle,
+		}, 2, 1),
+		prefix+"c",
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search: search,
+			Status: pointerify(contracts.Status_PROGRESS.String()),
+		}, 10, 0),
+		prefix+"b",
+	)
+
+	// wildcards in the search are matched as is
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search: pointerify(strings.TrimSuffix(prefix, "-") + "_"),
+		}, 10, 0),
+	)
+
+	task, err := s.getTask(a)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search:       search,
+			CreatedAfter: &task.DateTimeCreated,
+		}, 10, 0),
+		prefix+"a", prefix+"b",
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search:        search,
+			CreatedBefore: &task.DateTimeCreated,
+		}, 10, 0),
+		prefix+"c",
+	)
+
+	task, err = s.getTask(b)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Search:       search,
+			UpdatedAfter: &task.DateTimeUpdated,
+			SortBy:       tasks.SortByDateTimeUpdated,
+		}, 10, 0),
+		prefix+"b",
+	)
+}
+
+// testTaskListReadable only the tasks the reader has read access to are
+// listed when the listing is restricted to a reader
+func (s *suite) testTaskListReadable(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	readable := s.createTask(t, prefix+"readable")
+	writable := s.createTask(t, prefix+"writable")
+	s.createTask(t, prefix+"hidden")
+	user := s.id()
+	for id, perm := range map[string]int{
+		readable: acl.Read | acl.Write,
+		writable: acl.Write,
+	} {
+		err := s.commit(func(ctx cntxt.IContext) error {
+			return s.ACL.CreateACLEntry(
+				ctx,
+				domcom.TaskStreamName,
+				id,
+				testUserType,
+				user,
+				perm,
+			)
+		})
+		if err != nil {
+			t.Fatalf("failed to create acl entry: %v", err)
+		}
+	}
+
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Reader: &tasks.Reader{UserType: testUserType, UserID: user},
+			Search: &prefix,
+		}, 10, 0),
+		prefix+"readable",
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{
+			Reader: &tasks.Reader{UserType: testUserType, UserID: s.id()},
+			Search: &prefix,
+		}, 10, 0),
+	)
+	expectTitles(
+		t,
+		s.listTasks(t, tasks.ListOptions{Search: &prefix}, 10, 0),
+		prefix+"readable", prefix+"writable", prefix+"hidden",
+	)
+}
+
+// testTaskListAfter the listing continues after the given task in every
+// sorting and the count ignores where the listing continues from
+func (s *suite) testTaskListAfter(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	ids := map[string]string{}
+	for _, title := range []string{"b", "c", "a", "d"} {
+		ids[title] = s.createTask(t, prefix+title)
+	}
+	search := pointerify(prefix)
+
+	for _, tc := range []struct {
+		opts tasks.ListOptions
+		want []string
+	}{
+		{
+			opts: tasks.ListOptions{Search: search},
+			want: []string{"b", "c", "a", "d"},
+		},
+		{
+			opts: tasks.ListOptions{Search: search, Descending: true},
+			want: []string{"d", "a", "c", "b"},
+		},
+		{
+			opts: tasks.ListOptions{Search: search, SortBy: tasks.SortByTitle},
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			opts: tasks.ListOptions{
+				Search:     search,
+				SortBy:     tasks.SortByTitle,
+				Descending: true,
+			},
+			want: []string{"d", "c", "b", "a"},
+		},
+		{
+			// all of the tasks have the same status so the id decides
+			opts: tasks.ListOptions{Search: search, SortBy: tasks.SortByStatus},
+			want: nil,
+		},
+	} {
+		opts := tc.opts
+		var got []string
+		for {
+			ctx := s.Contexts.Create("")
+			page, err := s.Tasks.List(ctx, opts, 3, 0)
+			ctx.Cancel()
+			if err != nil {
+				t.Fatalf("failed to list tasks: %v", err)
+			}
+			for idx := range page {
+				got = append(got, strings.TrimPrefix(page[idx].Title, prefix))
+			}
+			if len(page) < 3 {
+				break
+			}
+			opts.After = &page[len(page)-1]
+		}
+		if len(got) != len(ids) {
+			t.Fatalf("expected %d tasks listed, got %v", len(ids), got)
+		}
+		if tc.want != nil {
+			expectTitles(t, got, tc.want...)
+		}
+
+		ctx := s.Contexts.Create("")
+		cnt, err := s.Tasks.Count(ctx, opts)
+		ctx.Cancel()
+		if err != nil {
+			t.Fatalf("failed to count tasks: %v", err)
+		}
+		if cnt != uint64(len(ids)) {
+			t.Fatalf("expected %d tasks counted, got %d", len(ids), cnt)
+		}
+	}
+}
+
+// testTaskShare sharing events move the version of the task forward without
+// changing it's data and keep the access they were written with
+func (s *suite) testTaskShare(t *testing.T) {
+	id := s.createTask(t, "title")
+	user := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Share(ctx, id, nil, 1, tasks.Access{
+			UserType:    testUserType,
+			UserID:      user,
+			Permissions: acl.Read,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s.Tasks.Unshare(ctx, id, nil, 2, tasks.Access{
+			UserType: testUserType,
+			UserID:   user,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to share task: %v", err)
+	}
+
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Title != "title" || task.Version != 2 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	evnts, err := s.Tasks.ListEvents(ctx, id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(evnts) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(evnts))
+	}
+	for idx, want := range []struct {
+		event string
+		perm  int
+	}{
+		{domcom.EventShared, acl.Read},
+		{domcom.EventUnshared, 0},
+	} {
+		evnt := evnts[idx+1]
+		access := evnt.Data.Access
+		if evnt.Event != want.event ||
+			access == nil ||
+			access.UserType != testUserType ||
+			access.UserID != user ||
+			access.Permissions != want.perm {
+			t.Fatalf("unexpected %s event %+v", want.event, evnt)
+		}
+	}
+
+	// a version that was taken by a sharing event is a conflict
+	err = s.updateTask(id, 2, "conflict")
+	if !domcom.IsError(err, domcom.VersionConflictErrorCode) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+}
+
+// testTaskTransition the transition event is written with the status it
+// moves the task to
+func (s *suite) testTaskTransition(t *testing.T) {
+	id := s.createTask(t, "Task-"+s.id())
+	review := contracts.Status_REVIEW.String()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Transition(ctx, id, nil, 1, "reviewed", review)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to transition task: %v", err)
+	}
+
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Status != review || task.Version != 1 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	evnts, err := s.Tasks.ListEvents(ctx, id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(evnts) != 2 || evnts[1].Event != "reviewed" ||
+		evnts[1].Data.Status == nil || *evnts[1].Data.Status != review {
+		t.Fatalf("unexpected events %+v", evnts)
+	}
+}
+
+// testTaskAssign the assignee is kept on the task and tasks can be listed by
+// their assignee
+func (s *suite) testTaskAssign(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	assigned := s.createTask(t, prefix+"assigned")
+	s.createTask(t, prefix+"unassigned")
+	user := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Assign(ctx, assigned, nil, 1, user, nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to assign task: %v", err)
+	}
+
+	task, err := s.getTask(assigned)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Assignee != user || task.Version != 1 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	opts := tasks.ListOptions{Search: &prefix, Assignee: &user}
+	expectTitles(t, s.listTasks(t, opts, 10, 0), prefix+"assigned")
+	ctx := s.Contexts.Create("")
+	cnt, err := s.Tasks.Count(ctx, opts)
+	ctx.Cancel()
+	if err != nil || cnt != 1 {
+		t.Fatalf("expected 1 assigned task, got %d %v", cnt, err)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Assign(ctx, assigned, nil, 2, "", nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to unassign task: %v", err)
+	}
+	expectTitles(t, s.listTasks(t, opts, 10, 0))
+	opts.Assignee = pointerify("")
+	expectTitles(
+		t,
+		s.listTasks(t, opts, 10, 0),
+		prefix+"assigned", prefix+"unassigned",
+	)
+}
+
+func (s *suite) testTaskMove(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	parent := s.createTask(t, prefix+"parent")
+	child := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Create(ctx, child, nil, tasks.TaskData{
+			Title:       pointerify(prefix + "child"),
+			Description: pointerify("description"),
+			Status:      pointerify(contracts.Status_PENDING.String()),
+			ParentID:    &parent,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create subtask: %v", err)
+	}
+	other := s.createTask(t, prefix+"other")
+
+	task, err := s.getTask(child)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.ParentID != parent {
+		t.Fatalf("expected parent %s, got %s", parent, task.ParentID)
+	}
+	opts := tasks.ListOptions{Search: &prefix, ParentID: &parent}
+	expectTitles(t, s.listTasks(t, opts, 10, 0), prefix+"child")
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Move(ctx, child, nil, 1, other)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to move task: %v", err)
+	}
+	task, err = s.getTask(child)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.ParentID != other || task.Version != 1 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	expectTitles(t, s.listTasks(t, opts, 10, 0))
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Move(ctx, child, nil, 2, "")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to move task to the top level: %v", err)
+	}
+	opts.ParentID = pointerify("")
+	expectTitles(
+		t,
+		s.listTasks(t, opts, 10, 0),
+		prefix+"parent", prefix+"child", prefix+"other",
+	)
+}
+
+func (s *suite) testTaskLockHierarchy(t *testing.T) {
+	expectSerialized(t, s.Contexts, func(ctx cntxt.IContext) error {
+		return s.Tasks.LockHierarchy(ctx)
+	})
+}
+
+func (s *suite) testTaskLockDependencies(t *testing.T) {
+	expectSerialized(t, s.Contexts, func(ctx cntxt.IContext) error {
+		return s.Tasks.LockDependencies(ctx)
+	})
+}
+
+// expectSerialized checks that a lock taken by one transaction holds back the
+// same lock in another transaction until the first transaction ends
+func expectSerialized(
+	t *testing.T,
+	ctxf cntxt.IFactory,
+	lock func(ctx cntxt.IContext) error,
+) {
+	first := ctxf.Create("")
+	defer first.RollbackTransaction()
+	err := lock(first)
+	if err != nil {
+		t.Fatalf("failed to take lock: %v", err)
+	}
+
+	done := make(chan error, 1)
+	second := ctxf.Create("")
+	defer second.RollbackTransaction()
+	go func() {
+		done <- lock(second)
+	}()
+	select {
+	case err = <-done:
+		t.Fatalf("expected lock to wait for the holder, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = first.CommitTransaction()
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatalf("failed to take released lock: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected lock to be taken once released")
+	}
+}
+
+// testTaskMetadata the metadata filters only compare top level strings,
+// numbers and booleans
+func (s *suite) testTaskMetadata(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	create := func(title string, mdata map[string]interface{}) string {
+		id := s.id()
+		err := s.commit(func(ctx cntxt.IContext) error {
+			_, err := s.Tasks.Create(ctx, id, nil, tasks.TaskData{
+				Title:       pointerify(prefix + title),
+				Description: pointerify("description"),
+				Status:      pointerify(contracts.Status_PENDING.String()),
+				RandomMap:   map[string]string{"source": title},
+				Metadata:    mdata,
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		return id
+	}
+	a := create("a", map[string]interface{}{
+		"ref":    "abc",
+		"count":  float64(3),
+		"ratio":  1.5,
+		"flag":   true,
+		"nested": map[string]interface{}{"ref": "abc"},
+	})
+	create("b", map[string]interface{}{"ref": "xyz", "flag": false})
+	create("c", map[string]interface{}{})
+
+	task, err := s.getTask(a)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.RandomMap["source"] != "a" || task.Metadata["ref"] != "abc" {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	list := func(mdata map[string]string, keys ...string) []string {
+		return s.listTasks(t, tasks.ListOptions{
+			Search:       &prefix,
+			Metadata:     mdata,
+			MetadataKeys: keys,
+		}, 10, 0)
+	}
+	expectTitles(t, list(map[string]string{"ref": "abc"}), prefix+"a")
+	expectTitles(t, list(map[string]string{"count": "3"}), prefix+"a")
+	expectTitles(t, list(map[string]string{"ratio": "1.5"}), prefix+"a")
+	expectTitles(t, list(map[string]string{"flag": "false"}), prefix+"b")
+	expectTitles(t, list(map[string]string{"nested": "abc"}))
+	expectTitles(
+		t,
+		list(map[string]string{"ref": "abc", "flag": "false"}),
+	)
+	expectTitles(t, list(nil, "flag"), prefix+"a", prefix+"b")
+	expectTitles(t, list(nil, "flag", "nested"), prefix+"a")
+	expectTitles(t, list(nil, "missing"))
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, a, nil, 1, tasks.TaskData{
+			RandomMap: map[string]string{},
+			Metadata:  map[string]interface{}{"ref": "def"},
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to update task: %v", err)
+	}
+	task, err = s.getTask(a)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if len(task.RandomMap) != 0 || len(task.Metadata) != 1 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	expectTitles(t, list(map[string]string{"ref": "abc"}))
+	expectTitles(t, list(nil, "flag"), prefix+"b")
+}
+
+// expectDependencies lists the blockers of the task, or the tasks blocked by
+// it, and checks that they are the expected tasks in order
+func (s *suite) expectDependencies(
+	t *testing.T,
+	id string,
+	blocked bool,
+	want ...string,
+) {
+	t.Helper()
+	ctx := s.Contexts.Create("")
+	defer ctx.Cancel()
+	list := s.Tasks.ListBlockers
+	if blocked {
+		list = s.Tasks.ListBlocked
+	}
+	got, err := list(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to list dependencies: %v", err)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected dependencies %v, got %v", want, got)
+	}
+}
+
+func (s *suite) testTaskDependencies(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	a := s.createTask(t, prefix+"a")
+	b := s.createTask(t, prefix+"b")
+	c := s.createTask(t, prefix+"c")
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.AddDependency(ctx, a, nil, 1, b)
+		if err != nil {
+			return err
+		}
+		_, err = s.Tasks.AddDependency(ctx, a, nil, 2, c)
+		if err != nil {
+			return err
+		}
+		_, err = s.Tasks.AddDependency(ctx, b, nil, 1, c)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to add dependencies: %v", err)
+	}
+
+	task, err := s.getTask(a)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.Version != 2 {
+		t.Fatalf("expected version 2, got %d", task.Version)
+	}
+	s.expectDependencies(t, a, false, b, c)
+	s.expectDependencies(t, c, true, a, b)
+
+	err = s.rollback(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.RemoveDependency(ctx, a, nil, 3, b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to remove dependency: %v", err)
+	}
+	s.expectDependencies(t, a, false, b, c)
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.RemoveDependency(ctx, a, nil, 3, b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to remove dependency: %v", err)
+	}
+	s.expectDependencies(t, a, false, c)
+	s.expectDependencies(t, b, true)
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Delete(ctx, c, nil, 1)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	s.expectDependencies(t, a, false)
+	s.expectDependencies(t, b, false)
+}
+
+func (s *suite) testTaskDueDate(t *testing.T) {
+	prefix := "Task-" + s.id() + "-"
+	due := time.Date(2001, 1, 1, 12, 0, 0, 0, time.UTC)
+	id := s.id()
+	err := s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Create(ctx, id, nil, tasks.TaskData{
+			Title:       pointerify(prefix + "due"),
+			Description: pointerify("description"),
+			Status:      pointerify(contracts.Status_PENDING.String()),
+			DueDate:     &due,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	s.createTask(t, prefix+"undue")
+
+	task, err := s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(due) {
+		t.Fatalf("expected due date %v, got %v", due, task.DueDate)
+	}
+
+	opts := tasks.ListOptions{
+		Search:    &prefix,
+		OverdueAt: pointerify(due),
+		TerminalStatuses: []string{
+			contracts.Status_COMPLETED.String(),
+			contracts.Status_CANCELLED.String(),
+		},
+	}
+	expectTitles(t, s.listTasks(t, opts, 10, 0))
+	opts.OverdueAt = pointerify(due.Add(time.Second))
+	expectTitles(t, s.listTasks(t, opts, 10, 0), prefix+"due")
+
+	// scheduled events keep the due date as is
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.FireSchedule(ctx, id, 1, domcom.EventOverdue, due)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to fire schedule: %v", err)
+	}
+	expectTitles(t, s.listTasks(t, opts, 10, 0), prefix+"due")
+
+	// tasks in any of the terminal statuses aren't overdue
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, id, nil, 2, tasks.TaskData{
+			Status: pointerify(contracts.Status_CANCELLED.String()),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to cancel task: %v", err)
+	}
+	expectTitles(t, s.listTasks(t, opts, 10, 0))
+	opts.TerminalStatuses = []string{contracts.Status_COMPLETED.String()}
+	expectTitles(t, s.listTasks(t, opts, 10, 0), prefix+"due")
+
+	// the zero time clears the due date
+	err = s.commit(func(ctx cntxt.IContext) error {
+		_, err := s.Tasks.Update(ctx, id, nil, 3, tasks.TaskData{
+			DueDate: &time.Time{},
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to clear due date: %v", err)
+	}
+	task, err = s.getTask(id)
+	if err != nil {
+		t.Fatalf("failed to get task: %v", err)
+	}
+	if task.DueDate != nil || task.Version != 3 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+}
+
+// testTaskSchedules the schedules are set far in the past so that no other
+// schedules in the store are due before them
+func (s *suite) testTaskSchedules(t *testing.T) {
+	id := s.createTask(t, "Task-"+s.id())
+	at := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := s.commit(func(ctx cntxt.IContext) error {
+		return s.Tasks.SetSchedules(ctx, id, []tasks.Schedule{
+			{TaskID: id, Event: domcom.EventOverdue, FireAt: at.Add(time.Hour)},
+			{TaskID: id, Event: domcom.EventReminderDue, FireAt: at},
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed to set schedules: %v", err)
+	}
+
+	claim := func(
+		fn func(func(ctx cntxt.IContext) error) error,
+		now time.Time,
+	) *tasks.Schedule {
+		t.Helper()
+		var res *tasks.Schedule
+		err := fn(func(ctx cntxt.IContext) error {
+			var err error
+			res, err = s.Tasks.ClaimDueSchedule(ctx, now)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("failed to claim schedule: %v", err)
+		}
+		return res
+	}
+	expect := func(schd *tasks.Schedule, event string, fireAt time.Time) {
+		t.Helper()
+		if schd == nil || schd.TaskID != id || schd.Event != event ||
+			!schd.FireAt.Equal(fireAt) {
+			t.Fatalf("expected %s schedule at %v, got %+v", event, fireAt, schd)
+		}
+	}
+
+	if schd := claim(s.commit, at.Add(-time.Second)); schd != nil {
+		t.Fatalf("expected no due schedules, got %+v", schd)
+	}
+	// claims that are rolled back leave the schedule in place
+	expect(claim(s.rollback, at.Add(time.Minute)), domcom.EventReminderDue, at)
+	expect(claim(s.commit, at.Add(time.Minute)), domcom.EventReminderDue, at)
+	if schd := claim(s.commit, at.Add(time.Minute)); schd != nil {
+		t.Fatalf("expected claimed schedule to be removed, got %+v", schd)
+	}
+
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.Tasks.SetSchedules(ctx, id, []tasks.Schedule{
+			{TaskID: id, Event: domcom.EventOverdue, FireAt: at.Add(time.Minute)},
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed to replace schedules: %v", err)
+	}
+	expect(
+		claim(s.commit, at.Add(time.Hour)),
+		domcom.EventOverdue,
+		at.Add(time.Minute),
+	)
+	if schd := claim(s.commit, at.Add(time.Hour)); schd != nil {
+		t.Fatalf("expected replaced schedule to be removed, got %+v", schd)
+	}
+}
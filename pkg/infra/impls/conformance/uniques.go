@@ -0,0 +1,106 @@
+package conformance
+
+import (
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+)
+
+func (s *suite) testUniques(t *testing.T) {
+	t.Run("Collision", s.testUniqueCollision)
+	t.Run("Remove", s.testUniqueRemove)
+	t.Run("Rollback", s.testUniqueRollback)
+}
+
+func (s *suite) registerUnique(
+	streamID string,
+	sagaID *string,
+	property string,
+	value string,
+) error {
+	return s.commit(func(ctx cntxt.IContext) error {
+		return s.Uniques.RegisterConstraint(
+			ctx,
+			testStream,
+			streamID,
+			sagaID,
+			property,
+			value,
+		)
+	})
+}
+
+func (s *suite) testUniqueCollision(t *testing.T) {
+	value := s.id()
+	err := s.registerUnique(s.id(), nil, "title", value)
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+
+	err = s.registerUnique(s.id(), nil, "title", value)
+	if !domcom.IsError(err, domcom.UniqueConstraintViolationErrorCode) {
+		t.Fatalf("expected unique constraint violation, got %v", err)
+	}
+
+	// the value is only unique for the property
+	err = s.registerUnique(s.id(), nil, "slug", value)
+	if err != nil {
+		t.Fatalf("failed to register constraint on other property: %v", err)
+	}
+}
+
+func (s *suite) testUniqueRemove(t *testing.T) {
+	id, value := s.id(), s.id()
+	err := s.registerUnique(id, nil, "title", value)
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.Uniques.RemoveConstraint(ctx, testStream, id)
+	})
+	if err != nil {
+		t.Fatalf("failed to remove constraint: %v", err)
+	}
+	err = s.registerUnique(s.id(), nil, "title", value)
+	if err != nil {
+		t.Fatalf("expected removed value to be free, got %v", err)
+	}
+
+	sagaID, value := s.id(), s.id()
+	err = s.registerUnique(s.id(), &sagaID, "title", value)
+	if err != nil {
+		t.Fatalf("failed to register saga constraint: %v", err)
+	}
+	err = s.commit(func(ctx cntxt.IContext) error {
+		return s.Uniques.RemoveSagaConstraints(ctx, sagaID)
+	})
+	if err != nil {
+		t.Fatalf("failed to remove saga constraints: %v", err)
+	}
+	err = s.registerUnique(s.id(), nil, "title", value)
+	if err != nil {
+		t.Fatalf("expected value of removed saga to be free, got %v", err)
+	}
+}
+
+func (s *suite) testUniqueRollback(t *testing.T) {
+	value := s.id()
+	err := s.rollback(func(ctx cntxt.IContext) error {
+		return s.Uniques.RegisterConstraint(
+			ctx,
+			testStream,
+			s.id(),
+			nil,
+			"title",
+			value,
+		)
+	})
+	if err != nil {
+		t.Fatalf("failed to register constraint: %v", err)
+	}
+	err = s.registerUnique(s.id(), nil, "title", value)
+	if err != nil {
+		t.Fatalf("expected rolled back value to be free, got %v", err)
+	}
+}
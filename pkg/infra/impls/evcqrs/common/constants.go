@@ -3,5 +3,7 @@ package common
 const (
 	SqlTransactionObjectKey = "sqltx"
 	TraceKey                = "traceinfo"
-	ACLCacheSuffix          = "acl:"
+	// ACLCacheSuffix is versioned so that entries cached before a migration
+	// changed the stored permissions aren't used
+	ACLCacheSuffix = "acl:v2:"
 )
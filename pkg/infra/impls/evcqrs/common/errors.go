@@ -5,7 +5,11 @@
 
 package common
 
-import "github.com/betalixt/gorr"
+import (
+	"fmt"
+
+	"github.com/betalixt/gorr"
+)
 
 const (
 	FailedToAssertContextTypeErrorCode    = 3_99_000
@@ -25,6 +29,21 @@ const (
 
 	NoValuesBeingUpdatedErrorCode    = 3_99_005
 	NoValuesBeingUpdatedErrorMessage = "NoValuesBeingUpdatedError"
+
+	UnknownProjectionErrorCode    = 3_99_006
+	UnknownProjectionErrorMessage = "UnknownProjectionError"
+
+	InvalidReplayModeErrorCode    = 3_99_007
+	InvalidReplayModeErrorMessage = "InvalidReplayModeError"
+
+	ReplayModeMismatchErrorCode    = 3_99_008
+	ReplayModeMismatchErrorMessage = "ReplayModeMismatchError"
+
+	UnsupportedSchemaVersionErrorCode    = 3_99_009
+	UnsupportedSchemaVersionErrorMessage = "UnsupportedSchemaVersionError"
+
+	MissingUpcasterErrorCode    = 3_99_010
+	MissingUpcasterErrorMessage = "MissingUpcasterError"
 )
 
 func NewFailedToAssertContextTypeError() *gorr.Error {
@@ -92,3 +111,61 @@ func NewNoValuesBeingUpdatedError() *gorr.Error {
 		"",
 	)
 }
+
+func NewUnknownProjectionError(name string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    UnknownProjectionErrorCode,
+			Message: UnknownProjectionErrorMessage,
+		},
+		400,
+		"no projection named "+name,
+	)
+}
+
+func NewInvalidReplayModeError(mode string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    InvalidReplayModeErrorCode,
+			Message: InvalidReplayModeErrorMessage,
+		},
+		400,
+		"invalid replay mode "+mode,
+	)
+}
+
+func NewReplayModeMismatchError(mode string) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    ReplayModeMismatchErrorCode,
+			Message: ReplayModeMismatchErrorMessage,
+		},
+		400,
+		"unfinished replay is in "+mode+" mode, restart to change modes",
+	)
+}
+
+func NewUnsupportedSchemaVersionError(
+	stream string,
+	version uint32,
+) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    UnsupportedSchemaVersionErrorCode,
+			Message: UnsupportedSchemaVersionErrorMessage,
+		},
+		500,
+		fmt.Sprintf("%s data schema version %d is not supported", stream, version),
+	)
+}
+
+func NewMissingUpcasterError(stream string, version uint32) *gorr.Error {
+	return gorr.NewError(
+		gorr.ErrorCode{
+			Code:    MissingUpcasterErrorCode,
+			Message: MissingUpcasterErrorMessage,
+		},
+		500,
+		fmt.Sprintf("no upcaster for %s data schema version %d", stream, version),
+	)
+}
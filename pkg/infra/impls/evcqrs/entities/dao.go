@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
 	"time"
 
@@ -30,6 +33,11 @@ type BaseEvent struct {
 	EventTime time.Time `db:"event_time"`
 	TraceID   string    `db:"trace_id"`
 	RequestID string    `db:"request_id"`
+
+	SchemaVersion uint32 `db:"schema_version"`
+	// TransactionID the transaction that wrote the event, only kept by the
+	// postgres event store
+	TransactionID *string `db:"transaction_id"`
 }
 
 // GetID getter for ID
@@ -58,6 +66,8 @@ func (dao *BaseEvent) ToDTO() *events.EventEntity {
 // IBaseEvent interface base base entity
 type IBaseEvent interface {
 	GetID() uint64
+	// Decode decodes the stored data upcasting it to the current schema
+	Decode() error
 	// GetSagaID() *string
 	// GetStream() string
 	// GetStreamID() string
@@ -68,6 +78,166 @@ type IBaseEvent interface {
 	// GetRequestID() string
 }
 
+// RawEvent event with it's data still serialized, used when events of multiple
+// streams are read together
+type RawEvent struct {
+	BaseEvent
+	Data []byte `db:"data"`
+}
+
+// Upcast upcasts the data to the current schema version of the stream
+func (dao *RawEvent) Upcast() error {
+	data, err := Upcasters.Upcast(dao.Stream, dao.SchemaVersion, dao.Data)
+	if err != nil {
+		return err
+	}
+	dao.Data = data
+	dao.SchemaVersion = Upcasters.CurrentVersion(dao.Stream)
+	return nil
+}
+
+// DecodeEventData decodes the raw event data stored for a stream into it's
+// domain DTO, raw data is returned as is for streams that are unknown
+func DecodeEventData(
+	stream string,
+	version uint32,
+	raw []byte,
+) (interface{}, error) {
+	raw, err := Upcasters.Upcast(stream, version, raw)
+	if err != nil {
+		return nil, err
+	}
+	switch stream {
+	case domcom.TaskStreamName:
+		dat := TaskData{}
+		if err := dat.Scan(raw); err != nil {
+			return nil, err
+		}
+		return dat.ToDTO(), nil
+	case domcom.QuoteStreamName:
+		dat := QuoteData{}
+		if err := dat.Scan(raw); err != nil {
+			return nil, err
+		}
+		return dat.ToDTO(), nil
+	}
+	return raw, nil
+}
+
+// =============================================================================
+// Outbox DAOs
+// =============================================================================
+
+// OutboxEntry dao representing a pending outbox entry joined with it's event
+type OutboxEntry struct {
+	OutboxID uint64 `db:"outbox_id"`
+	Attempts int    `db:"attempts"`
+	RawEvent
+}
+
+// =============================================================================
+// Snapshot DAOs
+// =============================================================================
+
+// Snapshot dao holding the folded state of a stream at a version
+type Snapshot struct {
+	Stream            string    `db:"stream"`
+	StreamID          string    `db:"stream_id"`
+	Version           uint64    `db:"version"`
+	EventID           uint64    `db:"event_id"`
+	EventTime         time.Time `db:"event_time"`
+	StreamTimeCreated time.Time `db:"stream_time_created"`
+	Data              []byte    `db:"data"`
+	SchemaVersion     uint32    `db:"schema_version"`
+	DateTimeCreated   time.Time `db:"date_time_created"`
+}
+
+// Upcast upcasts the data to the current schema version of the stream
+func (dao *Snapshot) Upcast() error {
+	data, err := Upcasters.Upcast(dao.Stream, dao.SchemaVersion, dao.Data)
+	if err != nil {
+		return err
+	}
+	dao.Data = data
+	dao.SchemaVersion = Upcasters.CurrentVersion(dao.Stream)
+	return nil
+}
+
+// =============================================================================
+// Saga DAOs
+// =============================================================================
+
+// Saga dao tracking the progress of a saga
+type Saga struct {
+	SagaID              string     `db:"saga_id"`
+	Status              string     `db:"status"`
+	StepCount           uint64     `db:"step_count"`
+	LastEventID         uint64     `db:"last_event_id"`
+	DateTimeCreated     time.Time  `db:"date_time_created"`
+	DateTimeUpdated     time.Time  `db:"date_time_updated"`
+	DateTimeCompensated *time.Time `db:"date_time_compensated"`
+}
+
+// ToDTO gets dto from dao
+func (dao *Saga) ToDTO() *sagas.Saga {
+	return &sagas.Saga{
+		SagaId:              dao.SagaID,
+		Status:              dao.Status,
+		StepCount:           dao.StepCount,
+		LastEventId:         dao.LastEventID,
+		DateTimeCreated:     dao.DateTimeCreated,
+		DateTimeUpdated:     dao.DateTimeUpdated,
+		DateTimeCompensated: dao.DateTimeCompensated,
+	}
+}
+
+// =============================================================================
+// Idempotency DAOs
+// =============================================================================
+
+// IdempotencyKey dao storing the result of a command handled with an
+// idempotency key
+type IdempotencyKey struct {
+	UserType        string    `db:"user_type"`
+	UserID          string    `db:"user_id"`
+	Key             string    `db:"idempotency_key"`
+	Command         string    `db:"command"`
+	Fingerprint     string    `db:"fingerprint"`
+	Result          []byte    `db:"result"`
+	DateTimeCreated time.Time `db:"date_time_created"`
+	DateTimeExpires time.Time `db:"date_time_expires"`
+}
+
+// ToDTO gets dto from dao
+func (dao *IdempotencyKey) ToDTO() *idempotency.Record {
+	return &idempotency.Record{
+		UserType:        dao.UserType,
+		UserId:          dao.UserID,
+		Key:             dao.Key,
+		Command:         dao.Command,
+		Fingerprint:     dao.Fingerprint,
+		Result:          dao.Result,
+		DateTimeCreated: dao.DateTimeCreated,
+		DateTimeExpires: dao.DateTimeExpires,
+	}
+}
+
+// =============================================================================
+// Replay DAOs
+// =============================================================================
+
+// ReplayProgress dao tracking how far a read model rebuild has gotten
+type ReplayProgress struct {
+	Projection        string     `db:"projection"`
+	Mode              string     `db:"mode"`
+	LastEventID       uint64     `db:"last_event_id"`
+	EventsApplied     uint64     `db:"events_applied"`
+	SnapshotEventID   uint64     `db:"snapshot_event_id"`
+	DateTimeCreated   time.Time  `db:"date_time_created"`
+	DateTimeUpdated   time.Time  `db:"date_time_updated"`
+	DateTimeCompleted *time.Time `db:"date_time_completed"`
+}
+
 // =============================================================================
 // Uniques DAOs
 // =============================================================================
@@ -312,6 +482,323 @@ func GetMigrationScripts() []psqldb.MigrationScript {
 			  DROP TABLE tasks;
 				`,
 		},
+		{
+			Key: "outbox",
+			Up: `
+				CREATE TABLE outbox (
+					id bigserial PRIMARY KEY NOT NULL,
+					event_id bigint NOT NULL,
+					attempts int NOT NULL DEFAULT 0,
+					last_error text,
+					date_time_created timestamp with time zone NOT NULL,
+					date_time_dispatched timestamp with time zone,
+					CONSTRAINT outbox_event_unique UNIQUE (event_id),
+					CONSTRAINT outbox_event_fk FOREIGN KEY (event_id) REFERENCES events (id)
+				);
+
+				CREATE INDEX idx_outbox_pending ON outbox(id)
+				WHERE date_time_dispatched IS NULL;
+
+				CREATE TRIGGER set_outbox_create_time
+				BEFORE INSERT ON outbox
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_created();
+				`,
+			Down: `
+			  DROP TRIGGER set_outbox_create_time on outbox;
+			  DROP INDEX idx_outbox_pending;
+			  DROP TABLE outbox;
+				`,
+		},
+		{
+			Key: "replay-progress",
+			Up: `
+				CREATE TABLE replay_progress (
+					projection text PRIMARY KEY NOT NULL,
+					mode text NOT NULL,
+					last_event_id bigint NOT NULL,
+					events_applied bigint NOT NULL,
+					date_time_created timestamp with time zone NOT NULL,
+					date_time_updated timestamp with time zone NOT NULL,
+					date_time_completed timestamp with time zone
+				);
+
+				CREATE TRIGGER set_replay_progress_create_time
+				BEFORE INSERT ON replay_progress
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_created();
+
+				CREATE TRIGGER set_replay_progress_update_time
+				BEFORE INSERT OR UPDATE ON replay_progress
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_updated();
+				`,
+			Down: `
+			  DROP TRIGGER set_replay_progress_create_time on replay_progress;
+			  DROP TRIGGER set_replay_progress_update_time on replay_progress;
+			  DROP TABLE replay_progress;
+				`,
+		},
+		{
+			Key: "snapshots",
+			Up: `
+				CREATE TABLE snapshots (
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					version bigint NOT NULL,
+					event_id bigint NOT NULL,
+					event_time timestamp with time zone NOT NULL,
+					stream_time_created timestamp with time zone NOT NULL,
+					data bytea NOT NULL,
+					date_time_created timestamp with time zone NOT NULL,
+					PRIMARY KEY(stream, stream_id, version)
+				);
+
+				CREATE TRIGGER set_snapshots_create_time
+				BEFORE INSERT ON snapshots
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_created();
+
+				ALTER TABLE replay_progress
+				ADD COLUMN snapshot_event_id bigint NOT NULL DEFAULT 0;
+				`,
+			Down: `
+			  ALTER TABLE replay_progress DROP COLUMN snapshot_event_id;
+			  DROP TRIGGER set_snapshots_create_time on snapshots;
+			  DROP TABLE snapshots;
+				`,
+		},
+		{
+			Key: "schema-versions",
+			Up: `
+				ALTER TABLE events
+				ADD COLUMN schema_version int NOT NULL DEFAULT 1;
+
+				ALTER TABLE snapshots
+				ADD COLUMN schema_version int NOT NULL DEFAULT 1;
+				`,
+			Down: `
+			  ALTER TABLE snapshots DROP COLUMN schema_version;
+			  ALTER TABLE events DROP COLUMN schema_version;
+				`,
+		},
+		{
+			Key: "sagas",
+			Up: `
+				CREATE TABLE sagas (
+					saga_id text PRIMARY KEY NOT NULL,
+					status text NOT NULL,
+					step_count bigint NOT NULL,
+					last_event_id bigint NOT NULL,
+					date_time_created timestamp with time zone NOT NULL,
+					date_time_updated timestamp with time zone NOT NULL,
+					date_time_compensated timestamp with time zone
+				);
+
+				CREATE TRIGGER set_sagas_create_time
+				BEFORE INSERT ON sagas
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_created();
+
+				CREATE TRIGGER set_sagas_update_time
+				BEFORE INSERT OR UPDATE ON sagas
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_updated();
+
+				CREATE INDEX idx_events_saga_id ON events(saga_id)
+				WHERE saga_id IS NOT NULL;
+
+				INSERT INTO sagas (saga_id, status, step_count, last_event_id)
+				SELECT saga_id, 'active', COUNT(*), MAX(id) FROM events
+				WHERE saga_id IS NOT NULL
+				GROUP BY saga_id;
+				`,
+			Down: `
+			  DROP INDEX idx_events_saga_id;
+			  DROP TRIGGER set_sagas_create_time on sagas;
+			  DROP TRIGGER set_sagas_update_time on sagas;
+			  DROP TABLE sagas;
+				`,
+		},
+		{
+			Key: "idempotency-keys",
+			Up: `
+				CREATE TABLE idempotency_keys (
+					user_type text NOT NULL,
+					user_id text NOT NULL,
+					idempotency_key text NOT NULL,
+					command text NOT NULL,
+					fingerprint text NOT NULL,
+					result bytea NOT NULL,
+					date_time_created timestamp with time zone NOT NULL,
+					date_time_expires timestamp with time zone NOT NULL,
+					PRIMARY KEY(user_type, user_id, idempotency_key)
+				);
+
+				CREATE TRIGGER set_idempotency_keys_create_time
+				BEFORE INSERT ON idempotency_keys
+				FOR EACH ROW
+				EXECUTE PROCEDURE trigger_set_date_time_created();
+				`,
+			Down: `
+			  DROP TRIGGER set_idempotency_keys_create_time on idempotency_keys;
+			  DROP TABLE idempotency_keys;
+				`,
+		},
+		{
+			Key: "uniques-value-constraint",
+			Up: `
+				ALTER TABLE uniques DROP CONSTRAINT uniques_pkey;
+				ALTER TABLE uniques ADD PRIMARY KEY (stream, stream_id, property);
+				ALTER TABLE uniques ADD CONSTRAINT uniques_value_unique
+				UNIQUE (stream, property, value);
+				`,
+			Down: `
+			  ALTER TABLE uniques DROP CONSTRAINT uniques_value_unique;
+			  ALTER TABLE uniques DROP CONSTRAINT uniques_pkey;
+			  ALTER TABLE uniques ADD PRIMARY KEY (stream, stream_id);
+				`,
+		},
+		{
+			Key: "tasks-list-indexes",
+			Up: `
+				CREATE INDEX idx_tasks_status ON tasks(status);
+				CREATE INDEX idx_tasks_date_time_created ON tasks(date_time_created, id);
+				CREATE INDEX idx_tasks_date_time_updated ON tasks(date_time_updated, id);
+				CREATE INDEX idx_tasks_title ON tasks(title COLLATE "C", id);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_title;
+			  DROP INDEX idx_tasks_date_time_updated;
+			  DROP INDEX idx_tasks_date_time_created;
+			  DROP INDEX idx_tasks_status;
+				`,
+		},
+		{
+			Key: "acl-user-index",
+			Up: `
+				CREATE INDEX idx_acl_user ON acl(user_type, user_id, stream, stream_id);
+				`,
+			Down: `
+			  DROP INDEX idx_acl_user;
+				`,
+		},
+		{
+			// tasks were only ever granted to their creators so the creators are
+			// the entries with read and write access
+			Key: "acl-task-share-permission",
+			Up: `
+				UPDATE acl SET permissions = permissions | 4
+				WHERE stream = 'tasks' AND permissions = 3;
+				`,
+			Down: `
+			  UPDATE acl SET permissions = permissions & 3 WHERE stream = 'tasks';
+				`,
+		},
+		{
+			Key: "tasks-assignee",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN assignee text NOT NULL DEFAULT '';
+				CREATE INDEX idx_tasks_assignee ON tasks(assignee);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_assignee;
+			  ALTER TABLE tasks DROP COLUMN assignee;
+				`,
+		},
+		{
+			Key: "tasks-due-date",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN due_date timestamp with time zone;
+				CREATE INDEX idx_tasks_due_date ON tasks(due_date);
+
+				CREATE TABLE task_schedules (
+					stream_id text NOT NULL,
+					event text NOT NULL,
+					fire_at timestamp with time zone NOT NULL,
+					PRIMARY KEY(stream_id, event)
+				);
+				CREATE INDEX idx_task_schedules_fire_at ON task_schedules(fire_at);
+				`,
+			Down: `
+			  DROP INDEX idx_task_schedules_fire_at;
+			  DROP TABLE task_schedules;
+			  DROP INDEX idx_tasks_due_date;
+			  ALTER TABLE tasks DROP COLUMN due_date;
+				`,
+		},
+		{
+			Key: "tasks-parent",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN parent_id text NOT NULL DEFAULT '';
+				CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_parent_id;
+			  ALTER TABLE tasks DROP COLUMN parent_id;
+				`,
+		},
+		{
+			Key: "task-dependencies",
+			Up: `
+				CREATE TABLE task_dependencies (
+					stream_id text NOT NULL,
+					blocker_id text NOT NULL,
+					PRIMARY KEY(stream_id, blocker_id)
+				);
+				CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+				`,
+			Down: `
+			  DROP INDEX idx_task_dependencies_blocker_id;
+			  DROP TABLE task_dependencies;
+				`,
+		},
+		{
+			Key: "outbox-dead-letter",
+			Up: `
+				ALTER TABLE outbox
+				ADD COLUMN date_time_dead_lettered timestamp with time zone;
+
+				DROP INDEX idx_outbox_pending;
+				CREATE INDEX idx_outbox_pending ON outbox(id)
+				WHERE date_time_dispatched IS NULL
+					AND date_time_dead_lettered IS NULL;
+				`,
+			Down: `
+			  DROP INDEX idx_outbox_pending;
+			  CREATE INDEX idx_outbox_pending ON outbox(id)
+			  WHERE date_time_dispatched IS NULL;
+			  ALTER TABLE outbox DROP COLUMN date_time_dead_lettered;
+				`,
+		},
+		{
+			Key: "events-transaction-id",
+			Up: `
+				ALTER TABLE events
+				ADD COLUMN transaction_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+				CREATE INDEX idx_events_stream_transaction
+				ON events(stream, transaction_id, id);
+				`,
+			Down: `
+			  DROP INDEX idx_events_stream_transaction;
+			  ALTER TABLE events DROP COLUMN transaction_id;
+				`,
+		},
+		{
+			Key: "tasks-search-trigram-indexes",
+			Up: `
+				CREATE EXTENSION IF NOT EXISTS pg_trgm;
+				CREATE INDEX idx_tasks_title_trgm ON tasks
+				USING gin (title gin_trgm_ops);
+				CREATE INDEX idx_tasks_description_trgm ON tasks
+				USING gin (description gin_trgm_ops);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_description_trgm;
+			  DROP INDEX idx_tasks_title_trgm;
+				`,
+		},
 	}
 	return migrationScripts
 }
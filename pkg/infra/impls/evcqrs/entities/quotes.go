@@ -69,10 +69,21 @@ func (*QuoteData) ToDTOSlice(
 	return dtos, nil
 }
 
-// QuoteEvent representing quote events
+// QuoteEvent representing quote events, the data is stored serialized and is
+// only decoded once the schema version is known
 type QuoteEvent struct {
 	BaseEvent
-	Data QuoteData `db:"data"`
+	RawData []byte    `db:"data"`
+	Data    QuoteData `db:"-"`
+}
+
+// Decode decodes the stored data upcasting it to the current schema
+func (dao *QuoteEvent) Decode() error {
+	raw, err := Upcasters.Upcast(dao.Stream, dao.SchemaVersion, dao.RawData)
+	if err != nil {
+		return err
+	}
+	return dao.Data.Scan(raw)
 }
 
 // ToDTO gets dto from dao
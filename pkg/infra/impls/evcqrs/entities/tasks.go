@@ -10,6 +10,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ driver.Value = (*TaskData)(nil)
@@ -31,16 +32,38 @@ func (t *TaskData) Scan(value interface{}) error {
 
 // FromDTO to populate structure from dto
 func (t *TaskData) FromDTO(data *tasks.TaskData) error {
-	mdata, err := structpb.NewStruct(data.Metadata)
-	if err != nil {
-		return err
-	}
 	*t = TaskData{
 		Title:       data.Title,
 		Description: data.Description,
 		Status:      data.Status,
 		RandomMap:   data.RandomMap,
-		Metadata:    mdata,
+		Assignee:    data.Assignee,
+		ParentId:    data.ParentID,
+		BlockerId:   data.BlockerID,
+	}
+	if data.RandomMap != nil && len(data.RandomMap) == 0 {
+		t.RandomMapCleared = true
+	}
+	if data.DueDate != nil {
+		t.DueDate = timestamppb.New(*data.DueDate)
+	}
+	// metadata is only set when it's being changed
+	if data.Metadata != nil {
+		mdata, err := structpb.NewStruct(data.Metadata)
+		if err != nil {
+			return err
+		}
+		t.Metadata = mdata
+	}
+	if data.Access != nil {
+		t.Access = &TaskAccess{
+			UserType:    data.Access.UserType,
+			UserId:      data.Access.UserID,
+			Permissions: int32(data.Access.Permissions),
+			PreviousPermissions: int32(
+				data.Access.PreviousPermissions,
+			),
+		}
 	}
 	return nil
 }
@@ -49,8 +72,8 @@ func (t *TaskData) FromDTO(data *tasks.TaskData) error {
 func (t *TaskData) GeneratePSQLReadModelSet(
 	pbeg int,
 ) (string, []interface{}, int) {
-	sets := make([]string, 5)
-	vals := make([]interface{}, 5)
+	sets := make([]string, 8)
+	vals := make([]interface{}, 8)
 	idx := 0
 	if t.Title != nil {
 		sets[idx] = "title = $" + strconv.Itoa(pbeg)
@@ -70,9 +93,9 @@ func (t *TaskData) GeneratePSQLReadModelSet(
 		idx++
 		pbeg++
 	}
-	if t.RandomMap != nil {
+	if t.RandomMap != nil || t.RandomMapCleared {
 		sets[idx] = "random_map = $" + strconv.Itoa(pbeg)
-		vals[idx] = JSONMapString(t.RandomMap)
+		vals[idx] = JSONMapString(t.RandomMapValue())
 		idx++
 		pbeg++
 	}
@@ -82,6 +105,24 @@ func (t *TaskData) GeneratePSQLReadModelSet(
 		idx++
 		pbeg++
 	}
+	if t.Assignee != nil {
+		sets[idx] = "assignee = $" + strconv.Itoa(pbeg)
+		vals[idx] = t.Assignee
+		idx++
+		pbeg++
+	}
+	if t.DueDate != nil {
+		sets[idx] = "due_date = $" + strconv.Itoa(pbeg)
+		vals[idx] = DueDateValue(t.DueDate)
+		idx++
+		pbeg++
+	}
+	if t.ParentId != nil {
+		sets[idx] = "parent_id = $" + strconv.Itoa(pbeg)
+		vals[idx] = t.ParentId
+		idx++
+		pbeg++
+	}
 	var set string
 	if idx == 0 {
 		set = ""
@@ -91,6 +132,57 @@ func (t *TaskData) GeneratePSQLReadModelSet(
 	return set, vals[:idx], pbeg
 }
 
+// Fold applies the changes of an event's data on to the state, fields that are
+// set on the event are the fields that were changed
+func (t *TaskData) Fold(delta *TaskData) {
+	if delta.Title != nil {
+		t.Title = delta.Title
+	}
+	if delta.Description != nil {
+		t.Description = delta.Description
+	}
+	if delta.Status != nil {
+		t.Status = delta.Status
+	}
+	if delta.RandomMap != nil || delta.RandomMapCleared {
+		t.RandomMap = delta.RandomMap
+	}
+	if delta.Metadata != nil {
+		t.Metadata = delta.Metadata
+	}
+	if delta.Assignee != nil {
+		t.Assignee = delta.Assignee
+	}
+	if delta.DueDate != nil {
+		t.DueDate = delta.DueDate
+	}
+	if delta.ParentId != nil {
+		t.ParentId = delta.ParentId
+	}
+}
+
+// RandomMapValue gives the random map the data sets, an empty map when it was
+// cleared
+func (t *TaskData) RandomMapValue() map[string]string {
+	if t.RandomMap == nil {
+		return map[string]string{}
+	}
+	return t.RandomMap
+}
+
+// DueDateValue gives the read model value of a due date, the zero time the
+// due date is cleared with is stored as null
+func DueDateValue(dueDate *timestamppb.Timestamp) *time.Time {
+	if dueDate == nil {
+		return nil
+	}
+	due := dueDate.AsTime()
+	if due.IsZero() {
+		return nil
+	}
+	return &due
+}
+
 // FromDTOSlice to create a dao slice from dto slice
 func (t *TaskData) FromDTOSlice(
 	daos []tasks.TaskData,
@@ -108,13 +200,36 @@ func (t *TaskData) FromDTOSlice(
 
 // ToDTO to get the dto from dao
 func (t *TaskData) ToDTO() *tasks.TaskData {
-	return &tasks.TaskData{
+	dto := &tasks.TaskData{
 		Title:       t.Title,
 		Description: t.Description,
 		Status:      t.Status,
 		RandomMap:   t.RandomMap,
-		Metadata:    t.Metadata.AsMap(),
+		Assignee:    t.Assignee,
+		ParentID:    t.ParentId,
+		BlockerID:   t.BlockerId,
+	}
+	if t.RandomMapCleared {
+		dto.RandomMap = map[string]string{}
+	}
+	if t.DueDate != nil {
+		due := t.DueDate.AsTime()
+		dto.DueDate = &due
+	}
+	if t.Metadata != nil {
+		dto.Metadata = t.Metadata.AsMap()
 	}
+	if t.Access != nil {
+		dto.Access = &tasks.Access{
+			UserType:    t.Access.UserType,
+			UserID:      t.Access.UserId,
+			Permissions: int(t.Access.Permissions),
+			PreviousPermissions: int(
+				t.Access.PreviousPermissions,
+			),
+		}
+	}
+	return dto
 }
 
 // ToDTOSlice to get the dto slice from dao slice
@@ -130,10 +245,31 @@ func (*TaskData) ToDTOSlice(
 	return dtos
 }
 
-// TaskEvent representing task events
+// TaskEvent representing task events, the data is stored serialized and is
+// only decoded once the schema version is known
 type TaskEvent struct {
 	BaseEvent
-	Data TaskData `db:"data"`
+	RawData []byte   `db:"data"`
+	Data    TaskData `db:"-"`
+}
+
+// Decode decodes the stored data upcasting it to the current schema
+func (dao *TaskEvent) Decode() error {
+	raw, err := Upcasters.Upcast(dao.Stream, dao.SchemaVersion, dao.RawData)
+	if err != nil {
+		return err
+	}
+	return dao.Data.Scan(raw)
+}
+
+// DecodeSlice decodes the stored data of all of the events
+func (*TaskEvent) DecodeSlice(daos []TaskEvent) error {
+	for idx := range daos {
+		if err := daos[idx].Decode(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ToDTO gets dto from dao
@@ -157,6 +293,41 @@ func (*TaskEvent) ToDTOSlice(
 	return dtos, nil
 }
 
+// TaskSnapshot snapshot of the task state
+type TaskSnapshot struct {
+	Snapshot
+}
+
+// ToDTO gets the task as it was at the snapshot
+func (dao *TaskSnapshot) ToDTO() (*tasks.Task, error) {
+	err := dao.Upcast()
+	if err != nil {
+		return nil, err
+	}
+	data := TaskData{}
+	err = data.Scan(dao.Data)
+	if err != nil {
+		return nil, err
+	}
+	dto := &tasks.Task{
+		Id:              dao.StreamID,
+		Title:           data.GetTitle(),
+		Description:     data.GetDescription(),
+		Status:          data.GetStatus(),
+		RandomMap:       data.RandomMap,
+		Assignee:        data.GetAssignee(),
+		DueDate:         DueDateValue(data.DueDate),
+		ParentID:        data.GetParentId(),
+		Version:         dao.Version,
+		DateTimeCreated: dao.StreamTimeCreated,
+		DateTimeUpdated: dao.EventTime,
+	}
+	if data.Metadata != nil {
+		dto.Metadata = data.Metadata.AsMap()
+	}
+	return dto, nil
+}
+
 // TaskReadModel the read model for task data
 type TaskReadModel struct {
 	ID              string        `db:"id"`
@@ -165,6 +336,9 @@ type TaskReadModel struct {
 	Status          string        `db:"status"`
 	RandomMap       JSONMapString `db:"random_map"`
 	Metadata        JSONObj       `db:"metadata"`
+	Assignee        string        `db:"assignee"`
+	DueDate         *time.Time    `db:"due_date"`
+	ParentID        string        `db:"parent_id"`
 	Version         uint64        `db:"version"`
 	DateTimeCreated time.Time     `db:"date_time_created"`
 	DateTimeUpdated time.Time     `db:"date_time_updated"`
@@ -179,6 +353,9 @@ func (dao *TaskReadModel) ToDTO() (*tasks.Task, error) {
 		Status:          dao.Status,
 		RandomMap:       dao.RandomMap,
 		Metadata:        dao.Metadata,
+		Assignee:        dao.Assignee,
+		DueDate:         dao.DueDate,
+		ParentID:        dao.ParentID,
 		Version:         dao.Version,
 		DateTimeUpdated: dao.DateTimeUpdated,
 		DateTimeCreated: dao.DateTimeCreated,
@@ -201,3 +378,19 @@ func (*TaskReadModel) ToDTOSlice(
 	}
 	return dtos, nil
 }
+
+// TaskSchedule a pending schedule of a task
+type TaskSchedule struct {
+	StreamID string    `db:"stream_id"`
+	Event    string    `db:"event"`
+	FireAt   time.Time `db:"fire_at"`
+}
+
+// ToDTO gets dto from dao
+func (dao *TaskSchedule) ToDTO() *tasks.Schedule {
+	return &tasks.Schedule{
+		TaskID: dao.StreamID,
+		Event:  dao.Event,
+		FireAt: dao.FireAt,
+	}
+}
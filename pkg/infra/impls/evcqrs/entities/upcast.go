@@ -0,0 +1,96 @@
+package entities
+
+import (
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+)
+
+// Schema versions of the data currently written for each stream, a version
+// must be bumped (and an upcaster from the previous version registered in
+// newUpcasterRegistry) whenever the stored data changes shape in a way the
+// current messages can't read
+const (
+	TaskDataSchemaVersion  uint32 = 1
+	QuoteDataSchemaVersion uint32 = 1
+)
+
+// Upcaster converts the serialized data of an event from a schema version to
+// the next schema version
+type Upcaster func(raw []byte) ([]byte, error)
+
+// UpcasterRegistry holds the current schema version of each stream along with
+// the upcasters that bring older data up to it
+type UpcasterRegistry struct {
+	current   map[string]uint32
+	upcasters map[string]map[uint32]Upcaster
+}
+
+// NewUpcasterRegistry constructs a new upcaster registry with the current
+// schema versions keyed by stream name
+func NewUpcasterRegistry(current map[string]uint32) *UpcasterRegistry {
+	return &UpcasterRegistry{
+		current:   current,
+		upcasters: map[string]map[uint32]Upcaster{},
+	}
+}
+
+// Register registers an upcaster converting data of the stream from the
+// version to the version after it
+func (r *UpcasterRegistry) Register(
+	stream string,
+	from uint32,
+	upc Upcaster,
+) {
+	if _, ok := r.upcasters[stream]; !ok {
+		r.upcasters[stream] = map[uint32]Upcaster{}
+	}
+	r.upcasters[stream][from] = upc
+}
+
+// CurrentVersion gets the schema version data of the stream is written with,
+// streams that aren't versioned are at the first version
+func (r *UpcasterRegistry) CurrentVersion(stream string) uint32 {
+	if version, ok := r.current[stream]; ok {
+		return version
+	}
+	return 1
+}
+
+// Upcast runs the upcasters needed to bring data of the stream from the version
+// to the current version, data already at the current version is returned as
+// is
+func (r *UpcasterRegistry) Upcast(
+	stream string,
+	version uint32,
+	raw []byte,
+) ([]byte, error) {
+	current := r.CurrentVersion(stream)
+	if version == 0 || version > current {
+		return nil, common.NewUnsupportedSchemaVersionError(stream, version)
+	}
+
+	var err error
+	for ; version < current; version++ {
+		upc, ok := r.upcasters[stream][version]
+		if !ok {
+			return nil, common.NewMissingUpcasterError(stream, version)
+		}
+		raw, err = upc(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return raw, nil
+}
+
+// Upcasters registry used when reading stored events and snapshots
+var Upcasters = newUpcasterRegistry()
+
+// newUpcasterRegistry creates the registry with all of the known upcasters
+func newUpcasterRegistry() *UpcasterRegistry {
+	reg := NewUpcasterRegistry(map[string]uint32{
+		domcom.TaskStreamName:  TaskDataSchemaVersion,
+		domcom.QuoteStreamName: QuoteDataSchemaVersion,
+	})
+	return reg
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"encoding/hex"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Fixtures of data stored before events were versioned, these rows were
+// migrated with schema version 1
+const (
+	taskDataV1Fixture  = "0a0b77726974652074657374731210636f766572206f6c64206576656e74731a04746f646f"
+	quoteDataV1Fixture = "0a0b737461792068756e677279"
+)
+
+func decodeFixture(t *testing.T, fixture string) []byte {
+	raw, err := hex.DecodeString(fixture)
+	if err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	return raw
+}
+
+func valueOf(in *string) string {
+	if in == nil {
+		return ""
+	}
+	return *in
+}
+
+func TestDecodeVersionOneTaskData(t *testing.T) {
+	raw := decodeFixture(t, taskDataV1Fixture)
+	dto, err := DecodeEventData(domcom.TaskStreamName, 1, raw)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	data, ok := dto.(*tasks.TaskData)
+	if !ok {
+		t.Fatalf("unexpected data type %T", dto)
+	}
+	if valueOf(data.Title) != "write tests" ||
+		valueOf(data.Description) != "cover old events" ||
+		valueOf(data.Status) != "todo" {
+		t.Errorf("unexpected task data %+v", data)
+	}
+}
+
+func TestDecodeVersionOneQuoteData(t *testing.T) {
+	raw := decodeFixture(t, quoteDataV1Fixture)
+	dto, err := DecodeEventData(domcom.QuoteStreamName, 1, raw)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	data, ok := dto.(*quotes.QuoteData)
+	if !ok {
+		t.Fatalf("unexpected data type %T", dto)
+	}
+	if valueOf(data.Quote) != "stay hungry" {
+		t.Errorf("unexpected quote data %+v", data)
+	}
+}
+
+func TestDecodeVersionOneTaskEvent(t *testing.T) {
+	evnt := TaskEvent{
+		BaseEvent: BaseEvent{
+			Stream:        domcom.TaskStreamName,
+			SchemaVersion: 1,
+		},
+		RawData: decodeFixture(t, taskDataV1Fixture),
+	}
+	if err := evnt.Decode(); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if evnt.Data.GetTitle() != "write tests" {
+		t.Errorf("unexpected title %s", evnt.Data.GetTitle())
+	}
+}
+
+// renumberField moves a string field to a new field number, the kind of change
+// a renamed and restructured field leaves behind in stored data
+func renumberField(from, to protowire.Number) Upcaster {
+	return func(raw []byte) ([]byte, error) {
+		out := []byte{}
+		for len(raw) > 0 {
+			num, typ, n := protowire.ConsumeTag(raw)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			m := protowire.ConsumeFieldValue(num, typ, raw[n:])
+			if m < 0 {
+				return nil, protowire.ParseError(m)
+			}
+			if num == from {
+				val, _ := protowire.ConsumeBytes(raw[n:])
+				out = protowire.AppendTag(out, to, protowire.BytesType)
+				out = protowire.AppendBytes(out, val)
+			} else {
+				out = append(out, raw[:n+m]...)
+			}
+			raw = raw[n+m:]
+		}
+		return out, nil
+	}
+}
+
+func TestUpcasterRegistryChainsUpcasters(t *testing.T) {
+	reg := NewUpcasterRegistry(map[string]uint32{
+		domcom.TaskStreamName: 3,
+	})
+	// version 1 stored the title in field 9, version 2 stored it in field 8
+	reg.Register(domcom.TaskStreamName, 1, renumberField(9, 8))
+	reg.Register(domcom.TaskStreamName, 2, renumberField(8, 1))
+
+	raw := protowire.AppendTag(nil, 9, protowire.BytesType)
+	raw = protowire.AppendString(raw, "legacy title")
+	raw = protowire.AppendTag(raw, 3, protowire.BytesType)
+	raw = protowire.AppendString(raw, "done")
+
+	out, err := reg.Upcast(domcom.TaskStreamName, 1, raw)
+	if err != nil {
+		t.Fatalf("failed to upcast: %v", err)
+	}
+	data := TaskData{}
+	if err := proto.Unmarshal(out, &data); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if data.GetTitle() != "legacy title" || data.GetStatus() != "done" {
+		t.Errorf("unexpected upcasted data %+v", &data)
+	}
+
+	// data at the current version is left as is
+	cur := decodeFixture(t, taskDataV1Fixture)
+	out, err = reg.Upcast(domcom.TaskStreamName, 3, cur)
+	if err != nil {
+		t.Fatalf("failed to upcast: %v", err)
+	}
+	if hex.EncodeToString(out) != taskDataV1Fixture {
+		t.Errorf("current version data was changed")
+	}
+}
+
+func TestUpcasterRegistryRejectsUnknownVersions(t *testing.T) {
+	reg := NewUpcasterRegistry(map[string]uint32{
+		domcom.TaskStreamName: 3,
+	})
+	reg.Register(domcom.TaskStreamName, 2, renumberField(8, 1))
+
+	cases := map[string]uint32{
+		"unversioned": 0,
+		"future":      4,
+		"gap":         1,
+	}
+	for name, version := range cases {
+		_, err := reg.Upcast(domcom.TaskStreamName, version, []byte{})
+		if err == nil {
+			t.Errorf("%s version %d was not rejected", name, version)
+		}
+	}
+}
+
+func TestRawEventUpcastSetsCurrentVersion(t *testing.T) {
+	evnt := RawEvent{
+		BaseEvent: BaseEvent{
+			Stream:        domcom.TaskStreamName,
+			SchemaVersion: 1,
+		},
+		Data: decodeFixture(t, taskDataV1Fixture),
+	}
+	if err := evnt.Upcast(); err != nil {
+		t.Fatalf("failed to upcast: %v", err)
+	}
+	if evnt.SchemaVersion != TaskDataSchemaVersion {
+		t.Errorf("unexpected schema version %d", evnt.SchemaVersion)
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/impl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	domtrace "techunicorn.com/udc-core/prototodo/pkg/domain/base/trace"
@@ -14,9 +16,15 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/config"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/dispatch"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/outbox"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/replay"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/redisdb"
@@ -73,6 +81,35 @@ var DependencySet = wire.NewSet(
 	config.NewRedisOptions,
 	snowflake.NewSnowflake,
 	config.NewSnowflakeOptions,
+	config.NewTasksOptions,
+	jsonschema.NewValidator,
+	config.NewMetadataSchemaOptions,
+	wire.Bind(
+		new(tasks.IMetadataValidator),
+		new(*jsonschema.Validator),
+	),
+
+	// Events
+	eventhub.NewHub,
+	wire.Bind(
+		new(events.INotifier),
+		new(*eventhub.Hub),
+	),
+	config.NewEventHubOptions,
+
+	// Dispatch
+	dispatch.NewLogDispatcher,
+	wire.Bind(
+		new(dispatch.IEventDispatcher),
+		new(*dispatch.LogDispatcher),
+	),
+	outbox.NewRelay,
+	config.NewOutboxOptions,
+
+	// Replay
+	replay.NewReplayer,
+	replay.NewProjectionList,
+	config.NewReplayOptions,
 
 	// Repos
 	repos.NewBaseDataRepository,
@@ -102,6 +139,7 @@ var DependencySet = wire.NewSet(
 		new(*repos.UIDRepository),
 	),
 	repos.NewTasksRepository,
+	config.NewSnapshotOptions,
 	wire.Bind(
 		new(tasks.IRepository),
 		new(*repos.TasksRepository),
@@ -111,6 +149,17 @@ var DependencySet = wire.NewSet(
 		new(quotes.IRepository),
 		new(*repos.QuotesRepository),
 	),
+	repos.NewSagasRepository,
+	wire.Bind(
+		new(sagas.IRepository),
+		new(*repos.SagasRepository),
+	),
+	repos.NewIdempotencyRepository,
+	config.NewIdempotencyOptions,
+	wire.Bind(
+		new(idempotency.IRepository),
+		new(*repos.IdempotencyRepository),
+	),
 
 	wire.Bind(
 		new(domtrace.IRepository),
@@ -152,16 +201,22 @@ func NewTraceExporterList(
 type Implementation struct {
 	dbctx *tsqlx.TracedDB
 	lgrf  *lgr.LoggerFactory
+	rly   *outbox.Relay
+	hub   *eventhub.Hub
 }
 
 // NewImplementation constructor for the evcqrs implementation
 func NewImplementation(
 	dbctx *tsqlx.TracedDB,
 	lgrf *lgr.LoggerFactory,
+	rly *outbox.Relay,
+	hub *eventhub.Hub,
 ) *Implementation {
 	return &Implementation{
 		dbctx: dbctx,
 		lgrf:  lgrf,
+		rly:   rly,
+		hub:   hub,
 	}
 }
 
@@ -179,12 +234,16 @@ func (i *Implementation) Start(ctx context.Context) error {
 		lgri.Error("failed to run migration", zap.Error(err))
 		return err
 	}
+	i.rly.Start(ctx)
+	i.hub.Start()
 	return nil
 }
 
 // Stop runs any routines that are required for the implementation layer to
 // gracefully shutdown
 func (i *Implementation) Stop(ctx context.Context) error {
+	i.hub.Stop()
+	i.rly.Stop(ctx)
 	i.lgrf.Close()
 	return nil
 }
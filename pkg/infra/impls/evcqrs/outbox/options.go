@@ -0,0 +1,12 @@
+package outbox
+
+import "time"
+
+// Options options for the outbox relay
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts attempts after which an entry that keeps failing is dead
+	// lettered
+	MaxAttempts int
+}
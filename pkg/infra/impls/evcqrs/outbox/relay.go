@@ -0,0 +1,279 @@
+// Package outbox implements the relay for the transactional outbox, events are
+// written to the outbox in the same transaction as the event store and the
+// relay publishes them through the event dispatcher
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/dispatch"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"github.com/BetaLixT/tsqlx"
+	"go.uber.org/zap"
+)
+
+// Relay polls the outbox for undispatched events and publishes them in order,
+// multiple relays can run against the same database as only the relay holding
+// the outbox lock publishes. Entries that keep failing are dead lettered after
+// the max attempts so they don't hold up the rest of the outbox
+type Relay struct {
+	dbctx *tsqlx.TracedDB
+	disp  dispatch.IEventDispatcher
+	lgrf  logger.IFactory
+	optn  *Options
+
+	ntfy    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	runmtx  sync.Mutex
+	running bool
+}
+
+// NewRelay constructs a new outbox relay
+func NewRelay(
+	dbctx *tsqlx.TracedDB,
+	disp dispatch.IEventDispatcher,
+	lgrf logger.IFactory,
+	optn *Options,
+) *Relay {
+	return &Relay{
+		dbctx: dbctx,
+		disp:  disp,
+		lgrf:  lgrf,
+		optn:  optn,
+		ntfy:  make(chan struct{}, 1),
+	}
+}
+
+// Start starts relaying events in the background
+func (r *Relay) Start(ctx context.Context) {
+	r.runmtx.Lock()
+	defer r.runmtx.Unlock()
+	if r.running {
+		return
+	}
+	r.running = true
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.run()
+}
+
+// Stop stops the relay, waiting for the batch in progress to complete
+func (r *Relay) Stop(ctx context.Context) {
+	r.runmtx.Lock()
+	defer r.runmtx.Unlock()
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stop)
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+	}
+}
+
+// Notify wakes up the relay so newly committed events are dispatched without
+// waiting for the next poll
+func (r *Relay) Notify() {
+	select {
+	case r.ntfy <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Relay) run() {
+	defer close(r.done)
+	lgr := r.lgrf.Create(context.Background())
+	tckr := time.NewTicker(r.optn.PollInterval)
+	defer tckr.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-tckr.C:
+		case <-r.ntfy:
+		}
+
+		// draining the outbox, a full batch means there may be more entries
+		for {
+			cnt, err := r.relayBatch(context.Background())
+			if err != nil {
+				lgr.Error("failed to relay outbox batch", zap.Error(err))
+				break
+			}
+			if cnt < r.optn.BatchSize {
+				break
+			}
+			select {
+			case <-r.stop:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// relayBatch dispatches a single batch of pending entries, entries are marked
+// as dispatched only after the dispatcher succeeds and dispatching stops at
+// the first failure to maintain ordering. Nothing is dispatched while another
+// relay holds the outbox lock
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	lgr := r.lgrf.Create(ctx)
+
+	tx, err := r.dbctx.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	locked := false
+	err = tx.Get(ctx, &locked, LockOutboxQuery, OutboxLockKey)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	entries := []entities.OutboxEntry{}
+	err = tx.Select(
+		ctx,
+		&entries,
+		SelectPendingOutboxQuery,
+		r.optn.BatchSize,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for idx := range entries {
+		dispErr := r.dispatch(ctx, &entries[idx])
+		if dispErr != nil {
+			attempts := entries[idx].Attempts + 1
+			if attempts >= r.optn.MaxAttempts {
+				// dead lettering the entry so the entries after it are relayed
+				lgr.Error(
+					"failed to dispatch event, dead lettering",
+					zap.Uint64("eventId", entries[idx].ID),
+					zap.Int("attempts", attempts),
+					zap.Error(dispErr),
+				)
+				_, err = tx.Exec(
+					ctx,
+					MarkOutboxDeadLetteredQuery,
+					entries[idx].OutboxID,
+					dispErr.Error(),
+				)
+				if err != nil {
+					return 0, err
+				}
+				continue
+			}
+
+			lgr.Warn(
+				"failed to dispatch event",
+				zap.Uint64("eventId", entries[idx].ID),
+				zap.Int("attempts", attempts),
+				zap.Error(dispErr),
+			)
+			_, err = tx.Exec(
+				ctx,
+				MarkOutboxFailedQuery,
+				entries[idx].OutboxID,
+				dispErr.Error(),
+			)
+			if err != nil {
+				return 0, err
+			}
+			// committing the attempt, the failed entry is retried next poll
+			return 0, tx.Commit()
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			MarkOutboxDispatchedQuery,
+			entries[idx].OutboxID,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(entries), tx.Commit()
+}
+
+func (r *Relay) dispatch(
+	ctx context.Context,
+	entry *entities.OutboxEntry,
+) error {
+	data, err := entities.DecodeEventData(
+		entry.Stream,
+		entry.SchemaVersion,
+		entry.Data,
+	)
+	if err != nil {
+		return err
+	}
+	return r.disp.DispatchEvent(ctx, &dispatch.Event{
+		EventEntity: *entry.BaseEvent.ToDTO(),
+		Data:        data,
+	})
+}
+
+// OutboxLockKey the advisory lock held by the relay publishing the outbox
+const OutboxLockKey = 7_010_001
+
+// - Queries
+const (
+	// the lock is released with the transaction so a relay that dies doesn't
+	// keep the outbox locked
+	LockOutboxQuery = `
+	SELECT pg_try_advisory_xact_lock($1)
+	`
+
+	SelectPendingOutboxQuery = `
+	SELECT
+		o.id AS outbox_id,
+		o.attempts,
+		e.id,
+		e.saga_id,
+		e.stream,
+		e.stream_id,
+		e.version,
+		e.event,
+		e.event_time,
+		e.trace_id,
+		e.request_id,
+		e.data,
+		e.schema_version
+	FROM outbox o
+	JOIN events e ON e.id = o.event_id
+	WHERE o.date_time_dispatched IS NULL
+		AND o.date_time_dead_lettered IS NULL
+	ORDER BY o.id
+	LIMIT $1
+	`
+
+	MarkOutboxDispatchedQuery = `
+	UPDATE outbox SET date_time_dispatched = NOW() WHERE id = $1
+	`
+
+	MarkOutboxFailedQuery = `
+	UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1
+	`
+
+	MarkOutboxDeadLetteredQuery = `
+	UPDATE outbox
+	SET
+		attempts = attempts + 1,
+		last_error = $2,
+		date_time_dead_lettered = NOW()
+	WHERE id = $1
+	`
+)
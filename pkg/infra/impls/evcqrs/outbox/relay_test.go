@@ -0,0 +1,343 @@
+//go:build postgres
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/dispatch"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+
+	"github.com/BetaLixT/tsqlx"
+	"go.uber.org/zap"
+)
+
+type mockTracer struct{}
+
+func (t *mockTracer) TraceDependency(
+	ctx context.Context,
+	spanID string,
+	dependencyType string,
+	serviceName string,
+	commandName string,
+	success bool,
+	startTimestamp time.Time,
+	eventTimestamp time.Time,
+	fields map[string]string,
+) {
+}
+
+type loggerFactory struct {
+	lgr *zap.Logger
+}
+
+func (f *loggerFactory) Create(_ context.Context) *zap.Logger {
+	return f.lgr
+}
+
+// mockDispatcher records the dispatched events, events with their id in fail
+// are rejected
+type mockDispatcher struct {
+	mtx        sync.Mutex
+	dispatched map[uint64]int
+	fail       map[uint64]bool
+}
+
+func newMockDispatcher() *mockDispatcher {
+	return &mockDispatcher{
+		dispatched: map[uint64]int{},
+		fail:       map[uint64]bool{},
+	}
+}
+
+func (d *mockDispatcher) DispatchEvent(
+	ctx context.Context,
+	evnt *dispatch.Event,
+) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.fail[evnt.Id] {
+		return errors.New("dispatch failed")
+	}
+	d.dispatched[evnt.Id]++
+	return nil
+}
+
+func (d *mockDispatcher) count(id uint64) int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.dispatched[id]
+}
+
+const (
+	testDatabaseConnString  = "host=127.0.0.1 port=5432 user=admin password=123456 dbname=todo_test sslmode=disable"
+	testDatabaseServiceName = "test-db"
+
+	insertTestEventQuery = `
+	INSERT INTO events(
+		stream,
+		stream_id,
+		version,
+		event,
+		trace_id,
+		request_id,
+		data
+	) VALUES(
+		'outbox-test', $1, 1, 'created', '', '', '\x7b7d'
+	) RETURNING id`
+
+	insertTestOutboxQuery = `
+	INSERT INTO outbox(event_id) VALUES($1)
+	`
+
+	// settling whatever previous runs left pending so only the entries of the
+	// test are relayed
+	settleOutboxQuery = `
+	UPDATE outbox SET date_time_dispatched = NOW()
+	WHERE date_time_dispatched IS NULL AND date_time_dead_lettered IS NULL
+	`
+
+	selectTestOutboxQuery = `
+	SELECT
+		attempts,
+		date_time_dispatched IS NOT NULL AS dispatched,
+		date_time_dead_lettered IS NOT NULL AS dead_lettered
+	FROM outbox WHERE event_id = $1
+	`
+)
+
+type outboxState struct {
+	Attempts     int  `db:"attempts"`
+	Dispatched   bool `db:"dispatched"`
+	DeadLettered bool `db:"dead_lettered"`
+}
+
+func createRelayDependencies(
+	t *testing.T,
+) (*tsqlx.TracedDB, *loggerFactory) {
+	lgr, _ := zap.NewDevelopment()
+	dbctx, err := psqldb.NewDatabaseContext(
+		&mockTracer{},
+		&psqldb.DatabaseOptions{
+			ConnectionString:    testDatabaseConnString,
+			DatabaseServiceName: testDatabaseServiceName,
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	err = psqldb.RunMigrations(
+		context.Background(),
+		lgr,
+		dbctx,
+		entities.GetMigrationScripts(),
+	)
+	if err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	_, err = dbctx.Exec(context.Background(), settleOutboxQuery)
+	if err != nil {
+		t.Fatalf("failed to settle outbox: %v", err)
+	}
+	return dbctx, &loggerFactory{lgr: lgr}
+}
+
+// createEntries writes events with their outbox entries, returning the event
+// ids in outbox order
+func createEntries(t *testing.T, dbctx *tsqlx.TracedDB, cnt int) []uint64 {
+	ctx := context.Background()
+	ids := make([]uint64, cnt)
+	prefix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	for idx := range ids {
+		err := dbctx.Get(
+			ctx,
+			&ids[idx],
+			insertTestEventQuery,
+			prefix+"-"+strconv.Itoa(idx),
+		)
+		if err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+		_, err = dbctx.Exec(ctx, insertTestOutboxQuery, ids[idx])
+		if err != nil {
+			t.Fatalf("failed to insert outbox entry: %v", err)
+		}
+	}
+	return ids
+}
+
+func getOutboxState(
+	t *testing.T,
+	dbctx *tsqlx.TracedDB,
+	id uint64,
+) outboxState {
+	state := outboxState{}
+	err := dbctx.Get(context.Background(), &state, selectTestOutboxQuery, id)
+	if err != nil {
+		t.Fatalf("failed to get outbox entry: %v", err)
+	}
+	return state
+}
+
+func TestRelayDispatches(t *testing.T) {
+	dbctx, lgrf := createRelayDependencies(t)
+	disp := newMockDispatcher()
+	r := NewRelay(dbctx, disp, lgrf, &Options{BatchSize: 10, MaxAttempts: 3})
+
+	ids := createEntries(t, dbctx, 3)
+	cnt, err := r.relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	if cnt != len(ids) {
+		t.Fatalf("expected %d entries relayed, got %d", len(ids), cnt)
+	}
+	for _, id := range ids {
+		if disp.count(id) != 1 {
+			t.Fatalf("expected event %d to be dispatched once", id)
+		}
+		if !getOutboxState(t, dbctx, id).Dispatched {
+			t.Fatalf("expected event %d to be marked dispatched", id)
+		}
+	}
+
+	// dispatched entries aren't picked up again
+	cnt, err = r.relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	if cnt != 0 {
+		t.Fatalf("expected nothing relayed, got %d", cnt)
+	}
+}
+
+func TestRelayRetriesAndDeadLetters(t *testing.T) {
+	dbctx, lgrf := createRelayDependencies(t)
+	disp := newMockDispatcher()
+	r := NewRelay(dbctx, disp, lgrf, &Options{BatchSize: 10, MaxAttempts: 3})
+
+	ids := createEntries(t, dbctx, 2)
+	disp.fail[ids[0]] = true
+
+	// the failing entry holds up the entries after it until it's dead lettered
+	for attempt := 1; attempt < 3; attempt++ {
+		_, err := r.relayBatch(context.Background())
+		if err != nil {
+			t.Fatalf("failed to relay batch: %v", err)
+		}
+		state := getOutboxState(t, dbctx, ids[0])
+		if state.Attempts != attempt || state.Dispatched || state.DeadLettered {
+			t.Fatalf("unexpected state after attempt %d: %+v", attempt, state)
+		}
+		if disp.count(ids[1]) != 0 {
+			t.Fatalf("expected event %d to wait for the failing entry", ids[1])
+		}
+	}
+
+	_, err := r.relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	state := getOutboxState(t, dbctx, ids[0])
+	if state.Attempts != 3 || state.Dispatched || !state.DeadLettered {
+		t.Fatalf("expected entry to be dead lettered, got %+v", state)
+	}
+	if disp.count(ids[1]) != 1 || !getOutboxState(t, dbctx, ids[1]).Dispatched {
+		t.Fatalf("expected event %d to be dispatched", ids[1])
+	}
+
+	// dead lettered entries aren't retried
+	disp.fail[ids[0]] = false
+	_, err = r.relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	if disp.count(ids[0]) != 0 {
+		t.Fatalf("expected dead lettered event %d to not be dispatched", ids[0])
+	}
+}
+
+func TestRelaySkipsWhileLocked(t *testing.T) {
+	dbctx, lgrf := createRelayDependencies(t)
+	disp := newMockDispatcher()
+	r := NewRelay(dbctx, disp, lgrf, &Options{BatchSize: 10, MaxAttempts: 3})
+
+	ids := createEntries(t, dbctx, 1)
+
+	// holding the lock as another relay would
+	tx, err := dbctx.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	locked := false
+	err = tx.Get(context.Background(), &locked, LockOutboxQuery, OutboxLockKey)
+	if err != nil || !locked {
+		tx.Rollback()
+		t.Fatalf("failed to lock outbox: %v", err)
+	}
+
+	cnt, err := r.relayBatch(context.Background())
+	tx.Rollback()
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	if cnt != 0 || disp.count(ids[0]) != 0 {
+		t.Fatalf("expected nothing relayed while the outbox is locked")
+	}
+
+	cnt, err = r.relayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+	if cnt != 1 || disp.count(ids[0]) != 1 {
+		t.Fatalf("expected entry to be relayed once the lock is released")
+	}
+}
+
+func TestConcurrentRelaysDispatchOnce(t *testing.T) {
+	dbctx, lgrf := createRelayDependencies(t)
+	disp := newMockDispatcher()
+	optn := &Options{BatchSize: 5, MaxAttempts: 3}
+	relays := []*Relay{
+		NewRelay(dbctx, disp, lgrf, optn),
+		NewRelay(dbctx, disp, lgrf, optn),
+	}
+
+	ids := createEntries(t, dbctx, 50)
+	deadline := time.Now().Add(30 * time.Second)
+	wg := sync.WaitGroup{}
+	errs := make(chan error, len(relays))
+	for _, r := range relays {
+		wg.Add(1)
+		go func(r *Relay) {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				_, err := r.relayBatch(context.Background())
+				if err != nil {
+					errs <- err
+					return
+				}
+				if disp.count(ids[len(ids)-1]) != 0 {
+					return
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("failed to relay batch: %v", err)
+	}
+
+	for _, id := range ids {
+		if cnt := disp.count(id); cnt != 1 {
+			t.Fatalf("expected event %d to be dispatched once, got %d", id, cnt)
+		}
+	}
+}
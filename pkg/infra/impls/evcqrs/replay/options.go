@@ -0,0 +1,31 @@
+package replay
+
+// Mode how the read model is rebuilt
+type Mode string
+
+const (
+	// ModeTruncate clears the read model and rebuilds it in place, the read
+	// model is incomplete until the replay finishes
+	ModeTruncate Mode = "truncate"
+	// ModeShadow rebuilds the read model into a shadow table that is swapped in
+	// once the replay has caught up, the read model stays usable throughout
+	ModeShadow Mode = "shadow"
+)
+
+// Options options for the replayer
+type Options struct {
+	BatchSize int
+}
+
+// Progress reported after every batch of events applied
+type Progress struct {
+	Projection    string
+	Mode          Mode
+	LastEventID   uint64
+	EventsApplied uint64
+	EventsTotal   uint64
+	Completed     bool
+}
+
+// ProgressReporter receives progress as the replay runs
+type ProgressReporter func(prgs Progress)
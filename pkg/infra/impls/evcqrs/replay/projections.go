@@ -0,0 +1,344 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"github.com/BetaLixT/tsqlx"
+)
+
+// IProjection folds the events of a single stream into a read model table
+type IProjection interface {
+	// Name unique name of the projection, used to track progress
+	Name() string
+	// Stream the stream whose events are folded by the projection
+	Stream() string
+	// Table the read model table the projection writes to
+	Table() string
+	// Apply applies a single event to the given table (which will either be the
+	// read model table or it's shadow)
+	Apply(
+		ctx context.Context,
+		tx *tsqlx.TracedTx,
+		table string,
+		evnt *entities.RawEvent,
+	) error
+}
+
+// ISnapshotProjection projection that can be seeded from snapshots of it's
+// stream instead of folding every event
+type ISnapshotProjection interface {
+	IProjection
+	// ApplySnapshot writes the state held by the snapshot to the given table
+	ApplySnapshot(
+		ctx context.Context,
+		tx *tsqlx.TracedTx,
+		table string,
+		snap *entities.Snapshot,
+	) error
+}
+
+// NewProjectionList provides all the projections known to the implementation
+func NewProjectionList() []IProjection {
+	return []IProjection{
+		&TasksProjection{},
+		&TaskDependenciesProjection{},
+		&QuotesProjection{},
+	}
+}
+
+// TasksProjection projection for the tasks read model
+type TasksProjection struct{}
+
+var _ ISnapshotProjection = (*TasksProjection)(nil)
+
+// Name unique name of the projection
+func (*TasksProjection) Name() string {
+	return "tasks"
+}
+
+// Stream stream the projection is built from
+func (*TasksProjection) Stream() string {
+	return domcom.TaskStreamName
+}
+
+// Table read model table
+func (*TasksProjection) Table() string {
+	return "tasks"
+}
+
+// Apply applies a task event to the table
+func (*TasksProjection) Apply(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	table string,
+	evnt *entities.RawEvent,
+) error {
+	data := entities.TaskData{}
+	err := data.Scan(evnt.Data)
+	if err != nil {
+		return err
+	}
+
+	switch evnt.Event {
+	case domcom.EventCreated:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(insertTaskProjectionQuery, table),
+			evnt.StreamID,
+			data.GetTitle(),
+			data.GetDescription(),
+			data.GetStatus(),
+			entities.JSONMapString(data.RandomMap),
+			entities.JSONObj(data.Metadata.AsMap()),
+			evnt.Version,
+			evnt.EventTime,
+			entities.DueDateValue(data.DueDate),
+			data.GetParentId(),
+		)
+	case domcom.EventDeleted:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(deleteProjectionQuery, table),
+			evnt.StreamID,
+		)
+	default:
+		set, vals, _ := data.GeneratePSQLReadModelSet(4)
+		if set != "" {
+			set += ","
+		}
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(updateTaskProjectionQuery, table, set),
+			append(
+				[]interface{}{evnt.StreamID, evnt.Version, evnt.EventTime},
+				vals...,
+			)...,
+		)
+	}
+	return err
+}
+
+// ApplySnapshot writes the task state held by the snapshot to the table
+func (*TasksProjection) ApplySnapshot(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	table string,
+	snap *entities.Snapshot,
+) error {
+	err := snap.Upcast()
+	if err != nil {
+		return err
+	}
+	data := entities.TaskData{}
+	err = data.Scan(snap.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		fmt.Sprintf(insertTaskSnapshotProjectionQuery, table),
+		snap.StreamID,
+		data.GetTitle(),
+		data.GetDescription(),
+		data.GetStatus(),
+		entities.JSONMapString(data.RandomMap),
+		entities.JSONObj(data.Metadata.AsMap()),
+		data.GetAssignee(),
+		snap.Version,
+		snap.StreamTimeCreated,
+		snap.EventTime,
+		entities.DueDateValue(data.DueDate),
+		data.GetParentId(),
+	)
+	return err
+}
+
+// TaskDependenciesProjection projection for the dependencies between tasks,
+// the dependencies aren't kept in the task snapshots so every event is folded
+type TaskDependenciesProjection struct{}
+
+var _ IProjection = (*TaskDependenciesProjection)(nil)
+
+// Name unique name of the projection
+func (*TaskDependenciesProjection) Name() string {
+	return "task-dependencies"
+}
+
+// Stream stream the projection is built from
+func (*TaskDependenciesProjection) Stream() string {
+	return domcom.TaskStreamName
+}
+
+// Table read model table
+func (*TaskDependenciesProjection) Table() string {
+	return "task_dependencies"
+}
+
+// Apply applies a task event to the table, the dependencies of deleted tasks
+// are removed in both directions
+func (*TaskDependenciesProjection) Apply(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	table string,
+	evnt *entities.RawEvent,
+) error {
+	data := entities.TaskData{}
+	err := data.Scan(evnt.Data)
+	if err != nil {
+		return err
+	}
+
+	switch evnt.Event {
+	case domcom.EventDependencyAdded:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(insertTaskDependencyProjectionQuery, table),
+			evnt.StreamID,
+			data.GetBlockerId(),
+		)
+	case domcom.EventDependencyRemoved:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(deleteTaskDependencyProjectionQuery, table),
+			evnt.StreamID,
+			data.GetBlockerId(),
+		)
+	case domcom.EventDeleted:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(deleteTaskDependenciesProjectionQuery, table),
+			evnt.StreamID,
+		)
+	}
+	return err
+}
+
+// QuotesProjection projection for the quotes read model
+type QuotesProjection struct{}
+
+var _ IProjection = (*QuotesProjection)(nil)
+
+// Name unique name of the projection
+func (*QuotesProjection) Name() string {
+	return "quotes"
+}
+
+// Stream stream the projection is built from
+func (*QuotesProjection) Stream() string {
+	return domcom.QuoteStreamName
+}
+
+// Table read model table
+func (*QuotesProjection) Table() string {
+	return "quotes"
+}
+
+// Apply applies a quote event to the table
+func (*QuotesProjection) Apply(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	table string,
+	evnt *entities.RawEvent,
+) error {
+	data := entities.QuoteData{}
+	err := data.Scan(evnt.Data)
+	if err != nil {
+		return err
+	}
+
+	switch evnt.Event {
+	case domcom.EventCreated:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(insertQuoteProjectionQuery, table),
+			evnt.StreamID,
+			data.GetQuote(),
+			evnt.Version,
+			evnt.EventTime,
+		)
+	case domcom.EventDeleted:
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(deleteProjectionQuery, table),
+			evnt.StreamID,
+		)
+	}
+	return err
+}
+
+// - Queries
+const (
+	insertTaskProjectionQuery = `
+	INSERT INTO %s (
+		id,
+		title,
+		description,
+		status,
+		random_map,
+		metadata,
+		version,
+		date_time_created,
+		date_time_updated,
+		due_date,
+		parent_id
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10
+	)
+	`
+
+	insertTaskSnapshotProjectionQuery = `
+	INSERT INTO %s (
+		id,
+		title,
+		description,
+		status,
+		random_map,
+		metadata,
+		assignee,
+		version,
+		date_time_created,
+		date_time_updated,
+		due_date,
+		parent_id
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+	)
+	`
+
+	updateTaskProjectionQuery = `
+	UPDATE %s SET %s version = $2, date_time_updated = $3 WHERE id = $1
+	`
+
+	insertTaskDependencyProjectionQuery = `
+	INSERT INTO %s (stream_id, blocker_id) VALUES ($1, $2)
+	`
+
+	deleteTaskDependencyProjectionQuery = `
+	DELETE FROM %s WHERE stream_id = $1 AND blocker_id = $2
+	`
+
+	deleteTaskDependenciesProjectionQuery = `
+	DELETE FROM %s WHERE stream_id = $1 OR blocker_id = $1
+	`
+
+	insertQuoteProjectionQuery = `
+	INSERT INTO %s (
+		id,
+		quote,
+		version,
+		date_time_created,
+		date_time_updated
+	) VALUES (
+		$1, $2, $3, $4, $4
+	)
+	`
+
+	deleteProjectionQuery = `
+	DELETE FROM %s WHERE id = $1
+	`
+)
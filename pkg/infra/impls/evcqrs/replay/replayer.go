@@ -0,0 +1,517 @@
+// Package replay rebuilds read models by folding the events table in order,
+// progress is checkpointed after every batch so an interrupted replay picks up
+// from where it stopped
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"github.com/BetaLixT/tsqlx"
+	"go.uber.org/zap"
+)
+
+// Replayer rebuilds read models from the event store
+type Replayer struct {
+	dbctx *tsqlx.TracedDB
+	lgrf  logger.IFactory
+	optn  *Options
+	prjs  []IProjection
+}
+
+// NewReplayer constructs a new replayer
+func NewReplayer(
+	dbctx *tsqlx.TracedDB,
+	lgrf logger.IFactory,
+	optn *Options,
+	prjs []IProjection,
+) *Replayer {
+	return &Replayer{
+		dbctx: dbctx,
+		lgrf:  lgrf,
+		optn:  optn,
+		prjs:  prjs,
+	}
+}
+
+// Projections lists the names of all the projections that can be replayed
+func (r *Replayer) Projections() []string {
+	names := make([]string, len(r.prjs))
+	for idx := range r.prjs {
+		names[idx] = r.prjs[idx].Name()
+	}
+	return names
+}
+
+// Replay rebuilds the read model of a projection, an unfinished replay of the
+// projection is resumed unless restart is set
+func (r *Replayer) Replay(
+	ctx context.Context,
+	name string,
+	mode Mode,
+	restart bool,
+	rprt ProgressReporter,
+) error {
+	lgr := r.lgrf.Create(ctx).With(
+		zap.String("projection", name),
+		zap.String("mode", string(mode)),
+	)
+
+	prj := r.getProjection(name)
+	if prj == nil {
+		return common.NewUnknownProjectionError(name)
+	}
+	if mode != ModeTruncate && mode != ModeShadow {
+		return common.NewInvalidReplayModeError(string(mode))
+	}
+
+	prgs, err := r.getProgress(ctx, prj)
+	if err != nil {
+		lgr.Error("failed to fetch replay progress", zap.Error(err))
+		return err
+	}
+	if prgs != nil && prgs.DateTimeCompleted == nil && !restart {
+		if Mode(prgs.Mode) != mode {
+			return common.NewReplayModeMismatchError(prgs.Mode)
+		}
+		lgr.Info(
+			"resuming replay",
+			zap.Uint64("lastEventId", prgs.LastEventID),
+			zap.Uint64("eventsApplied", prgs.EventsApplied),
+		)
+	} else {
+		lgr.Info("starting replay")
+		prgs, err = r.begin(ctx, prj, mode)
+		if err != nil {
+			lgr.Error("failed to begin replay", zap.Error(err))
+			return err
+		}
+	}
+
+	var total uint64
+	err = r.dbctx.Get(
+		ctx,
+		&total,
+		countStreamEventsQuery,
+		prj.Stream(),
+		prgs.SnapshotEventID,
+	)
+	if err != nil {
+		lgr.Error("failed to count events", zap.Error(err))
+		return err
+	}
+
+	report := func(completed bool) {
+		if rprt != nil {
+			rprt(Progress{
+				Projection:    prj.Name(),
+				Mode:          mode,
+				LastEventID:   prgs.LastEventID,
+				EventsApplied: prgs.EventsApplied,
+				EventsTotal:   total,
+				Completed:     completed,
+			})
+		}
+	}
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		tx, err := r.dbctx.Beginx()
+		if err != nil {
+			return err
+		}
+		cnt, err := r.applyBatch(ctx, tx, prj, mode, prgs, false)
+		if err != nil {
+			tx.Rollback()
+			lgr.Error(
+				"failed to apply batch",
+				zap.Uint64("lastEventId", prgs.LastEventID),
+				zap.Error(err),
+			)
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		if cnt == 0 {
+			break
+		}
+		report(false)
+	}
+
+	err = r.complete(ctx, prj, mode, prgs)
+	if err != nil {
+		lgr.Error("failed to complete replay", zap.Error(err))
+		return err
+	}
+	report(true)
+	lgr.Info(
+		"replay completed",
+		zap.Uint64("eventsApplied", prgs.EventsApplied),
+	)
+	return nil
+}
+
+func (r *Replayer) getProjection(name string) IProjection {
+	for idx := range r.prjs {
+		if r.prjs[idx].Name() == name {
+			return r.prjs[idx]
+		}
+	}
+	return nil
+}
+
+func (r *Replayer) getProgress(
+	ctx context.Context,
+	prj IProjection,
+) (*entities.ReplayProgress, error) {
+	prgs := entities.ReplayProgress{}
+	err := r.dbctx.Get(ctx, &prgs, selectReplayProgressQuery, prj.Name())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &prgs, nil
+}
+
+// begin clears the target of the replay, seeds it from the latest snapshots if
+// the projection supports them and resets the progress
+func (r *Replayer) begin(
+	ctx context.Context,
+	prj IProjection,
+	mode Mode,
+) (*entities.ReplayProgress, error) {
+	tx, err := r.dbctx.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if mode == ModeShadow {
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(dropTableQuery, shadowTable(prj)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(createShadowTableQuery, shadowTable(prj), prj.Table()),
+		)
+	} else {
+		_, err = tx.Exec(
+			ctx,
+			fmt.Sprintf(truncateTableQuery, prj.Table()),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// events after the cutoff are folded even if they have been snapshotted
+	// since, so snapshots written while the replay runs are ignored
+	var cutoff uint64
+	if sprj, ok := prj.(ISnapshotProjection); ok {
+		cutoff, err = r.seedSnapshots(ctx, tx, sprj, mode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prgs := entities.ReplayProgress{}
+	err = tx.Get(
+		ctx,
+		&prgs,
+		upsertReplayProgressQuery,
+		prj.Name(),
+		mode,
+		cutoff,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &prgs, tx.Commit()
+}
+
+// seedSnapshots writes the latest snapshot of every stream to the target
+// table, provides the event id cutoff for the snapshots that were used
+func (r *Replayer) seedSnapshots(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	prj ISnapshotProjection,
+	mode Mode,
+) (uint64, error) {
+	var cutoff uint64
+	err := tx.Get(ctx, &cutoff, selectMaxEventIDQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	snaps := []entities.Snapshot{}
+	err = tx.Select(
+		ctx,
+		&snaps,
+		selectLatestSnapshotsQuery,
+		prj.Stream(),
+		cutoff,
+	)
+	if err != nil || len(snaps) == 0 {
+		return cutoff, err
+	}
+
+	table := prj.Table()
+	if mode == ModeShadow {
+		table = shadowTable(prj)
+	} else {
+		_, err = tx.Exec(ctx, fmt.Sprintf(disableTriggersQuery, table))
+		if err != nil {
+			return 0, err
+		}
+	}
+	for idx := range snaps {
+		err = prj.ApplySnapshot(ctx, tx, table, &snaps[idx])
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed applying snapshot %s:%d: %w",
+				snaps[idx].StreamID,
+				snaps[idx].Version,
+				err,
+			)
+		}
+	}
+	if mode != ModeShadow {
+		_, err = tx.Exec(ctx, fmt.Sprintf(enableTriggersQuery, table))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return cutoff, nil
+}
+
+// applyBatch applies the next batch of events after the checkpoint and moves
+// the checkpoint forward in the same transaction. Events are folded in the
+// order their transactions were written in and event ids are assigned before
+// commit, so only events of transactions older than every transaction still
+// running are folded as a slower transaction could otherwise commit behind the
+// checkpoint. Once the read model is locked the transactions still running
+// can't write to it before the replay completes, so every committed event is
+// folded
+func (r *Replayer) applyBatch(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	prj IProjection,
+	mode Mode,
+	prgs *entities.ReplayProgress,
+	locked bool,
+) (int, error) {
+	evnts := []entities.RawEvent{}
+	err := tx.Select(
+		ctx,
+		&evnts,
+		selectStreamEventsQuery,
+		prj.Stream(),
+		prgs.LastEventID,
+		r.optn.BatchSize,
+		prgs.SnapshotEventID,
+		locked,
+	)
+	if err != nil || len(evnts) == 0 {
+		return 0, err
+	}
+
+	// the read model triggers would overwrite the timestamps being restored
+	// from the events, the shadow table is created without them
+	table := prj.Table()
+	if mode == ModeShadow {
+		table = shadowTable(prj)
+	} else {
+		_, err = tx.Exec(ctx, fmt.Sprintf(disableTriggersQuery, table))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for idx := range evnts {
+		err = evnts[idx].Upcast()
+		if err == nil {
+			err = prj.Apply(ctx, tx, table, &evnts[idx])
+		}
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed applying event %d: %w",
+				evnts[idx].ID,
+				err,
+			)
+		}
+	}
+
+	if mode != ModeShadow {
+		_, err = tx.Exec(ctx, fmt.Sprintf(enableTriggersQuery, table))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	last := evnts[len(evnts)-1].ID
+	_, err = tx.Exec(
+		ctx,
+		updateReplayProgressQuery,
+		prj.Name(),
+		last,
+		len(evnts),
+	)
+	if err != nil {
+		return 0, err
+	}
+	prgs.LastEventID = last
+	prgs.EventsApplied += uint64(len(evnts))
+	return len(evnts), nil
+}
+
+// complete marks the replay as complete, in shadow mode the writes to the read
+// model are blocked while the shadow catches up and is copied over
+func (r *Replayer) complete(
+	ctx context.Context,
+	prj IProjection,
+	mode Mode,
+	prgs *entities.ReplayProgress,
+) error {
+	tx, err := r.dbctx.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if mode == ModeShadow {
+		_, err = tx.Exec(ctx, fmt.Sprintf(lockTableQuery, prj.Table()))
+		if err != nil {
+			return err
+		}
+		for {
+			cnt, err := r.applyBatch(ctx, tx, prj, mode, prgs, true)
+			if err != nil {
+				return err
+			}
+			if cnt == 0 {
+				break
+			}
+		}
+		for _, qry := range []string{
+			fmt.Sprintf(disableTriggersQuery, prj.Table()),
+			fmt.Sprintf(truncateTableQuery, prj.Table()),
+			fmt.Sprintf(copyShadowTableQuery, prj.Table(), shadowTable(prj)),
+			fmt.Sprintf(enableTriggersQuery, prj.Table()),
+			fmt.Sprintf(dropTableQuery, shadowTable(prj)),
+		} {
+			_, err = tx.Exec(ctx, qry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, completeReplayProgressQuery, prj.Name())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func shadowTable(prj IProjection) string {
+	return prj.Table() + "_replay"
+}
+
+// - Queries
+const (
+	selectReplayProgressQuery = `
+	SELECT * FROM replay_progress WHERE projection = $1
+	`
+
+	upsertReplayProgressQuery = `
+	INSERT INTO replay_progress (
+		projection,
+		mode,
+		last_event_id,
+		events_applied,
+		snapshot_event_id
+	) VALUES (
+		$1, $2, 0, 0, $3
+	) ON CONFLICT (projection) DO UPDATE SET
+		mode = EXCLUDED.mode,
+		last_event_id = 0,
+		events_applied = 0,
+		snapshot_event_id = EXCLUDED.snapshot_event_id,
+		date_time_completed = NULL
+	RETURNING *
+	`
+
+	updateReplayProgressQuery = `
+	UPDATE replay_progress SET
+		last_event_id = $2,
+		events_applied = events_applied + $3
+	WHERE projection = $1
+	`
+
+	completeReplayProgressQuery = `
+	UPDATE replay_progress SET date_time_completed = NOW() WHERE projection = $1
+	`
+
+	selectMaxEventIDQuery = `
+	SELECT COALESCE(MAX(id), 0) FROM events
+	`
+
+	selectLatestSnapshotsQuery = `
+	SELECT DISTINCT ON (stream_id) * FROM snapshots
+	WHERE stream = $1 AND event_id <= $2
+	ORDER BY stream_id, version DESC
+	`
+
+	// events folded into the snapshots used to seed the replay are skipped
+	countStreamEventsQuery = `
+	SELECT COUNT(e.id) FROM events e
+	WHERE e.stream = $1 AND NOT EXISTS (
+		SELECT 1 FROM snapshots s
+		WHERE s.stream = e.stream AND s.stream_id = e.stream_id
+			AND s.event_id <= $2 AND s.version >= e.version
+	)
+	`
+
+	// the checkpoint is the position of the last event applied, the event ids
+	// are compared directly before the first event is applied
+	selectStreamEventsQuery = `
+	SELECT e.* FROM events e
+	LEFT JOIN events c ON c.id = $2
+	WHERE e.stream = $1
+		AND ($5 OR e.transaction_id < pg_snapshot_xmin(pg_current_snapshot()))
+		AND (
+			(c.id IS NULL AND e.id > $2)
+			OR e.transaction_id > c.transaction_id
+			OR (e.transaction_id = c.transaction_id AND e.id > c.id)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM snapshots s
+			WHERE s.stream = e.stream AND s.stream_id = e.stream_id
+				AND s.event_id <= $4 AND s.version >= e.version
+		)
+	ORDER BY e.transaction_id, e.id LIMIT $3
+	`
+
+	truncateTableQuery     = `TRUNCATE TABLE %s`
+	dropTableQuery         = `DROP TABLE IF EXISTS %s`
+	createShadowTableQuery = `CREATE TABLE %s (LIKE %s INCLUDING ALL)`
+	copyShadowTableQuery   = `INSERT INTO %s SELECT * FROM %s`
+	lockTableQuery         = `LOCK TABLE %s IN EXCLUSIVE MODE`
+	disableTriggersQuery   = `ALTER TABLE %s DISABLE TRIGGER USER`
+	enableTriggersQuery    = `ALTER TABLE %s ENABLE TRIGGER USER`
+)
@@ -0,0 +1,364 @@
+//go:build postgres
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+
+	"github.com/BetaLixT/tsqlx"
+	"go.uber.org/zap"
+)
+
+type mockTracer struct{}
+
+func (t *mockTracer) TraceDependency(
+	ctx context.Context,
+	spanID string,
+	dependencyType string,
+	serviceName string,
+	commandName string,
+	success bool,
+	startTimestamp time.Time,
+	eventTimestamp time.Time,
+	fields map[string]string,
+) {
+}
+
+type loggerFactory struct {
+	lgr *zap.Logger
+}
+
+func (f *loggerFactory) Create(_ context.Context) *zap.Logger {
+	return f.lgr
+}
+
+// testProjection counts the events of every stream of the test stream along
+// with the last version applied, an event applied twice is counted twice
+type testProjection struct{}
+
+var _ IProjection = (*testProjection)(nil)
+
+func (*testProjection) Name() string {
+	return "replay-test"
+}
+
+func (*testProjection) Stream() string {
+	return testStream
+}
+
+func (*testProjection) Table() string {
+	return "replay_test_counts"
+}
+
+func (*testProjection) Apply(
+	ctx context.Context,
+	tx *tsqlx.TracedTx,
+	table string,
+	evnt *entities.RawEvent,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		fmt.Sprintf(applyTestProjectionQuery, table),
+		evnt.StreamID,
+		evnt.Version,
+	)
+	return err
+}
+
+type streamCount struct {
+	StreamID string `db:"stream_id"`
+	Version  uint64 `db:"version"`
+	Events   uint64 `db:"events"`
+}
+
+const (
+	testDatabaseConnString  = "host=127.0.0.1 port=5432 user=admin password=123456 dbname=todo_test sslmode=disable"
+	testDatabaseServiceName = "test-db"
+
+	testStream = "replay-test"
+
+	createTestProjectionTableQuery = `
+	CREATE TABLE IF NOT EXISTS replay_test_counts (
+		stream_id text PRIMARY KEY NOT NULL,
+		version bigint NOT NULL,
+		events bigint NOT NULL
+	)
+	`
+
+	applyTestProjectionQuery = `
+	INSERT INTO %s AS t (stream_id, version, events) VALUES ($1, $2, 1)
+	ON CONFLICT (stream_id) DO UPDATE SET
+		version = EXCLUDED.version,
+		events = t.events + 1
+	`
+
+	insertTestEventQuery = `
+	INSERT INTO events(
+		stream,
+		stream_id,
+		version,
+		event,
+		trace_id,
+		request_id,
+		data
+	) VALUES(
+		'replay-test', $1, $2, 'updated', '', '', '\x7b7d'
+	)`
+
+	selectExpectedCountsQuery = `
+	SELECT stream_id, MAX(version) AS version, COUNT(*) AS events
+	FROM events WHERE stream = 'replay-test'
+	GROUP BY stream_id ORDER BY stream_id
+	`
+
+	selectTestCountsQuery = `
+	SELECT * FROM replay_test_counts ORDER BY stream_id
+	`
+
+	shadowTableExistsQuery = `
+	SELECT to_regclass('replay_test_counts_replay') IS NOT NULL
+	`
+)
+
+func createReplayer(t *testing.T) (*tsqlx.TracedDB, *Replayer) {
+	lgr, _ := zap.NewDevelopment()
+	dbctx, err := psqldb.NewDatabaseContext(
+		&mockTracer{},
+		&psqldb.DatabaseOptions{
+			ConnectionString:    testDatabaseConnString,
+			DatabaseServiceName: testDatabaseServiceName,
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	err = psqldb.RunMigrations(
+		context.Background(),
+		lgr,
+		dbctx,
+		entities.GetMigrationScripts(),
+	)
+	if err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	_, err = dbctx.Exec(context.Background(), createTestProjectionTableQuery)
+	if err != nil {
+		t.Fatalf("failed to create projection table: %v", err)
+	}
+	return dbctx, NewReplayer(
+		dbctx,
+		&loggerFactory{lgr: lgr},
+		&Options{BatchSize: 2},
+		[]IProjection{&testProjection{}},
+	)
+}
+
+// createStreams writes the given number of events to new streams of the test
+// stream, returning the ids of the streams
+func createStreams(
+	t *testing.T,
+	dbctx *tsqlx.TracedDB,
+	versions ...int,
+) []string {
+	prefix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	ids := make([]string, len(versions))
+	for idx, cnt := range versions {
+		ids[idx] = prefix + "-" + strconv.Itoa(idx)
+		for version := 1; version <= cnt; version++ {
+			_, err := dbctx.Exec(
+				context.Background(),
+				insertTestEventQuery,
+				ids[idx],
+				version,
+			)
+			if err != nil {
+				t.Fatalf("failed to insert event: %v", err)
+			}
+		}
+	}
+	return ids
+}
+
+// expectFolded checks that the projection table holds every event of the test
+// stream folded exactly once
+func expectFolded(t *testing.T, dbctx *tsqlx.TracedDB) {
+	expected := []streamCount{}
+	err := dbctx.Select(
+		context.Background(),
+		&expected,
+		selectExpectedCountsQuery,
+	)
+	if err != nil {
+		t.Fatalf("failed to count events: %v", err)
+	}
+	actual := []streamCount{}
+	err = dbctx.Select(context.Background(), &actual, selectTestCountsQuery)
+	if err != nil {
+		t.Fatalf("failed to select projection: %v", err)
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d streams, got %d", len(expected), len(actual))
+	}
+	for idx := range expected {
+		if actual[idx] != expected[idx] {
+			t.Fatalf("expected %+v, got %+v", expected[idx], actual[idx])
+		}
+	}
+}
+
+func TestReplayTruncate(t *testing.T) {
+	dbctx, rplyr := createReplayer(t)
+	createStreams(t, dbctx, 3, 1, 2)
+
+	// stale rows are cleared by the replay
+	_, err := dbctx.Exec(
+		context.Background(),
+		fmt.Sprintf(applyTestProjectionQuery, "replay_test_counts"),
+		"stale",
+		1,
+	)
+	if err != nil {
+		t.Fatalf("failed to insert stale row: %v", err)
+	}
+
+	var last Progress
+	err = rplyr.Replay(
+		context.Background(),
+		"replay-test",
+		ModeTruncate,
+		true,
+		func(prgs Progress) { last = prgs },
+	)
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+	if !last.Completed || last.EventsApplied != last.EventsTotal {
+		t.Fatalf("expected completed progress, got %+v", last)
+	}
+	expectFolded(t, dbctx)
+}
+
+func TestReplayShadow(t *testing.T) {
+	dbctx, rplyr := createReplayer(t)
+	ids := createStreams(t, dbctx, 2, 2)
+
+	// a write started before the replay commits an event with a lower id than
+	// events committed after it, it writes the read model the way commands do
+	tx, err := dbctx.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(context.Background(), insertTestEventQuery, ids[0], 3)
+	if err == nil {
+		_, err = tx.Exec(
+			context.Background(),
+			fmt.Sprintf(applyTestProjectionQuery, "replay_test_counts"),
+			ids[0],
+			3,
+		)
+	}
+	if err != nil {
+		t.Fatalf("failed to write event: %v", err)
+	}
+	createStreams(t, dbctx, 1)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- rplyr.Replay(
+			context.Background(),
+			"replay-test",
+			ModeShadow,
+			true,
+			nil,
+		)
+	}()
+
+	// the replay can't complete while the write holds the read model
+	select {
+	case err = <-done:
+		t.Fatalf("expected replay to wait for the write, got %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("failed to commit write: %v", err)
+	}
+	err = <-done
+	if err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+	expectFolded(t, dbctx)
+
+	exists := true
+	err = dbctx.Get(context.Background(), &exists, shadowTableExistsQuery)
+	if err != nil {
+		t.Fatalf("failed to check shadow table: %v", err)
+	}
+	if exists {
+		t.Fatalf("expected shadow table to be dropped")
+	}
+}
+
+func TestReplayResume(t *testing.T) {
+	dbctx, rplyr := createReplayer(t)
+	createStreams(t, dbctx, 3, 3)
+
+	// interrupting the replay after the first batch
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var first Progress
+	err := rplyr.Replay(
+		ctx,
+		"replay-test",
+		ModeShadow,
+		true,
+		func(prgs Progress) {
+			first = prgs
+			cancel()
+		},
+	)
+	if err != context.Canceled {
+		t.Fatalf("expected replay to be canceled, got %v", err)
+	}
+	if first.Completed || first.EventsApplied != 2 {
+		t.Fatalf("expected a single batch to be applied, got %+v", first)
+	}
+
+	// an unfinished replay can only be resumed in the mode it was started in
+	err = rplyr.Replay(
+		context.Background(),
+		"replay-test",
+		ModeTruncate,
+		false,
+		nil,
+	)
+	if !domcom.IsError(err, common.ReplayModeMismatchErrorCode) {
+		t.Fatalf("expected mode mismatch error, got %v", err)
+	}
+
+	var last Progress
+	err = rplyr.Replay(
+		context.Background(),
+		"replay-test",
+		ModeShadow,
+		false,
+		func(prgs Progress) { last = prgs },
+	)
+	if err != nil {
+		t.Fatalf("failed to resume replay: %v", err)
+	}
+	if !last.Completed || last.EventsApplied != last.EventsTotal {
+		t.Fatalf("expected completed progress, got %+v", last)
+	}
+	expectFolded(t, dbctx)
+}
@@ -114,14 +114,13 @@ func (r *ACLRepository) DeleteACLEntry(
 		streamID,
 	)
 	if err != nil {
-		lgr.Error("failed to delete entry")
+		if err == sql.ErrNoRows {
+			return domcom.NewACLEntryMissingError()
+		}
+		lgr.Error("failed to delete entry", zap.Error(err))
 		return err
 	}
-	err = r.rctx.ZRem(
-		ctx,
-		r.keySffx+userType+":"+userID,
-		generateACLSetMember(entry.Stream, entry.StreamID, entry.Permissions),
-	).Err()
+	err = r.invalidate(ctx, []entities.ACL{entry})
 	if err != nil {
 		lgr.Error("failed to delete cache entry", zap.Error(err))
 		return err
@@ -161,23 +160,64 @@ func (r *ACLRepository) DeleteACLEntries(
 		return err
 	}
 
-	rpipe := r.rctx.Pipeline()
-	for idx := range entry {
-		rpipe.ZRem(
-			ctx,
-			r.keySffx+entry[idx].UserType+":"+entry[idx].UserId,
-			generateACLSetMember(stream, streamID, entry[idx].Permissions),
-		)
-	}
-
-	_, err = rpipe.Exec(ctx)
+	err = r.invalidate(ctx, entry)
 	if err != nil {
-		lgr.Error("failed to delete cache entries")
+		lgr.Error("failed to delete cache entries", zap.Error(err))
 		return err
 	}
 	return nil
 }
 
+// invalidate removes the deleted entries from the cache, the entries are
+// removed again once the transaction commits since they could have been
+// cached again by a read that happened before the commit
+func (r *ACLRepository) invalidate(
+	ctx cntxt.IContext,
+	entries []entities.ACL,
+) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	remove := func(ctx context.Context) error {
+		rpipe := r.rctx.Pipeline()
+		for idx := range entries {
+			rpipe.ZRem(
+				ctx,
+				r.keySffx+entries[idx].UserType+":"+entries[idx].UserId,
+				generateACLSetMember(
+					entries[idx].Stream,
+					entries[idx].StreamID,
+					entries[idx].Permissions,
+				),
+			)
+		}
+		_, err := rpipe.Exec(ctx)
+		return err
+	}
+	// the transaction is already committed by the time this runs so failing
+	// to remove the entries again is only logged
+	ctx.RegisterCommitAction(func(c context.Context) error {
+		if err := remove(c); err != nil {
+			r.lgrf.Create(c).Warn(
+				"failed to delete cache entries after commit",
+				zap.Error(err),
+			)
+		}
+		return nil
+	})
+	return remove(ctx)
+}
+
+func (r *ACLRepository) GetPermissions(
+	ctx context.Context,
+	stream string,
+	streamID string,
+	userType string,
+	userID string,
+) (int, error) {
+	return r.getEntry(ctx, stream, streamID, userType, userID)
+}
+
 func (r *ACLRepository) CanRead(
 	ctx context.Context,
 	stream string,
@@ -250,6 +290,42 @@ func (r *ACLRepository) CanWrite(
 	return domcom.NewUserACLCheckFailedError()
 }
 
+func (r *ACLRepository) CanShare(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) error {
+	perm := 0
+	var err error
+	if len(streamIDs) == 1 {
+		perm, err = r.getEntry(
+			ctx,
+			stream,
+			streamIDs[0],
+			userType,
+			userID,
+		)
+	} else {
+		perm, err = r.getEntries(
+			ctx,
+			stream,
+			streamIDs,
+			userType,
+			userID,
+		)
+	}
+
+	if err != nil {
+		return err
+	}
+	if (perm & acl.Share) != 0 {
+		return nil
+	}
+	return domcom.NewUserACLCheckFailedError()
+}
+
 // getEntries gets acl entries, optimized to get a multiple records
 func (r *ACLRepository) getEntries(
 	ctx context.Context,
@@ -295,7 +371,7 @@ func (r *ACLRepository) getEntries(
 		}
 	}
 
-	perm := 0b11
+	perm := acl.Read | acl.Write | acl.Share
 	rpipe := r.rctx.Pipeline()
 	defer func() {
 		// Keeping the entire LRU cache alive if it's being used, if nmt used for
@@ -501,13 +577,13 @@ const (
 	`
 
 	DeleteACLEntryQuery = `
-  DELETE acl
+  DELETE FROM acl
   WHERE user_type = $1 AND user_id = $2 AND stream = $3 AND stream_id = $4
   RETURNING *
 	`
 
 	DeleteACLEntriesQuery = `
-  DELETE acl
+  DELETE FROM acl
   WHERE stream = $1 AND stream_id = $2
   RETURNING *
 	`
@@ -4,7 +4,6 @@ import (
 	"context"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
 	"testing"
-	"time"
 
 	"github.com/bwmarrin/snowflake"
 	"github.com/go-redis/redis/v8"
@@ -49,10 +48,7 @@ func TestCreateACLEntry(t *testing.T) {
 
 	id := sf.Generate().String()
 
-	ctx1 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx1 := ctxf.Create("")
 	err = r.CreateACLEntry(
 		ctx1,
 		id,
@@ -67,10 +63,7 @@ func TestCreateACLEntry(t *testing.T) {
 	}
 	ctx1.RollbackTransaction()
 
-	ctxr := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctxr := ctxf.Create("")
 
 	err = r.CanRead(
 		ctxr,
@@ -84,10 +77,7 @@ func TestCreateACLEntry(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx2 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx2 := ctxf.Create("")
 	err = r.CreateACLEntry(
 		ctx2,
 		id,
@@ -151,10 +141,7 @@ func TestCreateACLEntry(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx3 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx3 := ctxf.Create("")
 	err = r.CreateACLEntry(
 		ctx3,
 		id,
@@ -205,10 +192,7 @@ func TestCreateACLEntry(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx4 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx4 := ctxf.Create("")
 	err = r.CreateACLEntry(
 		ctx4,
 		id,
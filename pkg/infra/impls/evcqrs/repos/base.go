@@ -3,11 +3,15 @@ package repos
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
 
 	"github.com/BetaLixT/tsqlx"
+	"github.com/lib/pq"
 )
 
 // BaseDataRepository is the base repository containing common database
@@ -50,7 +54,31 @@ func (r *BaseDataRepository) insertEvent(
 		tid,
 		rid,
 		data,
+		entities.Upcasters.CurrentVersion(stream),
+	)
+	if err != nil {
+		if isUniqueViolation(err, eventVersionConstraint) {
+			return domcom.NewVersionConflictError(
+				"the version was written by a concurrent command",
+			)
+		}
+		return err
+	}
+	err = out.Decode()
+	if err != nil {
+		return err
+	}
+
+	// the outbox entry is written in the same transaction as the event so that
+	// every committed event is guaranteed to be picked up by the relay
+	_, err = trctx.Exec(
+		ctx,
+		insertOutboxQuery,
+		out.GetID(),
 	)
+	if err == nil {
+		err = r.recordSagaStep(ctx, trctx, sagaID, out.GetID())
+	}
 	if err == nil {
 		ctx.RegisterEvent(
 			out.GetID(),
@@ -66,6 +94,40 @@ func (r *BaseDataRepository) insertEvent(
 	return err
 }
 
+// recordSagaStep records a write made under a saga in the saga's state, writes
+// under sagas that have been compensated are rejected
+func (r *BaseDataRepository) recordSagaStep(
+	ctx context.Context,
+	trctx *tsqlx.TracedTx,
+	sagaID *string,
+	eventID uint64,
+) error {
+	if sagaID == nil {
+		return nil
+	}
+	saga := entities.Saga{}
+	err := trctx.Get(
+		ctx,
+		&saga,
+		upsertSagaStepQuery,
+		*sagaID,
+		eventID,
+	)
+	if err == sql.ErrNoRows {
+		return domcom.NewSagaCompensatedError()
+	}
+	return err
+}
+
+// isUniqueViolation checks if the error is a violation of the given unique
+// constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pqerr *pq.Error
+	return errors.As(err, &pqerr) &&
+		pqerr.Code == uniqueViolationErrorCode &&
+		pqerr.Constraint == constraint
+}
+
 func (r *BaseDataRepository) getDBTx(
 	ctx cntxt.IContext,
 ) (*tsqlx.TracedTx, error) {
@@ -104,6 +166,11 @@ func GetValueOrDefault[v comparable](in *v) (out v) {
 }
 
 const (
+	// postgres error code and the constraints that are mapped to domain errors
+	uniqueViolationErrorCode = "23505"
+	eventVersionConstraint   = "source_unique"
+	uniqueValueConstraint    = "uniques_value_unique"
+
 	insertEventQuery = `
 	INSERT INTO events(
 		saga_id,
@@ -113,8 +180,31 @@ const (
 		event,
 		trace_id,
 		request_id,
-		data
+		data,
+		schema_version
 	) VALUES(
-		$1, $2, $3, $4, $5, $6, $7, $8
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
 	) RETURNING *`
+
+	insertOutboxQuery = `
+	INSERT INTO outbox(
+		event_id
+	) VALUES(
+		$1
+	)`
+
+	upsertSagaStepQuery = `
+	INSERT INTO sagas(
+		saga_id,
+		status,
+		step_count,
+		last_event_id
+	) VALUES(
+		$1, 'active', 1, $2
+	)
+	ON CONFLICT (saga_id) DO UPDATE SET
+		step_count = sagas.step_count + 1,
+		last_event_id = GREATEST(sagas.last_event_id, $2)
+	WHERE sagas.status = 'active'
+	RETURNING *`
 )
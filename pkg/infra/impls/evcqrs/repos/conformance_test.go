@@ -0,0 +1,42 @@
+//go:build postgres
+
+package repos
+
+import (
+	"context"
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/conformance"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// TestConformance runs the conformance suite against the local Postgres and
+// Redis instances, run with go test -tags postgres
+func TestConformance(t *testing.T) {
+	ctxf, lgrf, dbctx, err := createDependenciesAndMigrate()
+	if err != nil {
+		t.Fatalf("failed to create dependencies: %v", err)
+	}
+
+	rdb := redis.NewClient(
+		&redis.Options{
+			Addr: "127.0.0.1:6379",
+			DB:   0,
+		},
+	)
+	err = rdb.Ping(context.Background()).Err()
+	if err != nil {
+		t.Fatalf("failed creating redis connection: %v", err)
+	}
+
+	base := NewBaseDataRepository(dbctx)
+	conformance.Run(t, &conformance.Subject{
+		Contexts: ctxf,
+		Tasks:    NewTasksRepository(base, lgrf, &SnapshotOptions{}),
+		Quotes:   NewQuotesRepository(dbctx, lgrf),
+		ACL:      NewACLRepository(base, rdb, lgrf),
+		Uniques:  NewUniquesRepository(base, lgrf),
+		Foreigns: NewForeignsRepository(base, lgrf),
+	})
+}
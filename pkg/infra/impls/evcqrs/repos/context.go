@@ -6,7 +6,9 @@ import (
 	domcntxt "techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	infrcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
 	implcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/outbox"
 	"sync"
 	"time"
 
@@ -32,6 +34,8 @@ type internalContext struct {
 	err       error
 	done      chan struct{}
 	dur       time.Time
+	values    map[any]any
+	valmtx    *sync.RWMutex
 
 	// - transaction
 	rtr                 retrier.Retrier
@@ -42,6 +46,8 @@ type internalContext struct {
 	isCommited          bool
 	isRolledback        bool
 	txmtx               *sync.Mutex
+	rly                 *outbox.Relay
+	hub                 *eventhub.Hub
 
 	// trace
 	ver string
@@ -80,7 +86,15 @@ func (c *internalContext) Err() error {
 }
 
 func (c *internalContext) Value(key any) any {
-	return nil
+	c.valmtx.RLock()
+	defer c.valmtx.RUnlock()
+	return c.values[key]
+}
+
+func (c *internalContext) SetValue(key, value any) {
+	c.valmtx.Lock()
+	defer c.valmtx.Unlock()
+	c.values[key] = value
 }
 
 // - Transaction functions
@@ -103,32 +117,39 @@ func (c *internalContext) CommitTransaction() error {
 		)
 	}
 	ctx := newMinimalContext(c)
-	for range c.events {
-		// TODO Event handling
-		// err := c.ndisp.DispatchEventNotification(
-		// 	ctx,
-		// 	evnt.stream,
-		// 	evnt.streamId,
-		// 	evnt.event,
-		// 	evnt.version,
-		// 	evnt.data,
-		// 	evnt.eventTime,
-		// )
-		// if err != nil {
-		// 	return err
-		// }
-	}
 	for _, commit := range c.commitActions {
 		err := commit(ctx)
 		if err != nil {
 			return err
 		}
 	}
-	// TODO: confirm event
 	c.isCommited = true
+
+	// events are persisted to the outbox along with the transaction, the relay
+	// is only notified so they get dispatched without waiting for a poll
+	if len(c.events) != 0 && c.rly != nil {
+		c.rly.Notify()
+	}
+	if c.hub != nil {
+		for _, stream := range c.committedStreams() {
+			c.hub.Publish(stream)
+		}
+	}
 	return nil
 }
 
+func (c *internalContext) committedStreams() []string {
+	streams := []string{}
+	seen := map[string]struct{}{}
+	for idx := range c.events {
+		if _, ok := seen[c.events[idx].stream]; !ok {
+			seen[c.events[idx].stream] = struct{}{}
+			streams = append(streams, c.events[idx].stream)
+		}
+	}
+	return streams
+}
+
 // TODO: better handling failed rollback transaction
 func (c *internalContext) RollbackTransaction() {
 	c.txmtx.Lock()
@@ -188,6 +209,7 @@ func (c *internalContext) RegisterEvent(
 	c.events = append(c.events, dispatchableEvent{
 		stream:    stream,
 		streamID:  streamID,
+		sagaID:    sagaID,
 		event:     event,
 		version:   int(version),
 		eventTime: eventTime,
@@ -8,8 +8,10 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
 	domcntxt "techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
 	implcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/outbox"
 	"regexp"
 	"strings"
 	"sync"
@@ -28,14 +30,20 @@ var _ cntxt.IFactory = (*ContextFactory)(nil)
 // ContextFactory to create new contexts
 type ContextFactory struct {
 	lgrf logger.IFactory
+	rly  *outbox.Relay
+	hub  *eventhub.Hub
 }
 
 // NewContextFactory constructor for context factory
 func NewContextFactory(
 	lgrf logger.IFactory,
+	rly *outbox.Relay,
+	hub *eventhub.Hub,
 ) *ContextFactory {
 	return &ContextFactory{
 		lgrf: lgrf,
+		rly:  rly,
+		hub:  hub,
 	}
 }
 
@@ -54,6 +62,8 @@ func (f *ContextFactory) Create(
 		cancelmtx: &sync.Mutex{},
 		err:       nil,
 		done:      make(chan struct{}, 1),
+		values:    map[any]any{},
+		valmtx:    &sync.RWMutex{},
 
 		rtr: *retrier.New(retrier.ExponentialBackoff(
 			5,
@@ -68,6 +78,8 @@ func (f *ContextFactory) Create(
 		isCommited:          false,
 		isRolledback:        false,
 		txmtx:               &sync.Mutex{},
+		rly:                 f.rly,
+		hub:                 f.hub,
 		ver:                 ver,
 		tid:                 tid,
 		pid:                 pid,
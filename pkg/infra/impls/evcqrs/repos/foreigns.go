@@ -59,8 +59,43 @@ func (r *ForeignsRepository) RegisterForeignItem(
 	)
 	if err != nil {
 		lgr.Error("failed to insert foreign item", zap.Error(err))
+		return err
 	}
-	return err
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
+}
+
+func (r *ForeignsRepository) EnsureForeignItem(
+	c context.Context,
+	sagaId *string,
+	foreignStream string,
+	foreignStreamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		EnsureForeignItemQuery,
+		foreignStream,
+		foreignStreamId,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to insert foreign item", zap.Error(err))
+		return err
+	}
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
 }
 
 func (r *ForeignsRepository) RemoveForeignItem(
@@ -131,8 +166,9 @@ func (r *ForeignsRepository) RegisterConstraint(
 	)
 	if err != nil {
 		lgr.Error("failed to insert foreign constraint", zap.Error(err))
+		return err
 	}
-	return err
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
 }
 
 func (r *ForeignsRepository) RemoveConstraint(
@@ -172,6 +208,45 @@ func (r *ForeignsRepository) RemoveConstraint(
 	return err
 }
 
+func (r *ForeignsRepository) RemoveSagaEntries(
+	c context.Context,
+	sagaId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	// constraints are removed first as they reference the foreign items
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaForeignConstraintsQuery,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to remove saga foreign constraints", zap.Error(err))
+		return err
+	}
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaForeignItemsQuery,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to remove saga foreign items", zap.Error(err))
+	}
+	return err
+}
+
 func (r *ForeignsRepository) ListAssociatedObjects(
 	c context.Context,
 	foreignStream string,
@@ -219,6 +294,18 @@ const (
 	) RETURNING *
 	`
 
+	// EnsureForeignItemQuery leaves an existing item as is, a concurrent
+	// insert waits for the other transaction instead of failing
+	EnsureForeignItemQuery = `
+	INSERT INTO foreigns(
+		stream,
+		stream_id,
+		saga_id
+	) VALUES(
+		$1, $2, $3
+	) ON CONFLICT DO NOTHING
+	`
+
 	DeleteForeignItemQuery = `
 	DELETE FROM foreigns
 	WHERE stream = $1 AND stream_id = $2
@@ -239,13 +326,23 @@ const (
 
 	DeleteForeignConstraintQuery = `
 	DELETE FROM foreign_constraints
-	WHERE foreign_stream = $1 AND foreign_stream = $2
+	WHERE foreign_stream = $1 AND foreign_stream_id = $2
 	 AND stream = $3 AND stream_id = $4
 	RETURNING *
 	`
 
+	DeleteSagaForeignConstraintsQuery = `
+	DELETE FROM foreign_constraints
+	WHERE saga_id = $1
+	`
+
+	DeleteSagaForeignItemsQuery = `
+	DELETE FROM foreigns
+	WHERE saga_id = $1
+	`
+
 	ListAssociatedObjectsQuery = `
-	SELECT stream, stream_id FROM foreign_constraints 
-	WHERE foreign_stream = $1 AND foreign_stream = $2
+	SELECT stream, stream_id FROM foreign_constraints
+	WHERE foreign_stream = $1 AND foreign_stream_id = $2
 	`
 )
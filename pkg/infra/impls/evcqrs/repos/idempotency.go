@@ -0,0 +1,153 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// IdempotencyOptions how long the results of commands are kept for replaying
+type IdempotencyOptions struct {
+	Expiry time.Duration
+}
+
+// IdempotencyRepository repository implementation for idempotency keys, the
+// results are written with the transaction of the command so a key is only
+// stored if the changes of the command are
+type IdempotencyRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+	opts *IdempotencyOptions
+}
+
+var _ idempotency.IRepository = (*IdempotencyRepository)(nil)
+
+// NewIdempotencyRepository creates new IdempotencyRepository
+func NewIdempotencyRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+	opts *IdempotencyOptions,
+) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+		opts:               opts,
+	}
+}
+
+// Get fetches the unexpired record of a key used by a user
+func (r *IdempotencyRepository) Get(
+	ctx context.Context,
+	userType string,
+	userID string,
+	key string,
+) (*idempotency.Record, error) {
+	rec := entities.IdempotencyKey{}
+	err := r.dbctx.Get(
+		ctx,
+		&rec,
+		SelectIdempotencyKeyQuery,
+		userType,
+		userID,
+		key,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rec.ToDTO(), nil
+}
+
+// Save stores the result of a command, expired keys of the user are cleared
+// out so that they can be reused
+func (r *IdempotencyRepository) Save(
+	c context.Context,
+	record idempotency.Record,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteExpiredIdempotencyKeysQuery,
+		record.UserType,
+		record.UserId,
+	)
+	if err != nil {
+		lgr.Error("failed to delete expired idempotency keys", zap.Error(err))
+		return err
+	}
+
+	rec := entities.IdempotencyKey{}
+	err = dbtx.Get(
+		ctx,
+		&rec,
+		InsertIdempotencyKeyQuery,
+		record.UserType,
+		record.UserId,
+		record.Key,
+		record.Command,
+		record.Fingerprint,
+		record.Result,
+		r.opts.Expiry.Seconds(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domcom.NewIdempotencyKeyInUseError()
+		}
+		lgr.Error("failed to insert idempotency key", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// - Queries
+const (
+	SelectIdempotencyKeyQuery = `
+	SELECT * FROM idempotency_keys
+	WHERE user_type = $1 AND user_id = $2 AND idempotency_key = $3
+		AND date_time_expires > NOW()
+	`
+
+	DeleteExpiredIdempotencyKeysQuery = `
+	DELETE FROM idempotency_keys
+	WHERE user_type = $1 AND user_id = $2 AND date_time_expires <= NOW()
+	`
+
+	InsertIdempotencyKeyQuery = `
+	INSERT INTO idempotency_keys (
+		user_type,
+		user_id,
+		idempotency_key,
+		command,
+		fingerprint,
+		result,
+		date_time_expires
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, NOW() + $7 * INTERVAL '1 second'
+	)
+	ON CONFLICT (user_type, user_id, idempotency_key) DO NOTHING
+	RETURNING *
+	`
+)
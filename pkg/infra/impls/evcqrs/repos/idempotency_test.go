@@ -0,0 +1,78 @@
+package repos
+
+import (
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctxf, lgrf, dbctx, err := createDependenciesAndMigrate()
+	if err != nil {
+		println("failed to create dependencies")
+		t.SkipNow()
+	}
+
+	r := NewIdempotencyRepository(
+		NewBaseDataRepository(dbctx),
+		lgrf,
+		&IdempotencyOptions{Expiry: time.Hour},
+	)
+
+	ctx := ctxf.Create("")
+	lgr := lgrf.Create(ctx)
+	sf, err := snowflake.NewNode(1)
+	if err != nil {
+		lgr.Error("failed to create snowflake", zap.Error(err))
+	}
+
+	rec := idempotency.Record{
+		UserType:    "user",
+		UserId:      sf.Generate().String(),
+		Key:         sf.Generate().String(),
+		Command:     "tasks.CreateTaskCommand",
+		Fingerprint: "fingerprint",
+		Result:      []byte("result"),
+	}
+	err = r.Save(ctx, rec)
+	if err != nil {
+		lgr.Error("failed to save key", zap.Error(err))
+		t.FailNow()
+	}
+
+	// keys aren't visible until the transaction of the command is commited
+	stored, err := r.Get(ctx, rec.UserType, rec.UserId, rec.Key)
+	if err != nil || stored != nil {
+		println("uncommited key was visible")
+		t.FailNow()
+	}
+	err = ctx.CommitTransaction()
+	if err != nil {
+		lgr.Error("failed to commit transaction", zap.Error(err))
+		t.FailNow()
+	}
+
+	stored, err = r.Get(ctx, rec.UserType, rec.UserId, rec.Key)
+	if err != nil || stored == nil {
+		println("failed to get key")
+		t.FailNow()
+	}
+	if string(stored.Result) != "result" ||
+		stored.Fingerprint != rec.Fingerprint ||
+		!stored.DateTimeExpires.After(stored.DateTimeCreated) {
+		println("invalid stored key")
+		t.FailNow()
+	}
+
+	ctx = ctxf.Create("")
+	err = r.Save(ctx, rec)
+	ctx.RollbackTransaction()
+	if !common.IsError(err, common.IdempotencyKeyInUseErrorCode) {
+		println("key was saved twice")
+		t.FailNow()
+	}
+}
@@ -94,6 +94,60 @@ func (r *QuotesRepository) Create(
 	return ev.ToDTO(), nil
 }
 
+// Delete deletes an existing quote
+func (r *QuotesRepository) Delete(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+) (*quotes.QuoteEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var ev entities.QuoteEvent
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&ev,
+		sagaID,
+		domcom.QuoteStreamName,
+		id,
+		version,
+		domcom.EventDeleted,
+		&entities.QuoteData{},
+	)
+	if err != nil {
+		lgr.Error("failed to insert delete event", zap.Error(err))
+		return nil, err
+	}
+
+	var res entities.QuoteReadModel
+	err = dbtx.Get(
+		ctx,
+		&res,
+		DeleteQuoteReadModelQuery,
+		id,
+		version-1,
+	)
+	if err != nil {
+		lgr.Error("failed to delete quote read model", zap.Error(err))
+		return nil, err
+	}
+
+	return ev.ToDTO(), nil
+}
+
 // GetRandom Fetch a random quote
 func (r *QuotesRepository) GetRandom(
 	ctx context.Context,
@@ -115,6 +169,10 @@ const (
 	) RETURNING *
 	`
 
+	DeleteQuoteReadModelQuery = `
+	DELETE FROM quotes WHERE id = $1 AND version = $2 RETURNING *
+	`
+
 	GetQuoteCountQuery = `
 	SELECT COUNT(id) FROM quotes
 	`
@@ -0,0 +1,134 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"go.uber.org/zap"
+)
+
+// SagasRepository repository implementation for saga state, the state itself
+// is recorded by the base repository as entities are written under a saga
+type SagasRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+}
+
+var _ sagas.IRepository = (*SagasRepository)(nil)
+
+// NewSagasRepository creates new SagasRepository
+func NewSagasRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+) *SagasRepository {
+	return &SagasRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+	}
+}
+
+// Get fetches the state of a saga
+func (r *SagasRepository) Get(
+	ctx context.Context,
+	sagaID string,
+) (*sagas.Saga, error) {
+	saga := entities.Saga{}
+	err := r.dbctx.Get(
+		ctx,
+		&saga,
+		SelectSagaQuery,
+		sagaID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domcom.NewSagaMissingError()
+		}
+		return nil, err
+	}
+	return saga.ToDTO(), nil
+}
+
+// ListEvents lists all events written under a saga ordered by event id
+func (r *SagasRepository) ListEvents(
+	ctx context.Context,
+	sagaID string,
+) ([]events.EventEntity, error) {
+	evnts := []entities.RawEvent{}
+	err := r.dbctx.Select(
+		ctx,
+		&evnts,
+		ListSagaEventsQuery,
+		sagaID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]events.EventEntity, len(evnts))
+	for idx := range evnts {
+		dtos[idx] = *evnts[idx].ToDTO()
+	}
+	return dtos, nil
+}
+
+// MarkCompensated marks an active saga as compensated
+func (r *SagasRepository) MarkCompensated(
+	c context.Context,
+	sagaID string,
+) (*sagas.Saga, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	saga := entities.Saga{}
+	err = dbtx.Get(
+		ctx,
+		&saga,
+		MarkSagaCompensatedQuery,
+		sagaID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domcom.NewSagaCompensatedError()
+		}
+		lgr.Error("failed to mark saga compensated", zap.Error(err))
+		return nil, err
+	}
+	return saga.ToDTO(), nil
+}
+
+// - Queries
+const (
+	SelectSagaQuery = `
+	SELECT * FROM sagas WHERE saga_id = $1
+	`
+
+	ListSagaEventsQuery = `
+	SELECT * FROM events WHERE saga_id = $1 ORDER BY id
+	`
+
+	MarkSagaCompensatedQuery = `
+	UPDATE sagas SET
+		status = 'compensated',
+		date_time_compensated = NOW()
+	WHERE saga_id = $1 AND status = 'active'
+	RETURNING *
+	`
+)
@@ -0,0 +1,152 @@
+package repos
+
+import (
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"testing"
+
+	"github.com/bwmarrin/snowflake"
+	"go.uber.org/zap"
+)
+
+func TestSagaCompensation(t *testing.T) {
+	ctxf, lgrf, dbctx, err := createDependenciesAndMigrate()
+	if err != nil {
+		println("failed to create dependencies")
+		t.SkipNow()
+	}
+
+	base := NewBaseDataRepository(dbctx)
+	r := NewTasksRepository(
+		base,
+		lgrf,
+		&SnapshotOptions{},
+	)
+	sr := NewSagasRepository(base, lgrf)
+
+	ctx := ctxf.Create("")
+
+	lgr := lgrf.Create(ctx)
+	sf, err := snowflake.NewNode(1)
+	if err != nil {
+		lgr.Error("failed to create snowflake", zap.Error(err))
+	}
+
+	id := sf.Generate().String()
+	sagaID := sf.Generate().String()
+	_, err = r.Create(
+		ctx,
+		id,
+		nil,
+		tasks.TaskData{
+			Title:       Pointerify("original title"),
+			Description: Pointerify("description"),
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to create record", zap.Error(err))
+		t.FailNow()
+	}
+	_, err = r.Update(
+		ctx,
+		id,
+		&sagaID,
+		1,
+		tasks.TaskData{
+			Title: Pointerify("saga title"),
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to update record", zap.Error(err))
+		t.FailNow()
+	}
+	err = ctx.CommitTransaction()
+	if err != nil {
+		lgr.Error("failed to commit transaction", zap.Error(err))
+		t.FailNow()
+	}
+
+	saga, err := sr.Get(ctx, sagaID)
+	if err != nil {
+		lgr.Error("failed to get saga", zap.Error(err))
+		t.FailNow()
+	}
+	if saga.Status != common.SagaStatusActive || saga.StepCount != 1 {
+		println("invalid saga state")
+		t.FailNow()
+	}
+	evnts, err := sr.ListEvents(ctx, sagaID)
+	if err != nil {
+		lgr.Error("failed to list saga events", zap.Error(err))
+		t.FailNow()
+	}
+	if len(evnts) != 1 || evnts[0].StreamId != id || evnts[0].Version != 1 {
+		println("invalid saga events")
+		t.FailNow()
+	}
+
+	ctx = ctxf.Create("")
+	ev, err := r.Compensate(
+		ctx,
+		id,
+		&sagaID,
+		2,
+		tasks.TaskData{
+			Title:       Pointerify("original title"),
+			Description: Pointerify("description"),
+			Status:      Pointerify("PENDING"),
+			RandomMap:   map[string]string{},
+			Metadata:    map[string]interface{}{},
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to compensate record", zap.Error(err))
+		t.FailNow()
+	}
+	if ev.Event != common.EventCompensated {
+		println("invalid event")
+		t.FailNow()
+	}
+	saga, err = sr.MarkCompensated(ctx, sagaID)
+	if err != nil {
+		lgr.Error("failed to mark saga compensated", zap.Error(err))
+		t.FailNow()
+	}
+	if saga.Status != common.SagaStatusCompensated ||
+		saga.DateTimeCompensated == nil {
+		println("invalid saga state")
+		t.FailNow()
+	}
+	err = ctx.CommitTransaction()
+	if err != nil {
+		lgr.Error("failed to commit transaction", zap.Error(err))
+		t.FailNow()
+	}
+
+	task, err := r.Get(ctx, id)
+	if err != nil {
+		lgr.Error("failed to get record", zap.Error(err))
+		t.FailNow()
+	}
+	if task.Title != "original title" || task.Version != 2 {
+		println("task not restored")
+		t.FailNow()
+	}
+
+	// writes under a compensated saga are rejected
+	ctx = ctxf.Create("")
+	_, err = r.Update(
+		ctx,
+		id,
+		&sagaID,
+		3,
+		tasks.TaskData{
+			Title: Pointerify("late title"),
+		},
+	)
+	ctx.RollbackTransaction()
+	if !common.IsError(err, common.SagaCompensatedErrorCode) {
+		println("write under compensated saga was not rejected")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,42 @@
+package repos
+
+// SnapshotOptions number of events between snapshots keyed by stream name, a
+// stream with no interval (or zero) is never snapshotted
+type SnapshotOptions struct {
+	Intervals map[string]uint64
+}
+
+// isSnapshotDue checks if a snapshot should be taken at the version
+func (o *SnapshotOptions) isSnapshotDue(stream string, version uint64) bool {
+	if o == nil || version == 0 {
+		return false
+	}
+	intrvl := o.Intervals[stream]
+	return intrvl != 0 && version%intrvl == 0
+}
+
+// - Queries
+const (
+	SelectLatestSnapshotQuery = `
+	SELECT * FROM snapshots
+	WHERE stream = $1 AND stream_id = $2
+		AND ($3::bigint IS NULL OR version <= $3)
+		AND ($4::timestamptz IS NULL OR event_time <= $4)
+	ORDER BY version DESC LIMIT 1
+	`
+
+	InsertSnapshotQuery = `
+	INSERT INTO snapshots (
+		stream,
+		stream_id,
+		version,
+		event_id,
+		event_time,
+		stream_time_created,
+		data,
+		schema_version
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8
+	)
+	`
+)
@@ -4,6 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
@@ -11,6 +15,7 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
 
+	"github.com/BetaLixT/tsqlx"
 	"go.uber.org/zap"
 )
 
@@ -18,16 +23,19 @@ import (
 type TasksRepository struct {
 	*BaseDataRepository
 	lgrf logger.IFactory
+	snpo *SnapshotOptions
 }
 
 // NewTasksRepository creates new TasksRepository
 func NewTasksRepository(
 	base *BaseDataRepository,
 	lgrf logger.IFactory,
+	snpo *SnapshotOptions,
 ) *TasksRepository {
 	return &TasksRepository{
 		BaseDataRepository: base,
 		lgrf:               lgrf,
+		snpo:               snpo,
 	}
 }
 
@@ -86,6 +94,8 @@ func (r *TasksRepository) Create(
 		evnt.Version,
 		evnt.EventTime,
 		evnt.EventTime,
+		entities.DueDateValue(dat.DueDate),
+		GetValueOrDefault(data.ParentID),
 	)
 	if err != nil {
 		return nil, err
@@ -116,19 +126,23 @@ func (r *TasksRepository) Get(
 	return task.ToDTO()
 }
 
-// List gives a paged list of tasks
+// List gives a paged list of the tasks matching the options
 func (r *TasksRepository) List(
 	ctx context.Context,
+	opts tasks.ListOptions,
 	countPerPage int,
 	pageNumber int,
 ) ([]tasks.Task, error) {
+	from, where, order, vals := generateTasksFilter(&opts, 3)
 	var tasks []entities.TaskReadModel
 	err := r.dbctx.Select(
 		ctx,
 		&tasks,
-		ListTasksQuery,
-		countPerPage,
-		pageNumber*countPerPage,
+		fmt.Sprintf(ListTasksQuery, from, where, order),
+		append(
+			[]interface{}{countPerPage, pageNumber * countPerPage},
+			vals...,
+		)...,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -140,6 +154,23 @@ func (r *TasksRepository) List(
 	return ((*entities.TaskReadModel)(nil)).ToDTOSlice(tasks)
 }
 
+// Count counts the tasks matching the filters of the options
+func (r *TasksRepository) Count(
+	ctx context.Context,
+	opts tasks.ListOptions,
+) (uint64, error) {
+	opts.After = nil
+	from, where, _, vals := generateTasksFilter(&opts, 1)
+	var cnt uint64
+	err := r.dbctx.Get(
+		ctx,
+		&cnt,
+		fmt.Sprintf(CountTasksQuery, from, where),
+		vals...,
+	)
+	return cnt, err
+}
+
 // Delete deletes an existing task
 func (r *TasksRepository) Delete(
 	c context.Context,
@@ -189,9 +220,106 @@ func (r *TasksRepository) Delete(
 		return nil, err
 	}
 
+	_, err = dbtx.Exec(ctx, DeleteTaskDependenciesQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return evnt.ToDTO(), nil
 }
 
+// FireSchedule writes the event of a schedule of an existing task
+func (r *TasksRepository) FireSchedule(
+	c context.Context,
+	id string,
+	version uint64,
+	event string,
+	dueDate time.Time,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		nil,
+		version,
+		event,
+		tasks.TaskData{DueDate: &dueDate},
+	)
+}
+
+// SetSchedules replaces the schedules of a task
+func (r *TasksRepository) SetSchedules(
+	c context.Context,
+	id string,
+	schedules []tasks.Schedule,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(ctx, DeleteTaskSchedulesQuery, id)
+	if err != nil {
+		lgr.Error("failed to delete task schedules", zap.Error(err))
+		return err
+	}
+	for _, schd := range schedules {
+		_, err = dbtx.Exec(
+			ctx,
+			InsertTaskScheduleQuery,
+			id,
+			schd.Event,
+			schd.FireAt,
+		)
+		if err != nil {
+			lgr.Error("failed to insert task schedule", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimDueSchedule removes and gives the earliest due schedule, the row is
+// locked until the transaction ends and rows locked by other transactions are
+// skipped so concurrent schedulers never claim the same schedule
+func (r *TasksRepository) ClaimDueSchedule(
+	c context.Context,
+	now time.Time,
+) (*tasks.Schedule, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	schd := entities.TaskSchedule{}
+	err = dbtx.Get(ctx, &schd, ClaimDueTaskScheduleQuery, now)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		lgr.Error("failed to claim task schedule", zap.Error(err))
+		return nil, err
+	}
+	return schd.ToDTO(), nil
+}
+
 // Update updates an existing task
 func (r *TasksRepository) Update(
 	c context.Context,
@@ -199,6 +327,277 @@ func (r *TasksRepository) Update(
 	sagaID *string,
 	version uint64,
 	dat tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(c, id, sagaID, version, domcom.EventUpdated, dat)
+}
+
+// Compensate restores an existing task to the given data
+func (r *TasksRepository) Compensate(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	dat tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(c, id, sagaID, version, domcom.EventCompensated, dat)
+}
+
+// Transition writes the event of a workflow transition moving an existing
+// task to the status
+func (r *TasksRepository) Transition(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	status string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		event,
+		tasks.TaskData{Status: &status},
+	)
+}
+
+// Assign writes an event assigning an existing task to a user
+func (r *TasksRepository) Assign(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	assignee string,
+	access *tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventAssigned,
+		tasks.TaskData{Assignee: &assignee, Access: access},
+	)
+}
+
+// Move writes an event moving an existing task under another task
+func (r *TasksRepository) Move(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	parentID string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventMoved,
+		tasks.TaskData{ParentID: &parentID},
+	)
+}
+
+// LockHierarchy takes the advisory lock serializing the changes to the task
+// hierarchy, the lock is released along with the transaction
+func (r *TasksRepository) LockHierarchy(c context.Context) error {
+	return r.lock(c, TaskHierarchyLockKey)
+}
+
+// LockDependencies takes the advisory lock serializing the changes to the task
+// dependencies, the lock is released along with the transaction
+func (r *TasksRepository) LockDependencies(c context.Context) error {
+	return r.lock(c, TaskDependenciesLockKey)
+}
+
+// lock takes the advisory lock with the key in the transaction of the context,
+// waiting for the transaction holding it to end
+func (r *TasksRepository) lock(c context.Context, key int64) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(ctx, LockTasksQuery, key)
+	if err != nil {
+		lgr.Error("failed to take lock", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AddDependency writes an event blocking an existing task by another task
+func (r *TasksRepository) AddDependency(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	blockerID string,
+) (*tasks.TaskEvent, error) {
+	evnt, err := r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyAdded,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return evnt, r.execDependency(c, InsertTaskDependencyQuery, id, blockerID)
+}
+
+// RemoveDependency writes an event unblocking an existing task from another
+// task
+func (r *TasksRepository) RemoveDependency(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	blockerID string,
+) (*tasks.TaskEvent, error) {
+	evnt, err := r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyRemoved,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return evnt, r.execDependency(c, DeleteTaskDependencyQuery, id, blockerID)
+}
+
+func (r *TasksRepository) execDependency(
+	c context.Context,
+	qry string,
+	id string,
+	blockerID string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(ctx, qry, id, blockerID)
+	if err != nil {
+		lgr.Error("failed to write task dependency", zap.Error(err))
+	}
+	return err
+}
+
+// ListBlockers lists the ids of the tasks blocking a task ordered by id
+func (r *TasksRepository) ListBlockers(
+	c context.Context,
+	id string,
+) ([]string, error) {
+	return r.listDependencies(c, ListTaskBlockersQuery, id)
+}
+
+// ListBlocked lists the ids of the tasks blocked by a task ordered by id
+func (r *TasksRepository) ListBlocked(
+	c context.Context,
+	id string,
+) ([]string, error) {
+	return r.listDependencies(c, ListBlockedTasksQuery, id)
+}
+
+func (r *TasksRepository) listDependencies(
+	c context.Context,
+	qry string,
+	id string,
+) ([]string, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	ids := []string{}
+	err = dbtx.Select(ctx, &ids, qry, id)
+	if err != nil {
+		lgr.Error("failed to list task dependencies", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Share writes an event granting a user access to an existing task
+func (r *TasksRepository) Share(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventShared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// Unshare writes an event revoking the access of a user to an existing task
+func (r *TasksRepository) Unshare(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventUnshared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// update writes an event changing the fields of the task that are set, events
+// only changing the access to or the dependencies of the task just move the
+// version forward
+func (r *TasksRepository) update(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	dat tasks.TaskData,
 ) (*tasks.TaskEvent, error) {
 	lgr := r.lgrf.Create(c)
 
@@ -212,10 +611,14 @@ func (r *TasksRepository) Update(
 	data.FromDTO(&dat)
 
 	set, vals, _ := data.GeneratePSQLReadModelSet(4)
-	if set == "" {
+	if set == "" && data.Access == nil && data.BlockerId == nil {
 		lgr.Error("no values updated")
 		return nil, domcom.NewNoTaskUpdatesError()
 	}
+	qry := UpdateTaskVersionQuery
+	if set != "" {
+		qry = fmt.Sprintf(UpdateTaskQuery, set)
+	}
 
 	dbtx, err := r.getDBTx(ctx)
 	if err != nil {
@@ -232,7 +635,7 @@ func (r *TasksRepository) Update(
 		domcom.TaskStreamName,
 		id,
 		version,
-		domcom.EventUpdated,
+		event,
 		&data,
 	)
 	if err != nil {
@@ -245,7 +648,7 @@ func (r *TasksRepository) Update(
 	err = dbtx.Get(
 		ctx,
 		&dest,
-		fmt.Sprintf(UpdateTaskQuery, set),
+		qry,
 		allvals...,
 	)
 	if err != nil {
@@ -253,11 +656,379 @@ func (r *TasksRepository) Update(
 		return nil, err
 	}
 
+	if r.snpo.isSnapshotDue(domcom.TaskStreamName, version) {
+		err = r.insertSnapshot(ctx, dbtx, &evnt)
+		if err != nil {
+			lgr.Error("failed to snapshot task", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return evnt.ToDTO(), nil
 }
 
+// insertSnapshot folds the events since the previous snapshot (including the
+// event that was just inserted) and stores the resulting state, the read model
+// is not used so the snapshot can't carry over a faulty projection
+func (r *TasksRepository) insertSnapshot(
+	ctx context.Context,
+	dbtx *tsqlx.TracedTx,
+	evnt *entities.TaskEvent,
+) error {
+	state := entities.TaskData{}
+	var created time.Time
+	var from uint64
+
+	snap := entities.Snapshot{}
+	err := dbtx.Get(
+		ctx,
+		&snap,
+		SelectLatestSnapshotQuery,
+		domcom.TaskStreamName,
+		evnt.StreamID,
+		evnt.Version,
+		nil,
+	)
+	if err == nil {
+		err = snap.Upcast()
+		if err != nil {
+			return err
+		}
+		err = state.Scan(snap.Data)
+		if err != nil {
+			return err
+		}
+		created = snap.StreamTimeCreated
+		from = snap.Version + 1
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+
+	var evnts []entities.TaskEvent
+	err = dbtx.Select(
+		ctx,
+		&evnts,
+		ListTaskEventsUntilQuery,
+		domcom.TaskStreamName,
+		evnt.StreamID,
+		from,
+		evnt.Version,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	err = ((*entities.TaskEvent)(nil)).DecodeSlice(evnts)
+	if err != nil {
+		return err
+	}
+	for idx := range evnts {
+		if evnts[idx].Event == domcom.EventCreated {
+			created = evnts[idx].EventTime
+		}
+		state.Fold(&evnts[idx].Data)
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		InsertSnapshotQuery,
+		domcom.TaskStreamName,
+		evnt.StreamID,
+		evnt.Version,
+		evnt.ID,
+		evnt.EventTime,
+		created,
+		&state,
+		entities.TaskDataSchemaVersion,
+	)
+	return err
+}
+
+// GetSnapshot gets the task as it was at the latest snapshot taken at or
+// before the given version or event time
+func (r *TasksRepository) GetSnapshot(
+	ctx context.Context,
+	id string,
+	version *uint64,
+	eventTime *time.Time,
+) (*tasks.Task, error) {
+	var snap entities.TaskSnapshot
+	err := r.dbctx.Get(
+		ctx,
+		&snap,
+		SelectLatestSnapshotQuery,
+		domcom.TaskStreamName,
+		id,
+		version,
+		eventTime,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return snap.ToDTO()
+}
+
+// ListEvents gives a paged list of events of a task ordered by version
+func (r *TasksRepository) ListEvents(
+	ctx context.Context,
+	id string,
+	countPerPage int,
+	pageNumber int,
+) ([]tasks.TaskEvent, error) {
+	var evnts []entities.TaskEvent
+	err := r.dbctx.Select(
+		ctx,
+		&evnts,
+		ListTaskEventsQuery,
+		domcom.TaskStreamName,
+		id,
+		countPerPage,
+		pageNumber*countPerPage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = ((*entities.TaskEvent)(nil)).DecodeSlice(evnts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ((*entities.TaskEvent)(nil)).ToDTOSlice(evnts)
+}
+
+// ListEventsAfter lists the events of all tasks after an event id, event ids
+// are assigned before commit so the events are listed in the order of the
+// transactions writing them and only once every older transaction has ended,
+// an event committed late is never listed before the events it precedes
+func (r *TasksRepository) ListEventsAfter(
+	ctx context.Context,
+	afterID uint64,
+	count int,
+) ([]tasks.TaskEvent, error) {
+	var evnts []entities.TaskEvent
+	err := r.dbctx.Select(
+		ctx,
+		&evnts,
+		ListTaskEventsAfterQuery,
+		domcom.TaskStreamName,
+		afterID,
+		count,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = ((*entities.TaskEvent)(nil)).DecodeSlice(evnts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ((*entities.TaskEvent)(nil)).ToDTOSlice(evnts)
+}
+
+// ListEventsUntil lists the events of a task ordered by version starting from
+// a version up to and including the given version or event time
+func (r *TasksRepository) ListEventsUntil(
+	ctx context.Context,
+	id string,
+	fromVersion uint64,
+	version *uint64,
+	eventTime *time.Time,
+) ([]tasks.TaskEvent, error) {
+	var evnts []entities.TaskEvent
+	err := r.dbctx.Select(
+		ctx,
+		&evnts,
+		ListTaskEventsUntilQuery,
+		domcom.TaskStreamName,
+		id,
+		fromVersion,
+		version,
+		eventTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = ((*entities.TaskEvent)(nil)).DecodeSlice(evnts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ((*entities.TaskEvent)(nil)).ToDTOSlice(evnts)
+}
+
+// sortedKeys gives the keys of the map sorted so the generated queries are
+// stable
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// generateTasksFilter generates the from, where and order by clauses selecting
+// the tasks matching the options with numbered parameters starting from pbeg,
+// the id of the task is always the last sort column so that pages are stable
+// and the listing can continue after a task
+func generateTasksFilter(
+	opts *tasks.ListOptions,
+	pbeg int,
+) (string, string, string, []interface{}) {
+	conds := []string{}
+	vals := []interface{}{}
+	param := func(val interface{}) int {
+		vals = append(vals, val)
+		return pbeg + len(vals) - 1
+	}
+	add := func(cond string, val interface{}) {
+		conds = append(conds, fmt.Sprintf(cond, param(val)))
+	}
+
+	// the acl is joined so that only the tasks the reader can read are listed
+	from := "tasks"
+	if opts.Reader != nil {
+		from = fmt.Sprintf(
+			ListTasksACLJoin,
+			param(domcom.TaskStreamName),
+			param(opts.Reader.UserType),
+			param(opts.Reader.UserID),
+			param(acl.Read),
+		)
+	}
+	if opts.Status != nil {
+		add("tasks.status = $%d", *opts.Status)
+	}
+	if opts.Assignee != nil {
+		add("tasks.assignee = $%d", *opts.Assignee)
+	}
+	if opts.ParentID != nil {
+		add("tasks.parent_id = $%d", *opts.ParentID)
+	}
+	for _, key := range sortedKeys(opts.Metadata) {
+		conds = append(conds, fmt.Sprintf(
+			"jsonb_typeof(tasks.metadata -> $%d) IN ('string','number','boolean')"+
+				" AND tasks.metadata ->> $%d = $%d",
+			param(key),
+			param(key),
+			param(opts.Metadata[key]),
+		))
+	}
+	for _, key := range opts.MetadataKeys {
+		add("tasks.metadata -> $%d IS NOT NULL", key)
+	}
+	if opts.OverdueAt != nil {
+		add("tasks.due_date < $%d", *opts.OverdueAt)
+		if len(opts.TerminalStatuses) != 0 {
+			phs := make([]string, len(opts.TerminalStatuses))
+			for idx, status := range opts.TerminalStatuses {
+				phs[idx] = fmt.Sprintf("$%d", param(status))
+			}
+			conds = append(conds, fmt.Sprintf(
+				"tasks.status NOT IN (%s)",
+				strings.Join(phs, ","),
+			))
+		}
+	}
+	if opts.CreatedAfter != nil {
+		add("tasks.date_time_created >= $%d", *opts.CreatedAfter)
+	}
+	if opts.CreatedBefore != nil {
+		add("tasks.date_time_created < $%d", *opts.CreatedBefore)
+	}
+	if opts.UpdatedAfter != nil {
+		add("tasks.date_time_updated >= $%d", *opts.UpdatedAfter)
+	}
+	if opts.UpdatedBefore != nil {
+		add("tasks.date_time_updated < $%d", *opts.UpdatedBefore)
+	}
+	// the search is served by the trigram indexes of the title and description
+	if opts.Search != nil {
+		add(
+			"(tasks.title ILIKE $%[1]d ESCAPE '\\' OR "+
+				"tasks.description ILIKE $%[1]d ESCAPE '\\')",
+			"%"+escapeLikePattern(*opts.Search)+"%",
+		)
+	}
+
+	var col string
+	switch opts.SortBy {
+	case tasks.SortByDateTimeUpdated:
+		col = "tasks.date_time_updated"
+	case tasks.SortByTitle:
+		col = `tasks.title COLLATE "C"`
+	case tasks.SortByStatus:
+		col = "tasks.status"
+	default:
+		col = "tasks.date_time_created"
+	}
+	dir := "ASC"
+	if opts.Descending {
+		dir = "DESC"
+	}
+
+	if opts.After != nil {
+		var val interface{}
+		switch opts.SortBy {
+		case tasks.SortByDateTimeUpdated:
+			val = opts.After.DateTimeUpdated
+		case tasks.SortByTitle:
+			val = opts.After.Title
+		case tasks.SortByStatus:
+			val = opts.After.Status
+		default:
+			val = opts.After.DateTimeCreated
+		}
+		op := ">"
+		if opts.Descending {
+			op = "<"
+		}
+		conds = append(conds, fmt.Sprintf(
+			"(%s, tasks.id) %s ($%d, $%d)",
+			col,
+			op,
+			param(val),
+			param(opts.After.Id),
+		))
+	}
+
+	where := ""
+	if len(conds) != 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	return from, where, col + " " + dir + ", tasks.id " + dir, vals
+}
+
+// escapeLikePattern escapes the wildcards of a like pattern so the text is
+// matched as is
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"%", `\%`,
+		"_", `\_`,
+	).Replace(text)
+}
+
+// Advisory lock keys serializing the changes to the task graphs, the keys
+// follow the key of the outbox lock
+const (
+	TaskHierarchyLockKey    = 7_010_002
+	TaskDependenciesLockKey = 7_010_003
+)
+
 // - Queries
 const (
+	// the lock is released with the transaction so a command that fails
+	// doesn't keep it
+	LockTasksQuery = `
+	SELECT pg_advisory_xact_lock($1)
+	`
+
 	InsertTaskReadModelQuery = `
 	INSERT INTO tasks (
 		id,
@@ -268,9 +1039,11 @@ const (
 		metadata,
 		version,
 		date_time_created,
-		date_time_updated
+		date_time_updated,
+		due_date,
+		parent_id
 	) VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 	) RETURNING *
 	`
 
@@ -283,10 +1056,93 @@ const (
 	`
 
 	ListTasksQuery = `
-	SELECT * FROM tasks LIMIT $1 OFFSET $2
+	SELECT tasks.* FROM %s %s ORDER BY %s LIMIT $1 OFFSET $2
+	`
+
+	CountTasksQuery = `
+	SELECT COUNT(*) FROM %s %s
+	`
+
+	ListTasksACLJoin = `tasks JOIN acl ON acl.stream = $%d
+		AND acl.stream_id = tasks.id
+		AND acl.user_type = $%d AND acl.user_id = $%d
+		AND (acl.permissions & $%d) != 0`
+
+	ListTaskEventsQuery = `
+	SELECT * FROM events
+	WHERE stream = $1 AND stream_id = $2
+	ORDER BY version LIMIT $3 OFFSET $4
+	`
+
+	// the listing continues after the position of the given event, the event
+	// ids are compared directly when it doesn't exist
+	ListTaskEventsAfterQuery = `
+	SELECT e.* FROM events e
+	LEFT JOIN events c ON c.id = $2
+	WHERE e.stream = $1
+		AND e.transaction_id < pg_snapshot_xmin(pg_current_snapshot())
+		AND (
+			(c.id IS NULL AND e.id > $2)
+			OR e.transaction_id > c.transaction_id
+			OR (e.transaction_id = c.transaction_id AND e.id > c.id)
+		)
+	ORDER BY e.transaction_id, e.id
+	LIMIT $3
+	`
+
+	ListTaskEventsUntilQuery = `
+	SELECT * FROM events
+	WHERE stream = $1 AND stream_id = $2 AND version >= $3
+		AND ($4::bigint IS NULL OR version <= $4)
+		AND ($5::timestamptz IS NULL OR event_time <= $5)
+	ORDER BY version
 	`
 
 	UpdateTaskQuery = `
 	UPDATE tasks SET %s, version = $3 WHERE id = $1 AND version = $2 RETURNING *
 	`
+
+	UpdateTaskVersionQuery = `
+	UPDATE tasks SET version = $3 WHERE id = $1 AND version = $2 RETURNING *
+	`
+
+	InsertTaskDependencyQuery = `
+	INSERT INTO task_dependencies (stream_id, blocker_id) VALUES ($1, $2)
+	`
+
+	DeleteTaskDependencyQuery = `
+	DELETE FROM task_dependencies WHERE stream_id = $1 AND blocker_id = $2
+	`
+
+	DeleteTaskDependenciesQuery = `
+	DELETE FROM task_dependencies WHERE stream_id = $1 OR blocker_id = $1
+	`
+
+	ListTaskBlockersQuery = `
+	SELECT blocker_id FROM task_dependencies
+	WHERE stream_id = $1 ORDER BY blocker_id
+	`
+
+	ListBlockedTasksQuery = `
+	SELECT stream_id FROM task_dependencies
+	WHERE blocker_id = $1 ORDER BY stream_id
+	`
+
+	DeleteTaskSchedulesQuery = `
+	DELETE FROM task_schedules WHERE stream_id = $1
+	`
+
+	InsertTaskScheduleQuery = `
+	INSERT INTO task_schedules (stream_id, event, fire_at) VALUES ($1, $2, $3)
+	`
+
+	ClaimDueTaskScheduleQuery = `
+	DELETE FROM task_schedules WHERE (stream_id, event) IN (
+		SELECT stream_id, event FROM task_schedules
+		WHERE fire_at <= $1
+		ORDER BY fire_at, stream_id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	) RETURNING stream_id, event, fire_at
+	`
 )
@@ -7,6 +7,7 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+	"reflect"
 	"testing"
 	"time"
 
@@ -77,13 +78,11 @@ func createDependenciesAndMigrate() (
 
 	ctxf := NewContextFactory(
 		lgrf,
-		NewTraceRepository(lgrf),
+		nil,
+		nil,
 	)
 
-	ctx := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx := ctxf.Create("")
 	err = psqldb.RunMigrations(
 		ctx,
 		lgr,
@@ -109,12 +108,10 @@ func TestCreateDelete(t *testing.T) {
 	r := NewTasksRepository(
 		base,
 		lgrf,
+		&SnapshotOptions{},
 	)
 
-	ctx := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx := ctxf.Create("")
 
 	lgr := lgrf.Create(ctx)
 	sf, err := snowflake.NewNode(1)
@@ -177,12 +174,10 @@ func TestCreateUpdate(t *testing.T) {
 	r := NewTasksRepository(
 		base,
 		lgrf,
+		&SnapshotOptions{},
 	)
 
-	ctx := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx := ctxf.Create("")
 
 	lgr := lgrf.Create(ctx)
 	sf, err := snowflake.NewNode(1)
@@ -211,10 +206,7 @@ func TestCreateUpdate(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx2 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx2 := ctxf.Create("")
 
 	ev, err := r.Update(
 		ctx2,
@@ -240,10 +232,7 @@ func TestCreateUpdate(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx3 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx3 := ctxf.Create("")
 	v, err := r.Get(ctx3, id)
 	if err != nil {
 		lgr.Error("failed to get task")
@@ -271,12 +260,10 @@ func TestFullUpdate(t *testing.T) {
 	r := NewTasksRepository(
 		base,
 		lgrf,
+		&SnapshotOptions{},
 	)
 
-	ctx := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx := ctxf.Create("")
 
 	lgr := lgrf.Create(ctx)
 	sf, err := snowflake.NewNode(1)
@@ -305,10 +292,7 @@ func TestFullUpdate(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx2 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx2 := ctxf.Create("")
 
 	_, err = r.Update(
 		ctx2,
@@ -333,10 +317,7 @@ func TestFullUpdate(t *testing.T) {
 		t.FailNow()
 	}
 
-	ctx3 := ctxf.Create(
-		context.Background(),
-		time.Minute*2,
-	)
+	ctx3 := ctxf.Create("")
 	v, err := r.Get(ctx3, id)
 	if err != nil {
 		lgr.Error("failed to get task")
@@ -366,3 +347,144 @@ func TestFullUpdate(t *testing.T) {
 }
 
 func Pointerify[x any](val x) *x { return &val }
+
+func TestConflictingVersion(t *testing.T) {
+	ctxf, lgrf, dbctx, err := createDependenciesAndMigrate()
+	if err != nil {
+		println("failed to create dependencies")
+		t.SkipNow()
+	}
+
+	base := NewBaseDataRepository(dbctx)
+	r := NewTasksRepository(
+		base,
+		lgrf,
+		&SnapshotOptions{},
+	)
+
+	ctx := ctxf.Create("")
+
+	lgr := lgrf.Create(ctx)
+	sf, err := snowflake.NewNode(1)
+	if err != nil {
+		lgr.Error("failed to create snowflake", zap.Error(err))
+	}
+
+	id := sf.Generate().String()
+	_, err = r.Create(
+		ctx,
+		id,
+		nil,
+		tasks.TaskData{
+			Title:       Pointerify("original title"),
+			Description: Pointerify("description"),
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to create record", zap.Error(err))
+		t.FailNow()
+	}
+	_, err = r.Update(
+		ctx,
+		id,
+		nil,
+		1,
+		tasks.TaskData{
+			Title: Pointerify("first title"),
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to update record", zap.Error(err))
+		t.FailNow()
+	}
+	err = ctx.CommitTransaction()
+	if err != nil {
+		lgr.Error("failed commit transaction record", zap.Error(err))
+		t.FailNow()
+	}
+
+	// a second writer that read the same version of the task
+	ctx2 := ctxf.Create("")
+	_, err = r.Update(
+		ctx2,
+		id,
+		nil,
+		1,
+		tasks.TaskData{
+			Title: Pointerify("second title"),
+		},
+	)
+	ctx2.RollbackTransaction()
+	if !common.IsError(err, common.VersionConflictErrorCode) {
+		lgr.Error("conflicting version was not rejected", zap.Error(err))
+		t.FailNow()
+	}
+}
+
+func TestGenerateTasksFilterMetadata(t *testing.T) {
+	due := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		opts  tasks.ListOptions
+		pbeg  int
+		where string
+		vals  []interface{}
+	}{
+		{name: "none", pbeg: 1},
+		{
+			name: "values sorted by key",
+			opts: tasks.ListOptions{
+				Metadata: map[string]string{"b": "2", "a": "x"},
+			},
+			pbeg: 1,
+			where: "WHERE jsonb_typeof(tasks.metadata -> $1) IN " +
+				"('string','number','boolean') AND tasks.metadata ->> $2 = $3" +
+				" AND jsonb_typeof(tasks.metadata -> $4) IN " +
+				"('string','number','boolean') AND tasks.metadata ->> $5 = $6",
+			vals: []interface{}{"a", "a", "x", "b", "b", "2"},
+		},
+		{
+			name: "keys",
+			opts: tasks.ListOptions{MetadataKeys: []string{"a", "b"}},
+			pbeg: 3,
+			where: "WHERE tasks.metadata -> $3 IS NOT NULL" +
+				" AND tasks.metadata -> $4 IS NOT NULL",
+			vals: []interface{}{"a", "b"},
+		},
+		{
+			name: "values and keys",
+			opts: tasks.ListOptions{
+				Metadata:     map[string]string{"a": "true"},
+				MetadataKeys: []string{"b"},
+			},
+			pbeg: 1,
+			where: "WHERE jsonb_typeof(tasks.metadata -> $1) IN " +
+				"('string','number','boolean') AND tasks.metadata ->> $2 = $3" +
+				" AND tasks.metadata -> $4 IS NOT NULL",
+			vals: []interface{}{"a", "a", "true", "b"},
+		},
+		{
+			name: "overdue",
+			opts: tasks.ListOptions{
+				OverdueAt:        &due,
+				TerminalStatuses: []string{"COMPLETED", "CANCELLED"},
+			},
+			pbeg: 1,
+			where: "WHERE tasks.due_date < $1" +
+				" AND tasks.status NOT IN ($2,$3)",
+			vals: []interface{}{due, "COMPLETED", "CANCELLED"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, where, _, vals := generateTasksFilter(&c.opts, c.pbeg)
+			if where != c.where {
+				t.Fatalf("expected where %q, got %q", c.where, where)
+			}
+			if len(vals) != len(c.vals) ||
+				(len(vals) != 0 && !reflect.DeepEqual(vals, c.vals)) {
+				t.Fatalf("expected values %v, got %v", c.vals, vals)
+			}
+		})
+	}
+}
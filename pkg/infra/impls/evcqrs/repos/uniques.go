@@ -4,6 +4,7 @@ import (
 	"context"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
@@ -62,12 +63,16 @@ func (r *UniquesRepository) RegisterConstraint(
 		value,
 	)
 	if err != nil {
+		if isUniqueViolation(err, uniqueValueConstraint) {
+			return domcom.NewUniqueConstraintViolationError(property)
+		}
 		lgr.Error("failed to insert unique constraint",
 			zap.Error(err),
 		)
+		return err
 	}
 
-	return err
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
 }
 
 func (r *UniquesRepository) RemoveConstraint(
@@ -107,6 +112,38 @@ func (r *UniquesRepository) RemoveConstraint(
 	return err
 }
 
+func (r *UniquesRepository) RemoveSagaConstraints(
+	c context.Context,
+	sagaId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaConstraintsQuery,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to delete saga unique constraints",
+			zap.Error(err),
+		)
+	}
+
+	return err
+}
+
 const (
 	InsertConstraintQuery = `
 	INSERT INTO uniques(
@@ -124,4 +161,8 @@ const (
 	WHERE stream = $1 AND stream_id = $2
 	RETURNING *
 	`
+	DeleteSagaConstraintsQuery = `
+	DELETE FROM uniques
+	WHERE saga_id = $1
+	`
 )
@@ -0,0 +1,220 @@
+// Package evsqlite Event source CQRS implementation of the domain layer on top
+// of an embedded SQLite database, meant for deployments where running postgres
+// and redis isn't an option
+package evsqlite
+
+import (
+	"context"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/impl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domtrace "techunicorn.com/udc-core/prototodo/pkg/domain/base/trace"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uids"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/config"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	evrepos "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evsqlite/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/memcache"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/snowflake"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/sqlitedb"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/appinsights"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/jaeger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/trace/promex"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/tracelib"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/BetaLixT/tsqlx"
+	"github.com/google/wire"
+	"go.uber.org/zap"
+)
+
+// DependencySet dependencies provided by the implementation
+var DependencySet = wire.NewSet(
+	NewImplementation,
+	wire.Bind(
+		new(impl.IImplementation),
+		new(*Implementation),
+	),
+	// Trace
+	NewTraceExporterList,
+	config.NewTraceOptions,
+	trace.NewTracer,
+	jaeger.NewJaegerTraceExporter,
+	config.NewJaegerExporterOptions,
+	appinsights.NewTraceExporter,
+	config.NewAppInsightsExporterOptions,
+	promex.NewTraceExporter,
+
+	// Infra
+	config.NewInitializer,
+	lgr.NewLoggerFactory,
+	wire.Bind(
+		new(logger.IFactory),
+		new(*lgr.LoggerFactory),
+	),
+	sqlitedb.NewDatabaseContext,
+	wire.Bind(
+		new(tsqlx.ITracer),
+		new(*tracelib.Tracer),
+	),
+	config.NewSQLiteDBOptions,
+	memcache.NewMemoryCache,
+	snowflake.NewSnowflake,
+	config.NewSnowflakeOptions,
+	config.NewTasksOptions,
+	jsonschema.NewValidator,
+	config.NewMetadataSchemaOptions,
+	wire.Bind(
+		new(tasks.IMetadataValidator),
+		new(*jsonschema.Validator),
+	),
+
+	// Events
+	eventhub.NewHub,
+	wire.Bind(
+		new(events.INotifier),
+		new(*eventhub.Hub),
+	),
+	config.NewEventHubOptions,
+
+	// Repos
+	repos.NewBaseDataRepository,
+	repos.NewACLRepository,
+	wire.Bind(
+		new(acl.IRepository),
+		new(*repos.ACLRepository),
+	),
+	repos.NewContextFactory,
+	wire.Bind(
+		new(cntxt.IFactory),
+		new(*evrepos.ContextFactory),
+	),
+	repos.NewForeignsRepository,
+	wire.Bind(
+		new(foreigns.IRepository),
+		new(*repos.ForeignsRepository),
+	),
+	repos.NewUniquesRepository,
+	wire.Bind(
+		new(uniques.IRepository),
+		new(*repos.UniquesRepository),
+	),
+	repos.NewUIDRepository,
+	wire.Bind(
+		new(uids.IRepository),
+		new(*repos.UIDRepository),
+	),
+	repos.NewTasksRepository,
+	wire.Bind(
+		new(tasks.IRepository),
+		new(*repos.TasksRepository),
+	),
+	repos.NewQuotesRepository,
+	wire.Bind(
+		new(quotes.IRepository),
+		new(*repos.QuotesRepository),
+	),
+	repos.NewSagasRepository,
+	wire.Bind(
+		new(sagas.IRepository),
+		new(*repos.SagasRepository),
+	),
+	repos.NewIdempotencyRepository,
+	config.NewIdempotencyOptions,
+	wire.Bind(
+		new(idempotency.IRepository),
+		new(*repos.IdempotencyRepository),
+	),
+
+	wire.Bind(
+		new(domtrace.IRepository),
+		new(*tracelib.Tracer),
+	),
+)
+
+// NewTraceExporterList provides a list of exporters for tracing, unlike the
+// evcqrs implementation the remote exporters are optional since edge
+// deployments usually have nowhere to export to
+func NewTraceExporterList(
+	insexp appinsights.TraceExporter,
+	jgrexp jaeger.TraceExporter,
+	prmex promex.TraceExporter,
+	lgrf logger.IFactory,
+) *trace.ExporterList {
+	lgr := lgrf.Create(context.Background())
+	exp := []sdktrace.SpanExporter{}
+
+	if insexp != nil {
+		exp = append(exp, insexp)
+	}
+	if jgrexp != nil {
+		exp = append(exp, jgrexp)
+	}
+	if len(exp) == 0 {
+		lgr.Warn("no remote tracing exporters found, traces won't be exported")
+	}
+	exp = append(exp, prmex)
+	return &trace.ExporterList{
+		Exporters: exp,
+	}
+}
+
+// Implementation used for graceful starting and stopping of the implementation
+// layer
+type Implementation struct {
+	dbctx *tsqlx.TracedDB
+	lgrf  *lgr.LoggerFactory
+	hub   *eventhub.Hub
+}
+
+// NewImplementation constructor for the evsqlite implementation
+func NewImplementation(
+	dbctx *tsqlx.TracedDB,
+	lgrf *lgr.LoggerFactory,
+	hub *eventhub.Hub,
+) *Implementation {
+	return &Implementation{
+		dbctx: dbctx,
+		lgrf:  lgrf,
+		hub:   hub,
+	}
+}
+
+// Start runs any routines that are required before the implemtation layer can
+// be utilized
+func (i *Implementation) Start(ctx context.Context) error {
+	lgri := i.lgrf.Create(ctx)
+	err := sqlitedb.RunMigrations(
+		ctx,
+		lgri,
+		i.dbctx,
+		repos.GetMigrationScripts(),
+	)
+	if err != nil {
+		lgri.Error("failed to run migration", zap.Error(err))
+		return err
+	}
+	i.hub.Start()
+	return nil
+}
+
+// Stop runs any routines that are required for the implementation layer to
+// gracefully shutdown
+func (i *Implementation) Stop(ctx context.Context) error {
+	i.hub.Stop()
+	i.lgrf.Close()
+	return nil
+}
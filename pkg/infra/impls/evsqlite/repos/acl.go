@@ -0,0 +1,372 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+// ACLRepository ACL repository caching the entries in process instead of in
+// redis, only entries that exist are cached so granting access is visible
+// immediately and deletes invalidate the cached entries
+type ACLRepository struct {
+	*BaseDataRepository
+	cache *cache.Cache
+	lgrf  logger.IFactory
+}
+
+var _ acl.IRepository = (*ACLRepository)(nil)
+
+func NewACLRepository(
+	base *BaseDataRepository,
+	cache *cache.Cache,
+	lgrf logger.IFactory,
+) *ACLRepository {
+	return &ACLRepository{
+		BaseDataRepository: base,
+		cache:              cache,
+		lgrf:               lgrf,
+	}
+}
+
+func (r *ACLRepository) CreateACLEntry(
+	c context.Context,
+	stream string,
+	streamID string,
+	userType string,
+	userID string,
+	permissions int,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	entry := entities.ACL{}
+	err = dbtx.Get(
+		ctx,
+		&entry,
+		InsertACLQuery,
+		stream,
+		streamID,
+		userType,
+		userID,
+		permissions,
+	)
+	if err != nil {
+		if isConstraintViolation(err, aclPrimaryKey) {
+			return domcom.NewACLEntryExistsError()
+		}
+		lgr.Error("failed to create ACL entry", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *ACLRepository) DeleteACLEntry(
+	c context.Context,
+	stream string,
+	streamID string,
+	userType string,
+	userID string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	var entries []entities.ACL
+	err = dbtx.Select(
+		ctx,
+		&entries,
+		DeleteACLEntryQuery,
+		userType,
+		userID,
+		stream,
+		streamID,
+	)
+	if err != nil {
+		lgr.Error("failed to delete entry", zap.Error(err))
+		return err
+	}
+	if len(entries) == 0 {
+		return domcom.NewACLEntryMissingError()
+	}
+	r.invalidate(ctx, entries)
+	return nil
+}
+
+func (r *ACLRepository) DeleteACLEntries(
+	c context.Context,
+	stream string,
+	streamID string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	var entries []entities.ACL
+	err = dbtx.Select(
+		ctx,
+		&entries,
+		DeleteACLEntriesQuery,
+		stream,
+		streamID,
+	)
+	if err != nil {
+		lgr.Error("failed to delete entries", zap.Error(err))
+		return err
+	}
+	r.invalidate(ctx, entries)
+	return nil
+}
+
+// invalidate removes the deleted entries from the cache, the entries are
+// removed again once the transaction commits since they could have been
+// cached again by a read that happened before the commit
+func (r *ACLRepository) invalidate(
+	ctx cntxt.IContext,
+	entries []entities.ACL,
+) {
+	if len(entries) == 0 {
+		return
+	}
+	keys := make([]string, len(entries))
+	for idx := range entries {
+		keys[idx] = generateACLCacheKey(
+			entries[idx].Stream,
+			entries[idx].StreamID,
+			entries[idx].UserType,
+			entries[idx].UserId,
+		)
+		r.cache.Delete(keys[idx])
+	}
+	ctx.RegisterCommitAction(func(context.Context) error {
+		for idx := range keys {
+			r.cache.Delete(keys[idx])
+		}
+		return nil
+	})
+}
+
+func (r *ACLRepository) GetPermissions(
+	ctx context.Context,
+	stream string,
+	streamID string,
+	userType string,
+	userID string,
+) (int, error) {
+	return r.getEntries(ctx, stream, []string{streamID}, userType, userID)
+}
+
+func (r *ACLRepository) CanRead(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) error {
+	perm, err := r.getEntries(ctx, stream, streamIDs, userType, userID)
+	if err != nil {
+		return err
+	}
+	if (perm & acl.Read) != 0 {
+		return nil
+	}
+	return domcom.NewUserACLCheckFailedError()
+}
+
+func (r *ACLRepository) CanWrite(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) error {
+	perm, err := r.getEntries(ctx, stream, streamIDs, userType, userID)
+	if err != nil {
+		return err
+	}
+	if (perm & acl.Write) != 0 {
+		return nil
+	}
+	return domcom.NewUserACLCheckFailedError()
+}
+
+func (r *ACLRepository) CanShare(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) error {
+	perm, err := r.getEntries(ctx, stream, streamIDs, userType, userID)
+	if err != nil {
+		return err
+	}
+	if (perm & acl.Share) != 0 {
+		return nil
+	}
+	return domcom.NewUserACLCheckFailedError()
+}
+
+// getEntries gets the permissions the user has on all of the entities, the
+// cached entries are used and only the missing entries are queried for
+func (r *ACLRepository) getEntries(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) (int, error) {
+	lgr := r.lgrf.Create(ctx)
+	if len(streamIDs) == 0 {
+		return 0, nil
+	}
+
+	perm := acl.Read | acl.Write | acl.Share
+	notFound := []string{}
+	for idx := range streamIDs {
+		val, ok := r.cache.Get(
+			generateACLCacheKey(stream, streamIDs[idx], userType, userID),
+		)
+		if !ok {
+			notFound = append(notFound, streamIDs[idx])
+			continue
+		}
+		perm = perm & val.(int)
+		if perm == 0 {
+			return perm, nil
+		}
+	}
+
+	if len(notFound) == 0 {
+		return perm, nil
+	}
+
+	ids, err := json.Marshal(notFound)
+	if err != nil {
+		return 0, err
+	}
+	var dbEntries []entities.ACL
+	err = r.dbctx.Select(
+		ctx,
+		&dbEntries,
+		SelectACLEntriesQuery,
+		userType,
+		userID,
+		stream,
+		string(ids),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		lgr.Error("failure while quering database", zap.Error(err))
+		return 0, err
+	}
+
+	for idx := range dbEntries {
+		perm = perm & dbEntries[idx].Permissions
+		r.cache.SetDefault(
+			generateACLCacheKey(
+				stream,
+				dbEntries[idx].StreamID,
+				userType,
+				userID,
+			),
+			dbEntries[idx].Permissions,
+		)
+	}
+	if len(dbEntries) != len(notFound) {
+		lgr.Warn("some acl entries were not present")
+		return 0, nil
+	}
+	return perm, nil
+}
+
+func generateACLCacheKey(
+	stream string,
+	id string,
+	userType string,
+	userID string,
+) string {
+	return "acl:" + strconv.Quote(stream) + ":" + strconv.Quote(id) + ":" +
+		strconv.Quote(userType) + ":" + strconv.Quote(userID)
+}
+
+// - Queries
+const (
+	// columns reported by SQLite when the primary key is violated
+	aclPrimaryKey = "acl.stream, acl.stream_id, acl.user_type, acl.user_id"
+
+	InsertACLQuery = `
+	INSERT INTO acl (
+		stream,
+		stream_id,
+		user_type,
+		user_id,
+		permissions
+	) VALUES (
+		?1, ?2, ?3, ?4, ?5
+	) RETURNING *
+	`
+
+	SelectACLEntriesQuery = `
+	SELECT * FROM acl
+	WHERE user_type = ?1 AND user_id = ?2 AND stream = ?3
+		AND stream_id IN (SELECT value FROM json_each(?4))
+	`
+
+	DeleteACLEntryQuery = `
+	DELETE FROM acl
+	WHERE user_type = ?1 AND user_id = ?2 AND stream = ?3 AND stream_id = ?4
+	RETURNING *
+	`
+
+	DeleteACLEntriesQuery = `
+	DELETE FROM acl
+	WHERE stream = ?1 AND stream_id = ?2
+	RETURNING *
+	`
+)
@@ -0,0 +1,209 @@
+// Package repos implements the interfaces defined on the domain layer on top
+// of an embedded SQLite database
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"github.com/BetaLixT/tsqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+// BaseDataRepository is the base repository containing common database
+// functionality to be embeded by other repos that implement persistence to the
+// database
+type BaseDataRepository struct {
+	dbctx *tsqlx.TracedDB
+}
+
+// NewBaseDataRepository Constructs a new base data repository
+func NewBaseDataRepository(
+	dbctx *tsqlx.TracedDB,
+) *BaseDataRepository {
+	return &BaseDataRepository{
+		dbctx: dbctx,
+	}
+}
+
+// insertEvent inserts an event, the event data is stored in the same format as
+// the evcqrs implementation so the same upcasters apply
+func (r *BaseDataRepository) insertEvent(
+	ctx cntxt.IContext,
+	trctx *tsqlx.TracedTx,
+	out entities.IBaseEvent,
+	sagaID *string,
+	stream string,
+	id string,
+	version uint64,
+	event string,
+	data interface{},
+) error {
+	_, tid, _, rid, _ := ctx.GetTraceInfo()
+	err := trctx.Get(
+		ctx,
+		out,
+		insertEventQuery,
+		sagaID,
+		stream,
+		id,
+		version,
+		event,
+		tid,
+		rid,
+		data,
+		entities.Upcasters.CurrentVersion(stream),
+		now(),
+	)
+	if err != nil {
+		if isConstraintViolation(err, eventVersionConstraint) {
+			return domcom.NewVersionConflictError(
+				"the version was written by a concurrent command",
+			)
+		}
+		return err
+	}
+	err = out.Decode()
+	if err != nil {
+		return err
+	}
+
+	err = r.recordSagaStep(ctx, trctx, sagaID, out.GetID())
+	if err == nil {
+		ctx.RegisterEvent(
+			out.GetID(),
+			sagaID,
+			stream,
+			id,
+			event,
+			version,
+			out.GetEventTime(),
+			data,
+		)
+	}
+	return err
+}
+
+// recordSagaStep records a write made under a saga in the saga's state, writes
+// under sagas that have been compensated are rejected
+func (r *BaseDataRepository) recordSagaStep(
+	ctx context.Context,
+	trctx *tsqlx.TracedTx,
+	sagaID *string,
+	eventID uint64,
+) error {
+	if sagaID == nil {
+		return nil
+	}
+	saga := entities.Saga{}
+	err := trctx.Get(
+		ctx,
+		&saga,
+		upsertSagaStepQuery,
+		*sagaID,
+		eventID,
+		now(),
+	)
+	if err == sql.ErrNoRows {
+		return domcom.NewSagaCompensatedError()
+	}
+	return err
+}
+
+func (r *BaseDataRepository) getDBTx(
+	ctx cntxt.IContext,
+) (*tsqlx.TracedTx, error) {
+	idbtx, nw, err := ctx.GetTransactionObject(
+		common.SqlTransactionObjectKey,
+		func() (interface{}, error) {
+			return r.dbctx.Beginx()
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbtx, ok := idbtx.(*tsqlx.TracedTx)
+	if !ok {
+		return nil, common.NewFailedToAssertDatabaseCtxTypeError()
+	}
+	if nw {
+		ctx.RegisterCommitAction(func(ctx context.Context) error {
+			return dbtx.Commit()
+		})
+		ctx.RegisterCompensatoryAction(func(ctx context.Context) error {
+			return dbtx.Rollback()
+		})
+	}
+	return dbtx, nil
+}
+
+// isConstraintViolation checks if the error is a violation of a constraint,
+// SQLite doesn't report the names of constraints so they are identified by
+// the columns in the error message
+func isConstraintViolation(err error, constraint string) bool {
+	var sqlerr sqlite3.Error
+	return errors.As(err, &sqlerr) &&
+		sqlerr.Code == sqlite3.ErrConstraint &&
+		strings.HasSuffix(sqlerr.Error(), constraint)
+}
+
+// isForeignKeyViolation checks if the error is a violation of a foreign key
+func isForeignKeyViolation(err error) bool {
+	var sqlerr sqlite3.Error
+	return errors.As(err, &sqlerr) &&
+		sqlerr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+}
+
+// now the current time, SQLite has no time zone aware timestamps so every
+// time is stored in UTC
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+const (
+	// columns reported by SQLite when the constraints are violated
+	eventVersionConstraint = "events.stream, events.stream_id, events.version"
+
+	insertEventQuery = `
+	INSERT INTO events(
+		saga_id,
+		stream,
+		stream_id,
+		version,
+		event,
+		trace_id,
+		request_id,
+		data,
+		schema_version,
+		event_time
+	) VALUES(
+		?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10
+	) RETURNING *`
+
+	upsertSagaStepQuery = `
+	INSERT INTO sagas(
+		saga_id,
+		status,
+		step_count,
+		last_event_id,
+		date_time_created,
+		date_time_updated
+	) VALUES(
+		?1, 'active', 1, ?2, ?3, ?3
+	)
+	ON CONFLICT (saga_id) DO UPDATE SET
+		step_count = sagas.step_count + 1,
+		last_event_id = MAX(sagas.last_event_id, ?2),
+		date_time_updated = ?3
+	WHERE sagas.status = 'active'
+	RETURNING *`
+)
@@ -0,0 +1,71 @@
+package repos
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/conformance"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/memcache"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/sqlitedb"
+)
+
+type mockTracer struct{}
+
+func (t *mockTracer) TraceDependency(
+	ctx context.Context,
+	spanId string,
+	dependencyType string,
+	serviceName string,
+	commandName string,
+	success bool,
+	startTimestamp time.Time,
+	eventTimestamp time.Time,
+	fields map[string]string,
+) {
+}
+
+// TestConformance runs the conformance suite against a fresh database file
+func TestConformance(t *testing.T) {
+	lgrf, err := lgr.NewLoggerFactory()
+	if err != nil {
+		t.Fatalf("failed to create logger factory: %v", err)
+	}
+	dbctx, err := sqlitedb.NewDatabaseContext(
+		&mockTracer{},
+		&sqlitedb.DatabaseOptions{
+			Path:                filepath.Join(t.TempDir(), "conformance.db"),
+			DatabaseServiceName: "sqlite-test",
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer dbctx.Close()
+
+	err = sqlitedb.RunMigrations(
+		context.Background(),
+		lgrf.Create(context.Background()),
+		dbctx,
+		GetMigrationScripts(),
+	)
+	if err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	base := NewBaseDataRepository(dbctx)
+	conformance.Run(t, &conformance.Subject{
+		Contexts: NewContextFactory(
+			lgrf,
+			eventhub.NewHub(&eventhub.Options{PollInterval: time.Minute}),
+		),
+		Tasks:    NewTasksRepository(base, lgrf),
+		Quotes:   NewQuotesRepository(base, lgrf),
+		ACL:      NewACLRepository(base, memcache.NewMemoryCache(), lgrf),
+		Uniques:  NewUniquesRepository(base, lgrf),
+		Foreigns: NewForeignsRepository(base, lgrf),
+	})
+}
@@ -0,0 +1,16 @@
+package repos
+
+import (
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	evrepos "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+)
+
+// NewContextFactory the contexts of the evcqrs implementation are used as is,
+// there is no outbox so subscribers are only notified through the hub
+func NewContextFactory(
+	lgrf logger.IFactory,
+	hub *eventhub.Hub,
+) *evrepos.ContextFactory {
+	return evrepos.NewContextFactory(lgrf, nil, hub)
+}
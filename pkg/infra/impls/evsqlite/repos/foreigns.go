@@ -0,0 +1,366 @@
+package repos
+
+import (
+	"context"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"go.uber.org/zap"
+)
+
+type ForeignsRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+}
+
+var _ foreigns.IRepository = (*ForeignsRepository)(nil)
+
+func NewForeignsRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+) *ForeignsRepository {
+	return &ForeignsRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+	}
+}
+
+func (r *ForeignsRepository) RegisterForeignItem(
+	c context.Context,
+	sagaId *string,
+	foreignStream string,
+	foreignStreamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	entry := entities.Foreign{}
+	err = dbtx.Get(
+		ctx,
+		&entry,
+		InsertForeignItemQuery,
+		foreignStream,
+		foreignStreamId,
+		sagaId,
+	)
+	if err != nil {
+		if isConstraintViolation(err, foreignsPrimaryKey) {
+			return domcom.NewForeignItemExistsError()
+		}
+		lgr.Error("failed to insert foreign item", zap.Error(err))
+		return err
+	}
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
+}
+
+func (r *ForeignsRepository) EnsureForeignItem(
+	c context.Context,
+	sagaId *string,
+	foreignStream string,
+	foreignStreamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		EnsureForeignItemQuery,
+		foreignStream,
+		foreignStreamId,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to insert foreign item", zap.Error(err))
+		return err
+	}
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
+}
+
+func (r *ForeignsRepository) RemoveForeignItem(
+	c context.Context,
+	foreignStream string,
+	foreignStreamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteForeignItemQuery,
+		foreignStream,
+		foreignStreamId,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domcom.NewForeignItemInUseError()
+		}
+		lgr.Error("failed to remove foreign item", zap.Error(err))
+	}
+	return err
+}
+
+func (r *ForeignsRepository) RegisterConstraint(
+	c context.Context,
+	sagaId *string,
+	foreignStream string,
+	foreignStreamId string,
+	stream string,
+	streamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	entry := entities.ForeignConstraint{}
+	err = dbtx.Get(
+		ctx,
+		&entry,
+		InsertForeignConstraintQuery,
+		foreignStream,
+		foreignStreamId,
+		stream,
+		streamId,
+		sagaId,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domcom.NewForeignItemMissingError()
+		}
+		if isConstraintViolation(err, foreignConstraintsPrimaryKey) {
+			return domcom.NewForeignConstraintExistsError()
+		}
+		lgr.Error("failed to insert foreign constraint", zap.Error(err))
+		return err
+	}
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
+}
+
+func (r *ForeignsRepository) RemoveConstraint(
+	c context.Context,
+	foreignStream string,
+	foreignStreamId string,
+	stream string,
+	streamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteForeignConstraintQuery,
+		foreignStream,
+		foreignStreamId,
+		stream,
+		streamId,
+	)
+	if err != nil {
+		lgr.Error("failed to remove foreign constraint", zap.Error(err))
+	}
+	return err
+}
+
+func (r *ForeignsRepository) RemoveSagaEntries(
+	c context.Context,
+	sagaId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	// constraints are removed first as they reference the foreign items
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaForeignConstraintsQuery,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to remove saga foreign constraints", zap.Error(err))
+		return err
+	}
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaForeignItemsQuery,
+		sagaId,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domcom.NewForeignItemInUseError()
+		}
+		lgr.Error("failed to remove saga foreign items", zap.Error(err))
+	}
+	return err
+}
+
+func (r *ForeignsRepository) ListAssociatedObjects(
+	c context.Context,
+	foreignStream string,
+	foreignStreamId string,
+) ([]foreigns.Object, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	entries := []entities.ForeignAssociatedObject{}
+	err = dbtx.Select(
+		ctx,
+		&entries,
+		ListAssociatedObjectsQuery,
+		foreignStream,
+		foreignStreamId,
+	)
+	if err != nil {
+		lgr.Error("failed to list associated objects", zap.Error(err))
+		return nil, err
+	}
+	return ((*entities.ForeignAssociatedObject)(nil)).ToDTOSlice(
+		entries,
+	), nil
+}
+
+const (
+	// columns reported by SQLite when the primary keys are violated
+	foreignsPrimaryKey           = "foreigns.stream, foreigns.stream_id"
+	foreignConstraintsPrimaryKey = "foreign_constraints.foreign_stream, " +
+		"foreign_constraints.foreign_stream_id, foreign_constraints.stream, " +
+		"foreign_constraints.stream_id"
+
+	InsertForeignItemQuery = `
+	INSERT INTO foreigns(
+		stream,
+		stream_id,
+		saga_id
+	) VALUES(
+		?1, ?2, ?3
+	) RETURNING *
+	`
+
+	// EnsureForeignItemQuery leaves an existing item as is, a concurrent
+	// insert waits for the other transaction instead of failing
+	EnsureForeignItemQuery = `
+	INSERT INTO foreigns(
+		stream,
+		stream_id,
+		saga_id
+	) VALUES(
+		?1, ?2, ?3
+	) ON CONFLICT DO NOTHING
+	`
+
+	DeleteForeignItemQuery = `
+	DELETE FROM foreigns
+	WHERE stream = ?1 AND stream_id = ?2
+	`
+
+	InsertForeignConstraintQuery = `
+	INSERT INTO foreign_constraints(
+		foreign_stream,
+		foreign_stream_id,
+		stream,
+		stream_id,
+		saga_id
+	) VALUES(
+		?1, ?2, ?3, ?4, ?5
+	) RETURNING *
+	`
+
+	DeleteForeignConstraintQuery = `
+	DELETE FROM foreign_constraints
+	WHERE foreign_stream = ?1 AND foreign_stream_id = ?2
+	 AND stream = ?3 AND stream_id = ?4
+	`
+
+	DeleteSagaForeignConstraintsQuery = `
+	DELETE FROM foreign_constraints
+	WHERE saga_id = ?1
+	`
+
+	DeleteSagaForeignItemsQuery = `
+	DELETE FROM foreigns
+	WHERE saga_id = ?1
+	`
+
+	ListAssociatedObjectsQuery = `
+	SELECT stream, stream_id FROM foreign_constraints
+	WHERE foreign_stream = ?1 AND foreign_stream_id = ?2
+	ORDER BY stream, stream_id
+	`
+)
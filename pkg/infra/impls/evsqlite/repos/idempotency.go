@@ -0,0 +1,154 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	evrepos "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+
+	"go.uber.org/zap"
+)
+
+// IdempotencyRepository repository implementation for idempotency keys, the
+// results are written with the transaction of the command so a key is only
+// stored if the changes of the command are
+type IdempotencyRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+	opts *evrepos.IdempotencyOptions
+}
+
+var _ idempotency.IRepository = (*IdempotencyRepository)(nil)
+
+// NewIdempotencyRepository creates new IdempotencyRepository
+func NewIdempotencyRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+	opts *evrepos.IdempotencyOptions,
+) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+		opts:               opts,
+	}
+}
+
+// Get fetches the unexpired record of a key used by a user
+func (r *IdempotencyRepository) Get(
+	ctx context.Context,
+	userType string,
+	userID string,
+	key string,
+) (*idempotency.Record, error) {
+	rec := entities.IdempotencyKey{}
+	err := r.dbctx.Get(
+		ctx,
+		&rec,
+		SelectIdempotencyKeyQuery,
+		userType,
+		userID,
+		key,
+		now(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rec.ToDTO(), nil
+}
+
+// Save stores the result of a command, expired keys of the user are cleared
+// out so that they can be reused
+func (r *IdempotencyRepository) Save(
+	c context.Context,
+	record idempotency.Record,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	tme := now()
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteExpiredIdempotencyKeysQuery,
+		record.UserType,
+		record.UserId,
+		tme,
+	)
+	if err != nil {
+		lgr.Error("failed to delete expired idempotency keys", zap.Error(err))
+		return err
+	}
+
+	rec := entities.IdempotencyKey{}
+	err = dbtx.Get(
+		ctx,
+		&rec,
+		InsertIdempotencyKeyQuery,
+		record.UserType,
+		record.UserId,
+		record.Key,
+		record.Command,
+		record.Fingerprint,
+		record.Result,
+		tme,
+		tme.Add(r.opts.Expiry),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domcom.NewIdempotencyKeyInUseError()
+		}
+		lgr.Error("failed to insert idempotency key", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// - Queries
+const (
+	SelectIdempotencyKeyQuery = `
+	SELECT * FROM idempotency_keys
+	WHERE user_type = ?1 AND user_id = ?2 AND idempotency_key = ?3
+		AND date_time_expires > ?4
+	`
+
+	DeleteExpiredIdempotencyKeysQuery = `
+	DELETE FROM idempotency_keys
+	WHERE user_type = ?1 AND user_id = ?2 AND date_time_expires <= ?3
+	`
+
+	InsertIdempotencyKeyQuery = `
+	INSERT INTO idempotency_keys (
+		user_type,
+		user_id,
+		idempotency_key,
+		command,
+		fingerprint,
+		result,
+		date_time_created,
+		date_time_expires
+	) VALUES (
+		?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8
+	)
+	ON CONFLICT (user_type, user_id, idempotency_key) DO NOTHING
+	RETURNING *
+	`
+)
@@ -0,0 +1,226 @@
+package repos
+
+import "techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+
+// GetMigrationScripts provides all the migration scripts required for the
+// application, the schema follows the postgres schema of the evcqrs
+// implementation without the outbox, replay progress and snapshots
+func GetMigrationScripts() []psqldb.MigrationScript {
+	migrationScripts := []psqldb.MigrationScript{
+		{
+			Key: "initial-event-source",
+			Up: `
+				CREATE TABLE events (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					saga_id text,
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					version bigint NOT NULL,
+					event text NOT NULL,
+					event_time timestamp NOT NULL,
+					data blob NOT NULL,
+					trace_id text NOT NULL,
+					request_id text NOT NULL,
+					schema_version int NOT NULL DEFAULT 1,
+					CONSTRAINT source_unique UNIQUE (stream, stream_id, version)
+				);
+
+				CREATE INDEX idx_events_stream_events ON events(stream, stream_id);
+				CREATE INDEX idx_events_saga_id ON events(saga_id)
+				WHERE saga_id IS NOT NULL;
+
+				CREATE TABLE uniques (
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					saga_id text,
+					property text NOT NULL,
+					value text NOT NULL,
+					PRIMARY KEY(stream, stream_id, property),
+					CONSTRAINT uniques_value_unique UNIQUE (stream, property, value)
+				);
+
+				CREATE TABLE acl (
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					user_type text NOT NULL,
+					user_id text NOT NULL,
+					permissions int NOT NULL,
+					PRIMARY KEY(stream, stream_id, user_type, user_id)
+				);
+
+				CREATE TABLE foreigns (
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					saga_id text,
+					PRIMARY KEY(stream, stream_id)
+				);
+
+				CREATE TABLE foreign_constraints (
+					foreign_stream text NOT NULL,
+					foreign_stream_id text NOT NULL,
+					stream text NOT NULL,
+					stream_id text NOT NULL,
+					saga_id text,
+					PRIMARY KEY (foreign_stream, foreign_stream_id, stream, stream_id),
+					CONSTRAINT foreign_constraints_fk FOREIGN KEY (foreign_stream, foreign_stream_id) REFERENCES foreigns (stream, stream_id)
+				);
+
+				CREATE TABLE sagas (
+					saga_id text PRIMARY KEY NOT NULL,
+					status text NOT NULL,
+					step_count bigint NOT NULL,
+					last_event_id bigint NOT NULL,
+					date_time_created timestamp NOT NULL,
+					date_time_updated timestamp NOT NULL,
+					date_time_compensated timestamp
+				);
+
+				CREATE TABLE idempotency_keys (
+					user_type text NOT NULL,
+					user_id text NOT NULL,
+					idempotency_key text NOT NULL,
+					command text NOT NULL,
+					fingerprint text NOT NULL,
+					result blob NOT NULL,
+					date_time_created timestamp NOT NULL,
+					date_time_expires timestamp NOT NULL,
+					PRIMARY KEY(user_type, user_id, idempotency_key)
+				);
+				`,
+			Down: `
+			  DROP TABLE idempotency_keys;
+			  DROP TABLE sagas;
+			  DROP TABLE foreign_constraints;
+			  DROP TABLE foreigns;
+			  DROP TABLE acl;
+			  DROP TABLE uniques;
+			  DROP INDEX idx_events_saga_id;
+			  DROP INDEX idx_events_stream_events;
+			  DROP TABLE events;
+				`,
+		},
+		{
+			Key: "read-models",
+			Up: `
+				CREATE TABLE tasks (
+					id text PRIMARY KEY NOT NULL,
+					title text NOT NULL,
+					description text NOT NULL,
+					status text NOT NULL,
+					random_map blob NOT NULL,
+					metadata blob NOT NULL,
+					version bigint NOT NULL,
+					date_time_created timestamp NOT NULL,
+					date_time_updated timestamp NOT NULL
+				);
+
+				CREATE TABLE quotes (
+					id text PRIMARY KEY NOT NULL,
+					quote text NOT NULL,
+					version bigint NOT NULL,
+					date_time_created timestamp NOT NULL,
+					date_time_updated timestamp NOT NULL
+				);
+				`,
+			Down: `
+			  DROP TABLE quotes;
+			  DROP TABLE tasks;
+				`,
+		},
+		{
+			Key: "tasks-list-indexes",
+			Up: `
+				CREATE INDEX idx_tasks_status ON tasks(status);
+				CREATE INDEX idx_tasks_date_time_created ON tasks(date_time_created, id);
+				CREATE INDEX idx_tasks_date_time_updated ON tasks(date_time_updated, id);
+				CREATE INDEX idx_tasks_title ON tasks(title, id);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_title;
+			  DROP INDEX idx_tasks_date_time_updated;
+			  DROP INDEX idx_tasks_date_time_created;
+			  DROP INDEX idx_tasks_status;
+				`,
+		},
+		{
+			Key: "acl-user-index",
+			Up: `
+				CREATE INDEX idx_acl_user ON acl(user_type, user_id, stream, stream_id);
+				`,
+			Down: `
+			  DROP INDEX idx_acl_user;
+				`,
+		},
+		{
+			// tasks were only ever granted to their creators so the creators are
+			// the entries with read and write access
+			Key: "acl-task-share-permission",
+			Up: `
+				UPDATE acl SET permissions = permissions | 4
+				WHERE stream = 'tasks' AND permissions = 3;
+				`,
+			Down: `
+			  UPDATE acl SET permissions = permissions & 3 WHERE stream = 'tasks';
+				`,
+		},
+		{
+			Key: "tasks-assignee",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN assignee text NOT NULL DEFAULT '';
+				CREATE INDEX idx_tasks_assignee ON tasks(assignee);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_assignee;
+			  ALTER TABLE tasks DROP COLUMN assignee;
+				`,
+		},
+		{
+			Key: "tasks-due-date",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN due_date timestamp;
+				CREATE INDEX idx_tasks_due_date ON tasks(due_date);
+
+				CREATE TABLE task_schedules (
+					stream_id text NOT NULL,
+					event text NOT NULL,
+					fire_at timestamp NOT NULL,
+					PRIMARY KEY(stream_id, event)
+				);
+				CREATE INDEX idx_task_schedules_fire_at ON task_schedules(fire_at);
+				`,
+			Down: `
+			  DROP INDEX idx_task_schedules_fire_at;
+			  DROP TABLE task_schedules;
+			  DROP INDEX idx_tasks_due_date;
+			  ALTER TABLE tasks DROP COLUMN due_date;
+				`,
+		},
+		{
+			Key: "tasks-parent",
+			Up: `
+				ALTER TABLE tasks ADD COLUMN parent_id text NOT NULL DEFAULT '';
+				CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+				`,
+			Down: `
+			  DROP INDEX idx_tasks_parent_id;
+			  ALTER TABLE tasks DROP COLUMN parent_id;
+				`,
+		},
+		{
+			Key: "task-dependencies",
+			Up: `
+				CREATE TABLE task_dependencies (
+					stream_id text NOT NULL,
+					blocker_id text NOT NULL,
+					PRIMARY KEY(stream_id, blocker_id)
+				);
+				CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+				`,
+			Down: `
+			  DROP INDEX idx_task_dependencies_blocker_id;
+			  DROP TABLE task_dependencies;
+				`,
+		},
+	}
+	return migrationScripts
+}
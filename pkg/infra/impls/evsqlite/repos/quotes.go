@@ -0,0 +1,189 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"go.uber.org/zap"
+)
+
+// QuotesRepository repository implementation for quotes
+type QuotesRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+}
+
+// NewQuotesRepository creates QuotesRepository
+func NewQuotesRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+) *QuotesRepository {
+	return &QuotesRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+	}
+}
+
+var _ quotes.IRepository = (*QuotesRepository)(nil)
+
+// Create a quote
+func (r *QuotesRepository) Create(
+	c context.Context,
+	id string,
+	sagaID *string,
+	quote string,
+) (*quotes.QuoteEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var ev entities.QuoteEvent
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&ev,
+		sagaID,
+		domcom.QuoteStreamName,
+		id,
+		0,
+		domcom.EventCreated,
+		&entities.QuoteData{
+			Quote: &quote,
+		},
+	)
+	if err != nil {
+		lgr.Error("failed to insert create event", zap.Error(err))
+		return nil, err
+	}
+
+	var res entities.QuoteReadModel
+	err = dbtx.Get(
+		ctx,
+		&res,
+		InsertQuoteReadModelQuery,
+		id,
+		ev.Data.Quote,
+		ev.Version,
+		ev.EventTime,
+		ev.EventTime,
+	)
+	if err != nil {
+		lgr.Error("failed to create quote read model", zap.Error(err))
+		return nil, err
+	}
+
+	return ev.ToDTO(), nil
+}
+
+// Delete deletes an existing quote
+func (r *QuotesRepository) Delete(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+) (*quotes.QuoteEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var ev entities.QuoteEvent
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&ev,
+		sagaID,
+		domcom.QuoteStreamName,
+		id,
+		version,
+		domcom.EventDeleted,
+		&entities.QuoteData{},
+	)
+	if err != nil {
+		lgr.Error("failed to insert delete event", zap.Error(err))
+		return nil, err
+	}
+
+	var res entities.QuoteReadModel
+	err = dbtx.Get(
+		ctx,
+		&res,
+		DeleteQuoteReadModelQuery,
+		id,
+		version-1,
+	)
+	if err != nil {
+		lgr.Error("failed to delete quote read model", zap.Error(err))
+		return nil, err
+	}
+
+	return ev.ToDTO(), nil
+}
+
+// GetRandom Fetch a random quote
+func (r *QuotesRepository) GetRandom(
+	ctx context.Context,
+) (*quotes.Quote, error) {
+	var res entities.QuoteReadModel
+	err := r.dbctx.Get(
+		ctx,
+		&res,
+		GetRandomQuoteQuery,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domcom.NewQuoteMissingError()
+		}
+		return nil, err
+	}
+	return res.ToDTO(), nil
+}
+
+// - Queries
+const (
+	InsertQuoteReadModelQuery = `
+	INSERT INTO quotes (
+		id,
+		quote,
+		version,
+		date_time_created,
+		date_time_updated
+	) VALUES (
+		?1, ?2, ?3, ?4, ?5
+	) RETURNING *
+	`
+
+	DeleteQuoteReadModelQuery = `
+	DELETE FROM quotes WHERE id = ?1 AND version = ?2 RETURNING *
+	`
+
+	GetRandomQuoteQuery = `
+	SELECT * FROM quotes ORDER BY RANDOM() LIMIT 1
+	`
+)
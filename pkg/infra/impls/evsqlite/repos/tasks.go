@@ -0,0 +1,1067 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+	evrepos "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/repos"
+
+	"go.uber.org/zap"
+)
+
+// TasksRepository repository implimentation for tasks
+type TasksRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+}
+
+// NewTasksRepository creates new TasksRepository
+func NewTasksRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+) *TasksRepository {
+	return &TasksRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+	}
+}
+
+var _ tasks.IRepository = (*TasksRepository)(nil)
+
+// Create creates a new task
+func (r *TasksRepository) Create(
+	c context.Context,
+	id string,
+	sagaID *string,
+	data tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var evnt entities.TaskEvent
+	var dat entities.TaskData
+	dat.FromDTO(&data)
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&evnt,
+		sagaID,
+		domcom.TaskStreamName,
+		id,
+		0,
+		domcom.EventCreated,
+		&dat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := entities.TaskReadModel{}
+	err = dbtx.Get(
+		ctx,
+		&dest,
+		InsertTaskReadModelQuery,
+		id,
+		evrepos.GetValueOrDefault(data.Title),
+		evrepos.GetValueOrDefault(data.Description),
+		evrepos.GetValueOrDefault(data.Status),
+		entities.JSONMapString(data.RandomMap),
+		entities.JSONObj(data.Metadata),
+		evnt.Version,
+		evnt.EventTime,
+		evnt.EventTime,
+		entities.DueDateValue(dat.DueDate),
+		evrepos.GetValueOrDefault(data.ParentID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return evnt.ToDTO(), nil
+}
+
+// Get fetches an exiting task
+func (r *TasksRepository) Get(
+	ctx context.Context,
+	id string,
+) (*tasks.Task, error) {
+	var task entities.TaskReadModel
+	err := r.dbctx.Get(
+		ctx,
+		&task,
+		SelectTaskByIdQuery,
+		id,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domcom.NewTaskMissingError()
+		}
+		return nil, err
+	}
+
+	return task.ToDTO()
+}
+
+// List gives a paged list of the tasks matching the options
+func (r *TasksRepository) List(
+	ctx context.Context,
+	opts tasks.ListOptions,
+	countPerPage int,
+	pageNumber int,
+) ([]tasks.Task, error) {
+	from, where, order, vals := generateTasksFilter(&opts, 3)
+	var tasks []entities.TaskReadModel
+	err := r.dbctx.Select(
+		ctx,
+		&tasks,
+		fmt.Sprintf(ListTasksQuery, from, where, order),
+		append(
+			[]interface{}{countPerPage, pageNumber * countPerPage},
+			vals...,
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ((*entities.TaskReadModel)(nil)).ToDTOSlice(tasks)
+}
+
+// Count counts the tasks matching the filters of the options
+func (r *TasksRepository) Count(
+	ctx context.Context,
+	opts tasks.ListOptions,
+) (uint64, error) {
+	opts.After = nil
+	from, where, _, vals := generateTasksFilter(&opts, 1)
+	var cnt uint64
+	err := r.dbctx.Get(
+		ctx,
+		&cnt,
+		fmt.Sprintf(CountTasksQuery, from, where),
+		vals...,
+	)
+	return cnt, err
+}
+
+// Delete deletes an existing task
+func (r *TasksRepository) Delete(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+) (*tasks.TaskEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var evnt entities.TaskEvent
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&evnt,
+		sagaID,
+		domcom.TaskStreamName,
+		id,
+		version,
+		domcom.EventDeleted,
+		&entities.TaskData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := entities.TaskReadModel{}
+	err = dbtx.Get(
+		ctx,
+		&dest,
+		DeleteTaskReadModelQuery,
+		id,
+		version-1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dbtx.Exec(ctx, DeleteTaskDependenciesQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return evnt.ToDTO(), nil
+}
+
+// FireSchedule writes the event of a schedule of an existing task
+func (r *TasksRepository) FireSchedule(
+	c context.Context,
+	id string,
+	version uint64,
+	event string,
+	dueDate time.Time,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		nil,
+		version,
+		event,
+		tasks.TaskData{DueDate: &dueDate},
+	)
+}
+
+// SetSchedules replaces the schedules of a task
+func (r *TasksRepository) SetSchedules(
+	c context.Context,
+	id string,
+	schedules []tasks.Schedule,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(ctx, DeleteTaskSchedulesQuery, id)
+	if err != nil {
+		lgr.Error("failed to delete task schedules", zap.Error(err))
+		return err
+	}
+	for _, schd := range schedules {
+		_, err = dbtx.Exec(
+			ctx,
+			InsertTaskScheduleQuery,
+			id,
+			schd.Event,
+			schd.FireAt.UTC(),
+		)
+		if err != nil {
+			lgr.Error("failed to insert task schedule", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimDueSchedule removes and gives the earliest due schedule, SQLite only
+// allows a single writer so a schedule being claimed by another connection
+// is gone by the time the delete runs
+func (r *TasksRepository) ClaimDueSchedule(
+	c context.Context,
+	now time.Time,
+) (*tasks.Schedule, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	schd := entities.TaskSchedule{}
+	err = dbtx.Get(ctx, &schd, ClaimDueTaskScheduleQuery, now.UTC())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		lgr.Error("failed to claim task schedule", zap.Error(err))
+		return nil, err
+	}
+	return schd.ToDTO(), nil
+}
+
+// Update updates an existing task
+func (r *TasksRepository) Update(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	dat tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(c, id, sagaID, version, domcom.EventUpdated, dat)
+}
+
+// Compensate restores an existing task to the given data
+func (r *TasksRepository) Compensate(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	dat tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(c, id, sagaID, version, domcom.EventCompensated, dat)
+}
+
+// Transition writes the event of a workflow transition moving an existing
+// task to the status
+func (r *TasksRepository) Transition(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	status string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		event,
+		tasks.TaskData{Status: &status},
+	)
+}
+
+// Assign writes an event assigning an existing task to a user
+func (r *TasksRepository) Assign(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	assignee string,
+	access *tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventAssigned,
+		tasks.TaskData{Assignee: &assignee, Access: access},
+	)
+}
+
+// Move writes an event moving an existing task under another task
+func (r *TasksRepository) Move(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	parentID string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventMoved,
+		tasks.TaskData{ParentID: &parentID},
+	)
+}
+
+// LockHierarchy serializes the changes to the task hierarchy, transactions
+// take the write lock of the database as they begin so beginning the
+// transaction of the context is enough
+func (r *TasksRepository) LockHierarchy(c context.Context) error {
+	return r.lock(c)
+}
+
+// LockDependencies serializes the changes to the task dependencies the same
+// way the hierarchy is
+func (r *TasksRepository) LockDependencies(c context.Context) error {
+	return r.lock(c)
+}
+
+// lock begins the transaction of the context, waiting for the transaction
+// holding the write lock to end
+func (r *TasksRepository) lock(c context.Context) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	_, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AddDependency writes an event blocking an existing task by another task
+func (r *TasksRepository) AddDependency(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	blockerID string,
+) (*tasks.TaskEvent, error) {
+	evnt, err := r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyAdded,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return evnt, r.execDependency(c, InsertTaskDependencyQuery, id, blockerID)
+}
+
+// RemoveDependency writes an event unblocking an existing task from another
+// task
+func (r *TasksRepository) RemoveDependency(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	blockerID string,
+) (*tasks.TaskEvent, error) {
+	evnt, err := r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyRemoved,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return evnt, r.execDependency(c, DeleteTaskDependencyQuery, id, blockerID)
+}
+
+func (r *TasksRepository) execDependency(
+	c context.Context,
+	qry string,
+	id string,
+	blockerID string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(ctx, qry, id, blockerID)
+	if err != nil {
+		lgr.Error("failed to write task dependency", zap.Error(err))
+	}
+	return err
+}
+
+// ListBlockers lists the ids of the tasks blocking a task ordered by id
+func (r *TasksRepository) ListBlockers(
+	c context.Context,
+	id string,
+) ([]string, error) {
+	return r.listDependencies(c, ListTaskBlockersQuery, id)
+}
+
+// ListBlocked lists the ids of the tasks blocked by a task ordered by id
+func (r *TasksRepository) ListBlocked(
+	c context.Context,
+	id string,
+) ([]string, error) {
+	return r.listDependencies(c, ListBlockedTasksQuery, id)
+}
+
+func (r *TasksRepository) listDependencies(
+	c context.Context,
+	qry string,
+	id string,
+) ([]string, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	ids := []string{}
+	err = dbtx.Select(ctx, &ids, qry, id)
+	if err != nil {
+		lgr.Error("failed to list task dependencies", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Share writes an event granting a user access to an existing task
+func (r *TasksRepository) Share(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventShared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// Unshare writes an event revoking the access of a user to an existing task
+func (r *TasksRepository) Unshare(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		c,
+		id,
+		sagaID,
+		version,
+		domcom.EventUnshared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// update writes an event changing the fields of the task that are set, events
+// only changing the access to or the dependencies of the task just move the
+// version forward
+func (r *TasksRepository) update(
+	c context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	dat tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return nil, common.NewFailedToAssertContextTypeError()
+	}
+
+	var data entities.TaskData
+	data.FromDTO(&dat)
+
+	set, vals := generateReadModelSet(&data, 5)
+	if set == "" && data.Access == nil && data.BlockerId == nil {
+		lgr.Error("no values updated")
+		return nil, domcom.NewNoTaskUpdatesError()
+	}
+	qry := UpdateTaskVersionQuery
+	if set != "" {
+		qry = fmt.Sprintf(UpdateTaskQuery, set)
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return nil, err
+	}
+
+	var evnt entities.TaskEvent
+	err = r.insertEvent(
+		ctx,
+		dbtx,
+		&evnt,
+		sagaID,
+		domcom.TaskStreamName,
+		id,
+		version,
+		event,
+		&data,
+	)
+	if err != nil {
+		lgr.Error("failed to insert update event", zap.Error(err))
+		return nil, err
+	}
+
+	allvals := append(
+		[]interface{}{id, version - 1, version, evnt.EventTime},
+		vals...,
+	)
+	dest := entities.TaskReadModel{}
+	err = dbtx.Get(
+		ctx,
+		&dest,
+		qry,
+		allvals...,
+	)
+	if err != nil {
+		lgr.Error("failed to update entity", zap.Error(err))
+		return nil, err
+	}
+
+	return evnt.ToDTO(), nil
+}
+
+// GetSnapshot snapshots aren't taken by the SQLite implementation, the tasks
+// are always folded from the start of the stream
+func (r *TasksRepository) GetSnapshot(
+	ctx context.Context,
+	id string,
+	version *uint64,
+	eventTime *time.Time,
+) (*tasks.Task, error) {
+	return nil, nil
+}
+
+// ListEvents gives a paged list of events of a task ordered by version
+func (r *TasksRepository) ListEvents(
+	ctx context.Context,
+	id string,
+	countPerPage int,
+	pageNumber int,
+) ([]tasks.TaskEvent, error) {
+	return r.listEvents(
+		ctx,
+		ListTaskEventsQuery,
+		domcom.TaskStreamName,
+		id,
+		countPerPage,
+		pageNumber*countPerPage,
+	)
+}
+
+// ListEventsAfter lists the events of all tasks after an event id, sqlite
+// allows a single writer so event ids are assigned in commit order
+func (r *TasksRepository) ListEventsAfter(
+	ctx context.Context,
+	afterID uint64,
+	count int,
+) ([]tasks.TaskEvent, error) {
+	return r.listEvents(
+		ctx,
+		ListTaskEventsAfterQuery,
+		domcom.TaskStreamName,
+		afterID,
+		count,
+	)
+}
+
+// ListEventsUntil lists the events of a task ordered by version starting from
+// a version up to and including the given version or event time
+func (r *TasksRepository) ListEventsUntil(
+	ctx context.Context,
+	id string,
+	fromVersion uint64,
+	version *uint64,
+	eventTime *time.Time,
+) ([]tasks.TaskEvent, error) {
+	var until *time.Time
+	if eventTime != nil {
+		utc := eventTime.UTC()
+		until = &utc
+	}
+	return r.listEvents(
+		ctx,
+		ListTaskEventsUntilQuery,
+		domcom.TaskStreamName,
+		id,
+		fromVersion,
+		version,
+		until,
+	)
+}
+
+func (r *TasksRepository) listEvents(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]tasks.TaskEvent, error) {
+	var evnts []entities.TaskEvent
+	err := r.dbctx.Select(
+		ctx,
+		&evnts,
+		query,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = ((*entities.TaskEvent)(nil)).DecodeSlice(evnts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ((*entities.TaskEvent)(nil)).ToDTOSlice(evnts)
+}
+
+// generateReadModelSet generates the set clause for the read model with
+// numbered parameters starting from pbeg
+func generateReadModelSet(
+	t *entities.TaskData,
+	pbeg int,
+) (string, []interface{}) {
+	sets := []string{}
+	vals := []interface{}{}
+	add := func(col string, val interface{}) {
+		sets = append(sets, col+" = ?"+strconv.Itoa(pbeg+len(vals)))
+		vals = append(vals, val)
+	}
+	if t.Title != nil {
+		add("title", t.Title)
+	}
+	if t.Description != nil {
+		add("description", t.Description)
+	}
+	if t.Status != nil {
+		add("status", t.Status)
+	}
+	if t.RandomMap != nil || t.RandomMapCleared {
+		add("random_map", entities.JSONMapString(t.RandomMapValue()))
+	}
+	if t.Metadata != nil {
+		add("metadata", entities.JSONObj(t.Metadata.AsMap()))
+	}
+	if t.Assignee != nil {
+		add("assignee", t.Assignee)
+	}
+	if t.DueDate != nil {
+		add("due_date", entities.DueDateValue(t.DueDate))
+	}
+	if t.ParentId != nil {
+		add("parent_id", t.ParentId)
+	}
+	return strings.Join(sets, ","), vals
+}
+
+// sortedKeys gives the keys of the map sorted so the generated queries are
+// stable
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// metadataPath gives the json path of a top level metadata key
+func metadataPath(key string) string {
+	return `$."` + key + `"`
+}
+
+// generateTasksFilter generates the from, where and order by clauses selecting
+// the tasks matching the options with numbered parameters starting from pbeg,
+// the id of the task is always the last sort column so that pages are stable
+// and the listing can continue after a task
+func generateTasksFilter(
+	opts *tasks.ListOptions,
+	pbeg int,
+) (string, string, string, []interface{}) {
+	conds := []string{}
+	vals := []interface{}{}
+	param := func(val interface{}) int {
+		vals = append(vals, val)
+		return pbeg + len(vals) - 1
+	}
+	add := func(cond string, val interface{}) {
+		conds = append(conds, fmt.Sprintf(cond, param(val)))
+	}
+
+	// the acl is joined so that only the tasks the reader can read are listed
+	from := "tasks"
+	if opts.Reader != nil {
+		from = fmt.Sprintf(
+			ListTasksACLJoin,
+			param(domcom.TaskStreamName),
+			param(opts.Reader.UserType),
+			param(opts.Reader.UserID),
+			param(acl.Read),
+		)
+	}
+	if opts.Status != nil {
+		add("tasks.status = ?%d", *opts.Status)
+	}
+	if opts.Assignee != nil {
+		add("tasks.assignee = ?%d", *opts.Assignee)
+	}
+	if opts.ParentID != nil {
+		add("tasks.parent_id = ?%d", *opts.ParentID)
+	}
+	// the metadata is stored as a blob so it's cast for the json functions,
+	// booleans are extracted as integers so they're compared by their type
+	for _, key := range sortedKeys(opts.Metadata) {
+		path := param(metadataPath(key))
+		val := fmt.Sprintf(
+			"CAST(json_extract(CAST(tasks.metadata AS TEXT), ?%d) AS TEXT)",
+			path,
+		)
+		conds = append(conds, fmt.Sprintf(
+			"(CASE json_type(CAST(tasks.metadata AS TEXT), ?%d)"+
+				" WHEN 'true' THEN 'true' WHEN 'false' THEN 'false'"+
+				" WHEN 'text' THEN %s WHEN 'integer' THEN %s WHEN 'real' THEN %s"+
+				" END) = ?%d",
+			path,
+			val,
+			val,
+			val,
+			param(opts.Metadata[key]),
+		))
+	}
+	for _, key := range opts.MetadataKeys {
+		add(
+			"json_type(CAST(tasks.metadata AS TEXT), ?%d) IS NOT NULL",
+			metadataPath(key),
+		)
+	}
+	if opts.OverdueAt != nil {
+		add("tasks.due_date < ?%d", opts.OverdueAt.UTC())
+		if len(opts.TerminalStatuses) != 0 {
+			phs := make([]string, len(opts.TerminalStatuses))
+			for idx, status := range opts.TerminalStatuses {
+				phs[idx] = fmt.Sprintf("?%d", param(status))
+			}
+			conds = append(conds, fmt.Sprintf(
+				"tasks.status NOT IN (%s)",
+				strings.Join(phs, ","),
+			))
+		}
+	}
+	if opts.CreatedAfter != nil {
+		add("tasks.date_time_created >= ?%d", opts.CreatedAfter.UTC())
+	}
+	if opts.CreatedBefore != nil {
+		add("tasks.date_time_created < ?%d", opts.CreatedBefore.UTC())
+	}
+	if opts.UpdatedAfter != nil {
+		add("tasks.date_time_updated >= ?%d", opts.UpdatedAfter.UTC())
+	}
+	if opts.UpdatedBefore != nil {
+		add("tasks.date_time_updated < ?%d", opts.UpdatedBefore.UTC())
+	}
+	if opts.Search != nil {
+		// like is only case insensitive for ascii characters in SQLite
+		add(
+			"(tasks.title LIKE ?%[1]d ESCAPE '\\' OR "+
+				"tasks.description LIKE ?%[1]d ESCAPE '\\')",
+			"%"+escapeLikePattern(*opts.Search)+"%",
+		)
+	}
+
+	var col string
+	switch opts.SortBy {
+	case tasks.SortByDateTimeUpdated:
+		col = "tasks.date_time_updated"
+	case tasks.SortByTitle:
+		col = `tasks.title`
+	case tasks.SortByStatus:
+		col = "tasks.status"
+	default:
+		col = "tasks.date_time_created"
+	}
+	dir := "ASC"
+	if opts.Descending {
+		dir = "DESC"
+	}
+
+	if opts.After != nil {
+		var val interface{}
+		switch opts.SortBy {
+		case tasks.SortByDateTimeUpdated:
+			val = opts.After.DateTimeUpdated.UTC()
+		case tasks.SortByTitle:
+			val = opts.After.Title
+		case tasks.SortByStatus:
+			val = opts.After.Status
+		default:
+			val = opts.After.DateTimeCreated.UTC()
+		}
+		op := ">"
+		if opts.Descending {
+			op = "<"
+		}
+		conds = append(conds, fmt.Sprintf(
+			"(%s, tasks.id) %s (?%d, ?%d)",
+			col,
+			op,
+			param(val),
+			param(opts.After.Id),
+		))
+	}
+
+	where := ""
+	if len(conds) != 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	return from, where, col + " " + dir + ", tasks.id " + dir, vals
+}
+
+// escapeLikePattern escapes the wildcards of a like pattern so the text is
+// matched as is
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"%", `\%`,
+		"_", `\_`,
+	).Replace(text)
+}
+
+// - Queries
+const (
+	InsertTaskReadModelQuery = `
+	INSERT INTO tasks (
+		id,
+		title,
+		description,
+		status,
+		random_map,
+		metadata,
+		version,
+		date_time_created,
+		date_time_updated,
+		due_date,
+		parent_id
+	) VALUES (
+		?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11
+	) RETURNING *
+	`
+
+	DeleteTaskReadModelQuery = `
+	DELETE FROM tasks WHERE id = ?1 AND version = ?2 RETURNING *
+	`
+
+	SelectTaskByIdQuery = `
+	SELECT * FROM tasks WHERE id = ?1
+	`
+
+	ListTasksQuery = `
+	SELECT tasks.* FROM %s %s ORDER BY %s LIMIT ?1 OFFSET ?2
+	`
+
+	CountTasksQuery = `
+	SELECT COUNT(*) FROM %s %s
+	`
+
+	ListTasksACLJoin = `tasks JOIN acl ON acl.stream = ?%d
+		AND acl.stream_id = tasks.id
+		AND acl.user_type = ?%d AND acl.user_id = ?%d
+		AND (acl.permissions & ?%d) != 0`
+
+	ListTaskEventsQuery = `
+	SELECT * FROM events
+	WHERE stream = ?1 AND stream_id = ?2
+	ORDER BY version LIMIT ?3 OFFSET ?4
+	`
+
+	ListTaskEventsAfterQuery = `
+	SELECT * FROM events WHERE stream = ?1 AND id > ?2 ORDER BY id LIMIT ?3
+	`
+
+	ListTaskEventsUntilQuery = `
+	SELECT * FROM events
+	WHERE stream = ?1 AND stream_id = ?2 AND version >= ?3
+		AND (?4 IS NULL OR version <= ?4)
+		AND (?5 IS NULL OR event_time <= ?5)
+	ORDER BY version
+	`
+
+	UpdateTaskQuery = `
+	UPDATE tasks SET %s, version = ?3, date_time_updated = ?4
+	WHERE id = ?1 AND version = ?2 RETURNING *
+	`
+
+	UpdateTaskVersionQuery = `
+	UPDATE tasks SET version = ?3, date_time_updated = ?4
+	WHERE id = ?1 AND version = ?2 RETURNING *
+	`
+
+	InsertTaskDependencyQuery = `
+	INSERT INTO task_dependencies (stream_id, blocker_id) VALUES (?1, ?2)
+	`
+
+	DeleteTaskDependencyQuery = `
+	DELETE FROM task_dependencies WHERE stream_id = ?1 AND blocker_id = ?2
+	`
+
+	DeleteTaskDependenciesQuery = `
+	DELETE FROM task_dependencies WHERE stream_id = ?1 OR blocker_id = ?1
+	`
+
+	ListTaskBlockersQuery = `
+	SELECT blocker_id FROM task_dependencies
+	WHERE stream_id = ?1 ORDER BY blocker_id
+	`
+
+	ListBlockedTasksQuery = `
+	SELECT stream_id FROM task_dependencies
+	WHERE blocker_id = ?1 ORDER BY stream_id
+	`
+
+	DeleteTaskSchedulesQuery = `
+	DELETE FROM task_schedules WHERE stream_id = ?1
+	`
+
+	InsertTaskScheduleQuery = `
+	INSERT INTO task_schedules (stream_id, event, fire_at) VALUES (?1, ?2, ?3)
+	`
+
+	ClaimDueTaskScheduleQuery = `
+	DELETE FROM task_schedules WHERE rowid = (
+		SELECT rowid FROM task_schedules
+		WHERE fire_at <= ?1
+		ORDER BY fire_at, stream_id
+		LIMIT 1
+	) RETURNING stream_id, event, fire_at
+	`
+)
@@ -0,0 +1,94 @@
+package repos
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+)
+
+// metadataCond the condition comparing the metadata value at the path
+// parameter with the value parameter
+func metadataCond(path int, val int) string {
+	ext := fmt.Sprintf(
+		"CAST(json_extract(CAST(tasks.metadata AS TEXT), ?%d) AS TEXT)",
+		path,
+	)
+	return fmt.Sprintf(
+		"(CASE json_type(CAST(tasks.metadata AS TEXT), ?%d)"+
+			" WHEN 'true' THEN 'true' WHEN 'false' THEN 'false'"+
+			" WHEN 'text' THEN %s WHEN 'integer' THEN %s WHEN 'real' THEN %s"+
+			" END) = ?%d",
+		path,
+		ext,
+		ext,
+		ext,
+		val,
+	)
+}
+
+func TestGenerateTasksFilterMetadata(t *testing.T) {
+	due := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		opts  tasks.ListOptions
+		pbeg  int
+		where string
+		vals  []interface{}
+	}{
+		{name: "none", pbeg: 1},
+		{
+			name: "values sorted by key",
+			opts: tasks.ListOptions{
+				Metadata: map[string]string{"b": "2", "a": "x"},
+			},
+			pbeg:  1,
+			where: "WHERE " + metadataCond(1, 2) + " AND " + metadataCond(3, 4),
+			vals:  []interface{}{`$."a"`, "x", `$."b"`, "2"},
+		},
+		{
+			name: "keys",
+			opts: tasks.ListOptions{MetadataKeys: []string{"a", "b"}},
+			pbeg: 3,
+			where: "WHERE json_type(CAST(tasks.metadata AS TEXT), ?3) IS NOT NULL" +
+				" AND json_type(CAST(tasks.metadata AS TEXT), ?4) IS NOT NULL",
+			vals: []interface{}{`$."a"`, `$."b"`},
+		},
+		{
+			name: "values and keys",
+			opts: tasks.ListOptions{
+				Metadata:     map[string]string{"a.b": "true"},
+				MetadataKeys: []string{"c"},
+			},
+			pbeg: 1,
+			where: "WHERE " + metadataCond(1, 2) +
+				" AND json_type(CAST(tasks.metadata AS TEXT), ?3) IS NOT NULL",
+			vals: []interface{}{`$."a.b"`, "true", `$."c"`},
+		},
+		{
+			name: "overdue",
+			opts: tasks.ListOptions{
+				OverdueAt:        &due,
+				TerminalStatuses: []string{"COMPLETED", "CANCELLED"},
+			},
+			pbeg: 1,
+			where: "WHERE tasks.due_date < ?1" +
+				" AND tasks.status NOT IN (?2,?3)",
+			vals: []interface{}{due, "COMPLETED", "CANCELLED"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, where, _, vals := generateTasksFilter(&c.opts, c.pbeg)
+			if where != c.where {
+				t.Fatalf("expected where %q, got %q", c.where, where)
+			}
+			if len(vals) != len(c.vals) ||
+				(len(vals) != 0 && !reflect.DeepEqual(vals, c.vals)) {
+				t.Fatalf("expected values %v, got %v", c.vals, vals)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package repos
+
+import (
+	"context"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/common"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/entities"
+
+	"go.uber.org/zap"
+)
+
+type UniquesRepository struct {
+	*BaseDataRepository
+	lgrf logger.IFactory
+}
+
+var _ uniques.IRepository = (*UniquesRepository)(nil)
+
+func NewUniquesRepository(
+	base *BaseDataRepository,
+	lgrf logger.IFactory,
+) *UniquesRepository {
+	return &UniquesRepository{
+		BaseDataRepository: base,
+		lgrf:               lgrf,
+	}
+}
+
+func (r *UniquesRepository) RegisterConstraint(
+	c context.Context,
+	stream string,
+	streamId string,
+	sagaId *string,
+	property string,
+	value string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	unqDao := entities.Unique{}
+	err = dbtx.Get(
+		ctx,
+		&unqDao,
+		InsertConstraintQuery,
+		stream,
+		streamId,
+		sagaId,
+		property,
+		value,
+	)
+	if err != nil {
+		if isConstraintViolation(err, uniqueValueConstraint) {
+			return domcom.NewUniqueConstraintViolationError(property)
+		}
+		lgr.Error("failed to insert unique constraint",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return r.recordSagaStep(ctx, dbtx, sagaId, 0)
+}
+
+func (r *UniquesRepository) RemoveConstraint(
+	c context.Context,
+	stream string,
+	streamId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteConstraintQuery,
+		stream,
+		streamId,
+	)
+	if err != nil {
+		lgr.Error("failed to delete unique constraint",
+			zap.Error(err),
+		)
+	}
+
+	return err
+}
+
+func (r *UniquesRepository) RemoveSagaConstraints(
+	c context.Context,
+	sagaId string,
+) error {
+	lgr := r.lgrf.Create(c)
+
+	ctx, ok := c.(cntxt.IContext)
+	if !ok {
+		lgr.Error("unexpected context type")
+		return common.NewFailedToAssertContextTypeError()
+	}
+
+	dbtx, err := r.getDBTx(ctx)
+	if err != nil {
+		lgr.Error("failed to get db transaction", zap.Error(err))
+		return err
+	}
+
+	_, err = dbtx.Exec(
+		ctx,
+		DeleteSagaConstraintsQuery,
+		sagaId,
+	)
+	if err != nil {
+		lgr.Error("failed to delete saga unique constraints",
+			zap.Error(err),
+		)
+	}
+
+	return err
+}
+
+const (
+	// columns reported by SQLite when uniques_value_unique is violated
+	uniqueValueConstraint = "uniques.stream, uniques.property, uniques.value"
+
+	InsertConstraintQuery = `
+	INSERT INTO uniques(
+		stream,
+		stream_id,
+		saga_id,
+		property,
+		value
+	) VALUES(
+		?1, ?2, ?3, ?4, ?5
+	) RETURNING *
+	`
+	DeleteConstraintQuery = `
+	DELETE FROM uniques
+	WHERE stream = ?1 AND stream_id = ?2
+	`
+	DeleteSagaConstraintsQuery = `
+	DELETE FROM uniques
+	WHERE saga_id = ?1
+	`
+)
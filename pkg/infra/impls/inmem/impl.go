@@ -1,4 +1,4 @@
-// Package inmem Event source CQRS implementation of the domain layer
+// Package inmem in memory implementation of the domain layer
 package inmem
 
 import (
@@ -7,7 +7,9 @@ import (
 
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/impl"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/trace"
@@ -15,12 +17,14 @@ import (
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/config"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/inmem/repos"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/jsonschema"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
 	"techunicorn.com/udc-core/prototodo/pkg/infra/snowflake"
 
-	"github.com/betalixt/gorr"
 	"github.com/google/wire"
 )
 
@@ -41,8 +45,24 @@ var DependencySet = wire.NewSet(
 	),
 	snowflake.NewSnowflake,
 	config.NewSnowflakeOptions,
+	config.NewTasksOptions,
+	jsonschema.NewValidator,
+	config.NewMetadataSchemaOptions,
+	wire.Bind(
+		new(tasks.IMetadataValidator),
+		new(*jsonschema.Validator),
+	),
+
+	// Events
+	eventhub.NewHub,
+	wire.Bind(
+		new(events.INotifier),
+		new(*eventhub.Hub),
+	),
+	config.NewEventHubOptions,
 
 	// Repos
+	repos.NewMemoryStore,
 	repos.NewACLRepository,
 	wire.Bind(
 		new(acl.IRepository),
@@ -78,6 +98,17 @@ var DependencySet = wire.NewSet(
 		new(quotes.IRepository),
 		new(*repos.QuotesRepository),
 	),
+	repos.NewSagasRepository,
+	wire.Bind(
+		new(sagas.IRepository),
+		new(*repos.SagasRepository),
+	),
+	repos.NewIdempotencyRepository,
+	config.NewInMemIdempotencyOptions,
+	wire.Bind(
+		new(idempotency.IRepository),
+		new(*repos.IdempotencyRepository),
+	),
 
 	NewBadTracer,
 	wire.Bind(
@@ -109,21 +140,35 @@ func (_ *badTracer) TraceRequest(
 
 // Implementation used for graceful starting and stopping of the implementation
 // layer
-type Implementation struct{}
+type Implementation struct {
+	lgrf *lgr.LoggerFactory
+	hub  *eventhub.Hub
+}
 
 // NewImplementation constructor for the inmem implementation
-func NewImplementation() *Implementation {
-	return &Implementation{}
+func NewImplementation(
+	lgrf *lgr.LoggerFactory,
+	hub *eventhub.Hub,
+) *Implementation {
+	return &Implementation{
+		lgrf: lgrf,
+		hub:  hub,
+	}
 }
 
 // Start runs any routines that are required before the implemtation layer can
-// be utilized
+// be utilized, everything is kept in memory so there is nothing to prepare
 func (i *Implementation) Start(ctx context.Context) error {
-	return gorr.NewNotImplemented()
+	lgri := i.lgrf.Create(ctx)
+	lgri.Warn("using the in memory implementation, data will not be persisted")
+	i.hub.Start()
+	return nil
 }
 
 // Stop runs any routines that are required for the implementation layer to
 // gracefully shutdown
 func (i *Implementation) Stop(ctx context.Context) error {
-	return gorr.NewNotImplemented()
+	i.hub.Stop()
+	i.lgrf.Close()
+	return nil
 }
@@ -3,45 +3,86 @@ package repos
 import (
 	"context"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
-
-	"github.com/betalixt/gorr"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 )
 
-type ACLRepository struct{}
+type ACLRepository struct {
+	store *MemoryStore
+}
 
 var _ acl.IRepository = (*ACLRepository)(nil)
 
-func NewACLRepository() *ACLRepository {
-	return &ACLRepository{}
+func NewACLRepository(
+	store *MemoryStore,
+) *ACLRepository {
+	return &ACLRepository{
+		store: store,
+	}
 }
 
 func (r *ACLRepository) CreateACLEntry(
-	c context.Context,
+	ctx context.Context,
 	stream string,
 	streamID string,
 	userType string,
 	userID string,
 	permissions int,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := aclKey{stream, streamID, userType, userID}
+		if _, ok := st.acl[key]; ok {
+			return domcom.NewACLEntryExistsError()
+		}
+		st.acl[key] = permissions
+		return nil
+	})
 }
 
 func (r *ACLRepository) DeleteACLEntry(
-	c context.Context,
+	ctx context.Context,
 	stream string,
 	streamID string,
 	userType string,
 	userID string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := aclKey{stream, streamID, userType, userID}
+		if _, ok := st.acl[key]; !ok {
+			return domcom.NewACLEntryMissingError()
+		}
+		delete(st.acl, key)
+		return nil
+	})
 }
 
 func (r *ACLRepository) DeleteACLEntries(
-	c context.Context,
+	ctx context.Context,
 	stream string,
 	streamID string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		for key := range st.acl {
+			if key.stream == stream && key.streamID == streamID {
+				delete(st.acl, key)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ACLRepository) GetPermissions(
+	ctx context.Context,
+	stream string,
+	streamID string,
+	userType string,
+	userID string,
+) (int, error) {
+	perm := 0
+	err := r.store.read(ctx, func(st *state) error {
+		perm = st.acl[aclKey{stream, streamID, userType, userID}]
+		return nil
+	})
+	return perm, err
 }
 
 func (r *ACLRepository) CanRead(
@@ -51,7 +92,7 @@ func (r *ACLRepository) CanRead(
 	userType string,
 	userID string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.check(ctx, stream, streamIDs, userType, userID, acl.Read)
 }
 
 func (r *ACLRepository) CanWrite(
@@ -61,5 +102,37 @@ func (r *ACLRepository) CanWrite(
 	userType string,
 	userID string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.check(ctx, stream, streamIDs, userType, userID, acl.Write)
+}
+
+func (r *ACLRepository) CanShare(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+) error {
+	return r.check(ctx, stream, streamIDs, userType, userID, acl.Share)
+}
+
+// check checks that the user has the permission on all of the entities, a
+// missing entry grants no permissions
+func (r *ACLRepository) check(
+	ctx context.Context,
+	stream string,
+	streamIDs []string,
+	userType string,
+	userID string,
+	permission int,
+) error {
+	return r.store.read(ctx, func(st *state) error {
+		perm := acl.Read | acl.Write | acl.Share
+		for _, id := range streamIDs {
+			perm &= st.acl[aclKey{stream, id, userType, userID}]
+		}
+		if len(streamIDs) == 0 || perm&permission == 0 {
+			return domcom.NewUserACLCheckFailedError()
+		}
+		return nil
+	})
 }
@@ -0,0 +1,29 @@
+package repos
+
+import (
+	"testing"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/impls/conformance"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/lgr"
+)
+
+func TestConformance(t *testing.T) {
+	lgrf, err := lgr.NewLoggerFactory()
+	if err != nil {
+		t.Fatalf("failed to create logger factory: %v", err)
+	}
+	store := NewMemoryStore()
+	conformance.Run(t, &conformance.Subject{
+		Contexts: NewContextFactory(
+			lgrf,
+			eventhub.NewHub(&eventhub.Options{PollInterval: time.Minute}),
+		),
+		Tasks:    NewTasksRepository(store),
+		Quotes:   NewQuotesRepository(store),
+		ACL:      NewACLRepository(store),
+		Uniques:  NewUniquesRepository(store),
+		Foreigns: NewForeignsRepository(store),
+	})
+}
@@ -2,27 +2,57 @@ package repos
 
 import (
 	"context"
+	"fmt"
 	domcntxt "techunicorn.com/udc-core/prototodo/pkg/domain/base/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/logger"
 	infrcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/cntxt"
+	"techunicorn.com/udc-core/prototodo/pkg/infra/eventhub"
 	implcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"sync"
 	"time"
 
-	"github.com/betalixt/gorr"
+	"go.uber.org/zap"
 )
 
 // ContextFactory to create new contexts
-type ContextFactory struct{}
+type ContextFactory struct {
+	lgrf logger.IFactory
+	hub  *eventhub.Hub
+}
 
 // NewContextFactory constructor for context factory
-func NewContextFactory() *ContextFactory {
-	return &ContextFactory{}
+func NewContextFactory(
+	lgrf logger.IFactory,
+	hub *eventhub.Hub,
+) *ContextFactory {
+	return &ContextFactory{
+		lgrf: lgrf,
+		hub:  hub,
+	}
 }
 
-// Create creates a new context with timeout, transactions and trace info
+// Create creates a new context with timeout and transactions
 func (f *ContextFactory) Create(
 	string,
 ) domcntxt.IContext {
-	c := &internalContext{}
+	c := &internalContext{
+		lgrf: f.lgrf,
+
+		cancelmtx: &sync.Mutex{},
+		err:       nil,
+		done:      make(chan struct{}, 1),
+		values:    map[any]any{},
+		valmtx:    &sync.RWMutex{},
+
+		compensatoryActions: []implcntxt.Action{},
+		commitActions:       []implcntxt.Action{},
+		streams:             []string{},
+		txObjs:              map[string]interface{}{},
+		isCommited:          false,
+		isRolledback:        false,
+		txmtx:               &sync.Mutex{},
+		hub:                 f.hub,
+	}
 	return c
 }
 
@@ -33,13 +63,43 @@ var (
 	_ implcntxt.IContext = (*internalContext)(nil)
 )
 
-type internalContext struct{}
+type internalContext struct {
+	lgrf      logger.IFactory
+	cancelmtx *sync.Mutex
+	err       error
+	done      chan struct{}
+	dur       time.Time
+	values    map[any]any
+	valmtx    *sync.RWMutex
+
+	// - transaction
+	compensatoryActions []implcntxt.Action
+	commitActions       []implcntxt.Action
+	streams             []string
+	txObjs              map[string]interface{}
+	isCommited          bool
+	isRolledback        bool
+	txmtx               *sync.Mutex
+	hub                 *eventhub.Hub
+}
 
 // - Base context functions
 func (c *internalContext) cancel(err error) {
+	// done is closed before rolling back so that a transaction waiting to
+	// begin gives up instead of holding up the rollback
+	c.cancelmtx.Lock()
+	if c.err != nil {
+		c.cancelmtx.Unlock()
+		return
+	}
+	c.err = err
+	close(c.done)
+	c.cancelmtx.Unlock()
+	c.RollbackTransaction()
 }
 
 func (c *internalContext) Cancel() {
+	c.cancel(fmt.Errorf("context manually canceled"))
 }
 
 func (c *internalContext) Deadline() (time.Time, bool) {
@@ -47,39 +107,101 @@ func (c *internalContext) Deadline() (time.Time, bool) {
 }
 
 func (c *internalContext) Done() <-chan struct{} {
-	return nil
+	return c.done
 }
 
 func (c *internalContext) Err() error {
-	return nil
+	c.cancelmtx.Lock()
+	defer c.cancelmtx.Unlock()
+	return c.err
 }
 
 func (c *internalContext) Value(key any) any {
-	return nil
+	c.valmtx.RLock()
+	defer c.valmtx.RUnlock()
+	return c.values[key]
+}
+
+func (c *internalContext) SetValue(key, value any) {
+	c.valmtx.Lock()
+	defer c.valmtx.Unlock()
+	c.values[key] = value
 }
 
 // - Transaction functions
-func (c *internalContext) SetTimeout(time.Duration) {
+func (c *internalContext) SetTimeout(timeout time.Duration) {
+	c.dur = time.Now().Add(timeout)
+	time.AfterFunc(
+		time.Until(c.dur),
+		func() {
+			c.cancel(context.DeadlineExceeded)
+		},
+	)
 }
 
 func (c *internalContext) CommitTransaction() error {
-	return gorr.NewNotImplemented()
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	if c.isCommited || c.isRolledback {
+		return fmt.Errorf(
+			"tried to commit transaction that has already been commited/rolled back",
+		)
+	}
+	ctx := newMinimalContext(c)
+	for _, commit := range c.commitActions {
+		err := commit(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	c.isCommited = true
+
+	if c.hub != nil {
+		for _, stream := range c.streams {
+			c.hub.Publish(stream)
+		}
+	}
+	return nil
 }
 
-// TODO: better handling failed rollback transaction
+// RollbackTransaction discards the writes of the transaction, the memory store
+// can't fail to roll back so there is nothing to retry
 func (c *internalContext) RollbackTransaction() {
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	if c.isCommited || c.isRolledback {
+		return
+	}
+
+	c.isRolledback = true
+	ctx := newMinimalContext(c)
+	lgr := c.lgrf.Create(ctx)
+	for _, cmp := range c.compensatoryActions {
+		err := cmp(ctx)
+		if err != nil {
+			lgr.Error("failed to run compensatory action", zap.Error(err))
+		}
+	}
 }
 
 func (c *internalContext) RegisterCompensatoryAction(
 	cmp ...implcntxt.Action,
 ) {
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	c.compensatoryActions = append(c.compensatoryActions, cmp...)
 }
 
 func (c *internalContext) RegisterCommitAction(
 	cmp ...implcntxt.Action,
 ) {
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	c.commitActions = append(c.commitActions, cmp...)
 }
 
+// RegisterEvent the events are already part of the state of the transaction,
+// only the streams are kept to notify subscribers on commit
 func (c *internalContext) RegisterEvent(
 	id uint64,
 	sagaID *string,
@@ -90,13 +212,32 @@ func (c *internalContext) RegisterEvent(
 	eventTime time.Time,
 	data interface{},
 ) {
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	for _, s := range c.streams {
+		if s == stream {
+			return
+		}
+	}
+	c.streams = append(c.streams, stream)
 }
 
 func (c *internalContext) GetTransactionObject(
 	key string,
 	constr implcntxt.Constructor,
 ) (interface{}, bool, error) {
-	return nil, false, gorr.NewNotImplemented()
+	c.txmtx.Lock()
+	defer c.txmtx.Unlock()
+	intr, ok := c.txObjs[key]
+	if ok {
+		return intr, false, nil
+	}
+	intr, err := constr()
+	if err != nil {
+		return nil, false, err
+	}
+	c.txObjs[key] = intr
+	return intr, true, nil
 }
 
 func (c *internalContext) GetTraceInfo() (ver, tid, pid, rid, flg string) {
@@ -111,10 +252,14 @@ var (
 )
 
 func newMinimalContext(ctx *internalContext) *minimalContext {
-	return &minimalContext{}
+	return &minimalContext{
+		done: make(chan struct{}, 1),
+	}
 }
 
-type minimalContext struct{}
+type minimalContext struct {
+	done chan struct{}
+}
 
 // - Base context functions
 func (c *minimalContext) Deadline() (time.Time, bool) {
@@ -122,7 +267,7 @@ func (c *minimalContext) Deadline() (time.Time, bool) {
 }
 
 func (c *minimalContext) Done() <-chan struct{} {
-	return nil
+	return c.done
 }
 
 func (c *minimalContext) Err() error {
@@ -2,61 +2,183 @@ package repos
 
 import (
 	"context"
+	"sort"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/foreigns"
-
-	"github.com/betalixt/gorr"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 )
 
-type ForeignsRepository struct{}
+type ForeignsRepository struct {
+	store *MemoryStore
+}
 
 var _ foreigns.IRepository = (*ForeignsRepository)(nil)
 
-func NewForeignsRepository() *ForeignsRepository {
-	return &ForeignsRepository{}
+func NewForeignsRepository(
+	store *MemoryStore,
+) *ForeignsRepository {
+	return &ForeignsRepository{
+		store: store,
+	}
 }
 
 func (r *ForeignsRepository) RegisterForeignItem(
-	c context.Context,
+	ctx context.Context,
 	sagaId *string,
 	foreignStream string,
 	foreignStreamId string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := streamKey{foreignStream, foreignStreamId}
+		if _, ok := st.foreigns[key]; ok {
+			return domcom.NewForeignItemExistsError()
+		}
+		err := st.checkSaga(sagaId)
+		if err != nil {
+			return err
+		}
+		st.foreigns[key] = copyString(sagaId)
+		st.recordSagaStep(sagaId, 0)
+		return nil
+	})
+}
+
+func (r *ForeignsRepository) EnsureForeignItem(
+	ctx context.Context,
+	sagaId *string,
+	foreignStream string,
+	foreignStreamId string,
+) error {
+	return r.store.write(ctx, func(st *state) error {
+		key := streamKey{foreignStream, foreignStreamId}
+		if _, ok := st.foreigns[key]; ok {
+			return nil
+		}
+		err := st.checkSaga(sagaId)
+		if err != nil {
+			return err
+		}
+		st.foreigns[key] = copyString(sagaId)
+		st.recordSagaStep(sagaId, 0)
+		return nil
+	})
 }
 
 func (r *ForeignsRepository) RemoveForeignItem(
-	c context.Context,
+	ctx context.Context,
 	foreignStream string,
 	foreignStreamId string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := streamKey{foreignStream, foreignStreamId}
+		for cnst := range st.constraints {
+			if cnst.foreign == key {
+				return domcom.NewForeignItemInUseError()
+			}
+		}
+		delete(st.foreigns, key)
+		return nil
+	})
 }
 
 func (r *ForeignsRepository) RegisterConstraint(
-	c context.Context,
+	ctx context.Context,
 	sagaId *string,
 	foreignStream string,
 	foreignStreamId string,
 	stream string,
 	streamId string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := constraintKey{
+			foreign: streamKey{foreignStream, foreignStreamId},
+			object:  streamKey{stream, streamId},
+		}
+		if _, ok := st.foreigns[key.foreign]; !ok {
+			return domcom.NewForeignItemMissingError()
+		}
+		if _, ok := st.constraints[key]; ok {
+			return domcom.NewForeignConstraintExistsError()
+		}
+		err := st.checkSaga(sagaId)
+		if err != nil {
+			return err
+		}
+		st.constraints[key] = copyString(sagaId)
+		st.recordSagaStep(sagaId, 0)
+		return nil
+	})
 }
 
 func (r *ForeignsRepository) RemoveConstraint(
-	c context.Context,
+	ctx context.Context,
 	foreignStream string,
 	foreignStreamId string,
 	stream string,
 	streamId string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		delete(st.constraints, constraintKey{
+			foreign: streamKey{foreignStream, foreignStreamId},
+			object:  streamKey{stream, streamId},
+		})
+		return nil
+	})
+}
+
+func (r *ForeignsRepository) RemoveSagaEntries(
+	ctx context.Context,
+	sagaId string,
+) error {
+	return r.store.write(ctx, func(st *state) error {
+		// the items of the saga can only be removed if the only constraints
+		// referencing them are the ones of the saga
+		for key, saga := range st.constraints {
+			if saga != nil && *saga == sagaId {
+				continue
+			}
+			if item, ok := st.foreigns[key.foreign]; ok &&
+				item != nil && *item == sagaId {
+				return domcom.NewForeignItemInUseError()
+			}
+		}
+		for key, saga := range st.constraints {
+			if saga != nil && *saga == sagaId {
+				delete(st.constraints, key)
+			}
+		}
+		for key, saga := range st.foreigns {
+			if saga != nil && *saga == sagaId {
+				delete(st.foreigns, key)
+			}
+		}
+		return nil
+	})
 }
 
 func (r *ForeignsRepository) ListAssociatedObjects(
-	c context.Context,
+	ctx context.Context,
 	foreignStream string,
 	foreignStreamId string,
 ) ([]foreigns.Object, error) {
-	return nil, gorr.NewNotImplemented()
+	var res []foreigns.Object
+	err := r.store.read(ctx, func(st *state) error {
+		key := streamKey{foreignStream, foreignStreamId}
+		res = []foreigns.Object{}
+		for cnst := range st.constraints {
+			if cnst.foreign == key {
+				res = append(res, foreigns.Object{
+					Stream:   cnst.object.stream,
+					StreamId: cnst.object.streamID,
+				})
+			}
+		}
+		sort.Slice(res, func(i, j int) bool {
+			if res[i].Stream == res[j].Stream {
+				return res[i].StreamId < res[j].StreamId
+			}
+			return res[i].Stream < res[j].Stream
+		})
+		return nil
+	})
+	return res, err
 }
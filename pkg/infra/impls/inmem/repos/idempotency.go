@@ -0,0 +1,77 @@
+package repos
+
+import (
+	"context"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"time"
+)
+
+// IdempotencyOptions how long the results of commands are kept for replaying
+type IdempotencyOptions struct {
+	Expiry time.Duration
+}
+
+// IdempotencyRepository repository implementation for idempotency keys
+type IdempotencyRepository struct {
+	store *MemoryStore
+	opts  *IdempotencyOptions
+}
+
+// NewIdempotencyRepository creates new IdempotencyRepository
+func NewIdempotencyRepository(
+	store *MemoryStore,
+	opts *IdempotencyOptions,
+) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		store: store,
+		opts:  opts,
+	}
+}
+
+var _ idempotency.IRepository = (*IdempotencyRepository)(nil)
+
+// Get fetches the unexpired record of a key used by a user
+func (r *IdempotencyRepository) Get(
+	ctx context.Context,
+	userType string,
+	userID string,
+	key string,
+) (*idempotency.Record, error) {
+	var res *idempotency.Record
+	err := r.store.read(ctx, func(st *state) error {
+		rec, ok := st.idempotency[idempotencyKey{userType, userID, key}]
+		if ok && rec.DateTimeExpires.After(time.Now()) {
+			res = &rec
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Save stores the result of a command, expired keys of the user are cleared
+// out so that they can be reused
+func (r *IdempotencyRepository) Save(
+	ctx context.Context,
+	record idempotency.Record,
+) error {
+	return r.store.write(ctx, func(st *state) error {
+		now := time.Now().UTC()
+		for key, rec := range st.idempotency {
+			if key.userType == record.UserType &&
+				key.userID == record.UserId &&
+				!rec.DateTimeExpires.After(now) {
+				delete(st.idempotency, key)
+			}
+		}
+
+		key := idempotencyKey{record.UserType, record.UserId, record.Key}
+		if _, ok := st.idempotency[key]; ok {
+			return domcom.NewIdempotencyKeyInUseError()
+		}
+		record.DateTimeCreated = now
+		record.DateTimeExpires = now.Add(r.opts.Expiry)
+		st.idempotency[key] = record
+		return nil
+	})
+}
@@ -2,34 +2,118 @@ package repos
 
 import (
 	"context"
+	"math/rand"
+	"sort"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
-
-	"github.com/betalixt/gorr"
 )
 
 // QuotesRepository repository implementation for quotes
-type QuotesRepository struct{}
+type QuotesRepository struct {
+	store *MemoryStore
+}
 
 // NewQuotesRepository creates QuotesRepository
-func NewQuotesRepository() *QuotesRepository {
-	return &QuotesRepository{}
+func NewQuotesRepository(
+	store *MemoryStore,
+) *QuotesRepository {
+	return &QuotesRepository{
+		store: store,
+	}
 }
 
 var _ quotes.IRepository = (*QuotesRepository)(nil)
 
 // Create a quote
 func (r *QuotesRepository) Create(
-	c context.Context,
+	ctx context.Context,
 	id string,
 	sagaID *string,
 	quote string,
 ) (*quotes.QuoteEvent, error) {
-	return nil, gorr.NewNotImplemented()
+	var res *quotes.QuoteEvent
+	err := r.store.write(ctx, func(st *state) error {
+		evnt, err := st.insertEvent(
+			sagaID,
+			domcom.QuoteStreamName,
+			id,
+			0,
+			domcom.EventCreated,
+			quotes.QuoteData{
+				Quote: &quote,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		res = toQuoteEvent(evnt)
+		st.quotes[id] = quotes.Quote{
+			Id:              id,
+			Quote:           quote,
+			Version:         evnt.Version,
+			DateTimeCreated: evnt.EventTime,
+			DateTimeUpdated: evnt.EventTime,
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Delete deletes an existing quote
+func (r *QuotesRepository) Delete(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+) (*quotes.QuoteEvent, error) {
+	var res *quotes.QuoteEvent
+	err := r.store.write(ctx, func(st *state) error {
+		if _, ok := st.quotes[id]; !ok {
+			return domcom.NewQuoteMissingError()
+		}
+		evnt, err := st.insertEvent(
+			sagaID,
+			domcom.QuoteStreamName,
+			id,
+			version,
+			domcom.EventDeleted,
+			quotes.QuoteData{},
+		)
+		if err != nil {
+			return err
+		}
+		res = toQuoteEvent(evnt)
+		delete(st.quotes, id)
+		return nil
+	})
+	return res, err
 }
 
 // GetRandom Fetch a random quote
 func (r *QuotesRepository) GetRandom(
 	ctx context.Context,
 ) (*quotes.Quote, error) {
-	return nil, gorr.NewNotImplemented()
+	var res *quotes.Quote
+	err := r.store.read(ctx, func(st *state) error {
+		if len(st.quotes) == 0 {
+			return domcom.NewQuoteMissingError()
+		}
+		ids := make([]string, 0, len(st.quotes))
+		for id := range st.quotes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		quote := st.quotes[ids[rand.Intn(len(ids))]]
+		res = &quote
+		return nil
+	})
+	return res, err
+}
+
+func toQuoteEvent(evnt *storedEvent) *quotes.QuoteEvent {
+	data, _ := evnt.data.(quotes.QuoteData)
+	return &quotes.QuoteEvent{
+		EventEntity: evnt.EventEntity,
+		Data:        data,
+	}
 }
@@ -0,0 +1,84 @@
+package repos
+
+import (
+	"context"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
+	"time"
+)
+
+// SagasRepository repository implementation for saga state, the state itself
+// is recorded by the store as entities are written under a saga
+type SagasRepository struct {
+	store *MemoryStore
+}
+
+// NewSagasRepository creates new SagasRepository
+func NewSagasRepository(
+	store *MemoryStore,
+) *SagasRepository {
+	return &SagasRepository{
+		store: store,
+	}
+}
+
+var _ sagas.IRepository = (*SagasRepository)(nil)
+
+// Get fetches the state of a saga
+func (r *SagasRepository) Get(
+	ctx context.Context,
+	sagaID string,
+) (*sagas.Saga, error) {
+	var res *sagas.Saga
+	err := r.store.read(ctx, func(st *state) error {
+		saga, ok := st.sagas[sagaID]
+		if !ok {
+			return domcom.NewSagaMissingError()
+		}
+		res = &saga
+		return nil
+	})
+	return res, err
+}
+
+// ListEvents lists all events written under a saga ordered by event id
+func (r *SagasRepository) ListEvents(
+	ctx context.Context,
+	sagaID string,
+) ([]events.EventEntity, error) {
+	var res []events.EventEntity
+	err := r.store.read(ctx, func(st *state) error {
+		res = []events.EventEntity{}
+		for idx := range st.events {
+			if st.events[idx].SagaId != nil &&
+				*st.events[idx].SagaId == sagaID {
+				res = append(res, st.events[idx].EventEntity)
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+// MarkCompensated marks an active saga as compensated
+func (r *SagasRepository) MarkCompensated(
+	ctx context.Context,
+	sagaID string,
+) (*sagas.Saga, error) {
+	var res *sagas.Saga
+	err := r.store.write(ctx, func(st *state) error {
+		saga, ok := st.sagas[sagaID]
+		if !ok || saga.Status != domcom.SagaStatusActive {
+			return domcom.NewSagaCompensatedError()
+		}
+		now := time.Now().UTC()
+		saga.Status = domcom.SagaStatusCompensated
+		saga.DateTimeUpdated = now
+		saga.DateTimeCompensated = &now
+		st.sagas[sagaID] = saga
+		res = &saga
+		return nil
+	})
+	return res, err
+}
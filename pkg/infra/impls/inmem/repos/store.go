@@ -0,0 +1,454 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/events"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/idempotency"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/quotes"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/unions/sagas"
+	implcntxt "techunicorn.com/udc-core/prototodo/pkg/infra/impls/evcqrs/cntxt"
+	"time"
+)
+
+// memoryTransactionObjectKey key of the transaction in the context
+const memoryTransactionObjectKey = "memory-transaction"
+
+// errNoTransaction returned to avoid starting a transaction for reads
+var errNoTransaction = errors.New("context has no transaction")
+
+// MemoryStore holds all of the data of the in memory implementation, the
+// events are the source of truth and the read models are projected along with
+// every event that is written
+type MemoryStore struct {
+	mtx   *sync.RWMutex
+	state *state
+
+	// only a single transaction can write at a time, the transaction holds the
+	// semaphore from its first write until it is commited or rolled back
+	wsem chan struct{}
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mtx:   &sync.RWMutex{},
+		state: newState(),
+		wsem:  make(chan struct{}, 1),
+	}
+}
+
+// read runs fn with the current state, fn must not change the state. Reads
+// made in a context with an open transaction see the writes of the
+// transaction
+func (s *MemoryStore) read(
+	ctx context.Context,
+	fn func(st *state) error,
+) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if ictx, ok := ctx.(implcntxt.IContext); ok {
+		tx, err := s.getTx(ictx, false)
+		if err != nil {
+			return err
+		}
+		if tx != nil {
+			tx.mtx.Lock()
+			defer tx.mtx.Unlock()
+			if !tx.closed {
+				return fn(tx.state)
+			}
+		}
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return fn(s.state)
+}
+
+// write runs fn with exclusive access to the state of the transaction of the
+// context, the changes are only made visible to other contexts once the
+// transaction is commited. Contexts without transactions write directly to
+// the store, fn is expected to validate everything before it starts making
+// changes
+func (s *MemoryStore) write(
+	ctx context.Context,
+	fn func(st *state) error,
+) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	ictx, ok := ctx.(implcntxt.IContext)
+	if !ok {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		return fn(s.state)
+	}
+
+	tx, err := s.getTx(ictx, true)
+	if err != nil {
+		return err
+	}
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.closed {
+		return fmt.Errorf("transaction has already been commited/rolled back")
+	}
+	count := len(tx.state.events)
+	err = fn(tx.state)
+	if err != nil {
+		return err
+	}
+	for _, evnt := range tx.state.events[count:] {
+		ictx.RegisterEvent(
+			evnt.Id,
+			evnt.SagaId,
+			evnt.Stream,
+			evnt.StreamId,
+			evnt.Event,
+			evnt.Version,
+			evnt.EventTime,
+			evnt.data,
+		)
+	}
+	return nil
+}
+
+// memoryTx a transaction of a context, the writes are made to a copy of the
+// state that replaces the state of the store on commit
+type memoryTx struct {
+	mtx    *sync.Mutex
+	state  *state
+	closed bool
+}
+
+// getTx gets the transaction of the context, a new transaction is only
+// started if begin is set otherwise nil is returned when the context has no
+// transaction
+func (s *MemoryStore) getTx(
+	ctx implcntxt.IContext,
+	begin bool,
+) (*memoryTx, error) {
+	itx, nw, err := ctx.GetTransactionObject(
+		memoryTransactionObjectKey,
+		func() (interface{}, error) {
+			if !begin {
+				return nil, errNoTransaction
+			}
+			return s.begin(ctx)
+		},
+	)
+	if err == errNoTransaction {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx, ok := itx.(*memoryTx)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert memory transaction type")
+	}
+	if nw {
+		ctx.RegisterCommitAction(func(context.Context) error {
+			return s.commit(tx)
+		})
+		ctx.RegisterCompensatoryAction(func(context.Context) error {
+			s.rollback(tx)
+			return nil
+		})
+	}
+	return tx, nil
+}
+
+// begin waits for the running transaction to finish and starts a new one with
+// a copy of the current state
+func (s *MemoryStore) begin(ctx context.Context) (*memoryTx, error) {
+	select {
+	case s.wsem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return &memoryTx{
+		mtx:   &sync.Mutex{},
+		state: s.state.clone(),
+	}, nil
+}
+
+// commit makes the state of the transaction the state of the store
+func (s *MemoryStore) commit(tx *memoryTx) error {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.closed {
+		return fmt.Errorf("transaction has already been commited/rolled back")
+	}
+	tx.closed = true
+	s.mtx.Lock()
+	s.state = tx.state
+	s.mtx.Unlock()
+	<-s.wsem
+	return nil
+}
+
+// rollback discards the state of the transaction
+func (s *MemoryStore) rollback(tx *memoryTx) {
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+	if tx.closed {
+		return
+	}
+	tx.closed = true
+	tx.state = nil
+	<-s.wsem
+}
+
+type streamKey struct {
+	stream   string
+	streamID string
+}
+
+type aclKey struct {
+	stream   string
+	streamID string
+	userType string
+	userID   string
+}
+
+type uniqueKey struct {
+	stream   string
+	property string
+	value    string
+}
+
+type unique struct {
+	streamID string
+	sagaID   *string
+}
+
+type constraintKey struct {
+	foreign streamKey
+	object  streamKey
+}
+
+type scheduleKey struct {
+	taskID string
+	event  string
+}
+
+type dependencyKey struct {
+	taskID    string
+	blockerID string
+}
+
+type idempotencyKey struct {
+	userType string
+	userID   string
+	key      string
+}
+
+// storedEvent an event along with its data, the data is tasks.TaskData or
+// quotes.QuoteData depending on the stream
+type storedEvent struct {
+	events.EventEntity
+	data interface{}
+}
+
+// state everything stored by the in memory implementation
+type state struct {
+	lastEventID uint64
+	events      []storedEvent
+	versions    map[streamKey]uint64
+	tasks       map[string]tasks.Task
+	quotes      map[string]quotes.Quote
+	acl         map[aclKey]int
+	uniques     map[uniqueKey]unique
+	foreigns    map[streamKey]*string
+	constraints map[constraintKey]*string
+	sagas       map[string]sagas.Saga
+	idempotency map[idempotencyKey]idempotency.Record
+	schedules   map[scheduleKey]time.Time
+	blockers    map[dependencyKey]struct{}
+}
+
+// clone copies the state so that it can be changed without affecting the
+// original, the stored values are never changed in place so copying the maps
+// is sufficient
+func (st *state) clone() *state {
+	return &state{
+		lastEventID: st.lastEventID,
+		// capped so that appends never write into the original array
+		events:      st.events[:len(st.events):len(st.events)],
+		versions:    cloneMap(st.versions),
+		tasks:       cloneMap(st.tasks),
+		quotes:      cloneMap(st.quotes),
+		acl:         cloneMap(st.acl),
+		uniques:     cloneMap(st.uniques),
+		foreigns:    cloneMap(st.foreigns),
+		constraints: cloneMap(st.constraints),
+		sagas:       cloneMap(st.sagas),
+		idempotency: cloneMap(st.idempotency),
+		schedules:   cloneMap(st.schedules),
+		blockers:    cloneMap(st.blockers),
+	}
+}
+
+func newState() *state {
+	return &state{
+		events:      []storedEvent{},
+		versions:    map[streamKey]uint64{},
+		tasks:       map[string]tasks.Task{},
+		quotes:      map[string]quotes.Quote{},
+		acl:         map[aclKey]int{},
+		uniques:     map[uniqueKey]unique{},
+		foreigns:    map[streamKey]*string{},
+		constraints: map[constraintKey]*string{},
+		sagas:       map[string]sagas.Saga{},
+		idempotency: map[idempotencyKey]idempotency.Record{},
+		schedules:   map[scheduleKey]time.Time{},
+		blockers:    map[dependencyKey]struct{}{},
+	}
+}
+
+// checkVersion checks that the version is the next version of the stream, the
+// same rule the unique version constraint of the event store enforces
+func (st *state) checkVersion(stream, streamID string, version uint64) error {
+	cur, ok := st.versions[streamKey{stream, streamID}]
+	if (!ok && version == 0) || (ok && version == cur+1) {
+		return nil
+	}
+	return domcom.NewVersionConflictError(fmt.Sprintf(
+		"version %d can't be written to %s %s",
+		version,
+		stream,
+		streamID,
+	))
+}
+
+// checkSaga checks that writes can still be made under the saga
+func (st *state) checkSaga(sagaID *string) error {
+	if sagaID == nil {
+		return nil
+	}
+	if saga, ok := st.sagas[*sagaID]; ok &&
+		saga.Status != domcom.SagaStatusActive {
+		return domcom.NewSagaCompensatedError()
+	}
+	return nil
+}
+
+// insertEvent appends an event to the stream, the version and saga are
+// checked before anything is changed
+func (st *state) insertEvent(
+	sagaID *string,
+	stream string,
+	streamID string,
+	version uint64,
+	event string,
+	data interface{},
+) (*storedEvent, error) {
+	err := st.checkVersion(stream, streamID, version)
+	if err != nil {
+		return nil, err
+	}
+	err = st.checkSaga(sagaID)
+	if err != nil {
+		return nil, err
+	}
+
+	st.lastEventID++
+	evnt := storedEvent{
+		EventEntity: events.EventEntity{
+			Id:        st.lastEventID,
+			SagaId:    copyString(sagaID),
+			Stream:    stream,
+			StreamId:  streamID,
+			Event:     event,
+			Version:   version,
+			EventTime: time.Now().UTC(),
+		},
+		data: data,
+	}
+	st.events = append(st.events, evnt)
+	st.versions[streamKey{stream, streamID}] = version
+	st.recordSagaStep(sagaID, evnt.Id)
+	return &evnt, nil
+}
+
+// recordSagaStep records a write made under a saga, checkSaga is expected to
+// have been called before
+func (st *state) recordSagaStep(sagaID *string, eventID uint64) {
+	if sagaID == nil {
+		return
+	}
+	now := time.Now().UTC()
+	saga, ok := st.sagas[*sagaID]
+	if !ok {
+		saga = sagas.Saga{
+			SagaId:          *sagaID,
+			Status:          domcom.SagaStatusActive,
+			DateTimeCreated: now,
+		}
+	}
+	saga.StepCount++
+	if eventID > saga.LastEventId {
+		saga.LastEventId = eventID
+	}
+	saga.DateTimeUpdated = now
+	st.sagas[*sagaID] = saga
+}
+
+// eventsAfter gives the index of the first event with an id greater than the
+// given id
+func (st *state) eventsAfter(id uint64) int {
+	return sort.Search(len(st.events), func(idx int) bool {
+		return st.events[idx].Id > id
+	})
+}
+
+// streamEvents lists the events of a stream ordered by version
+func (st *state) streamEvents(stream, streamID string) []storedEvent {
+	evnts := []storedEvent{}
+	for idx := range st.events {
+		if st.events[idx].Stream == stream &&
+			st.events[idx].StreamId == streamID {
+			evnts = append(evnts, st.events[idx])
+		}
+	}
+	return evnts
+}
+
+// page gives the bounds of a page of a list of the given length
+func page(length, countPerPage, pageNumber int) (int, int) {
+	start := countPerPage * pageNumber
+	if start > length || start < 0 {
+		start = length
+	}
+	end := start + countPerPage
+	if end > length || countPerPage < 0 {
+		end = length
+	}
+	return start, end
+}
+
+func copyString(in *string) *string {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func cloneMap[K comparable, V any](in map[K]V) map[K]V {
+	out := make(map[K]V, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
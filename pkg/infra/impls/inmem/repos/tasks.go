@@ -2,29 +2,57 @@ package repos
 
 import (
 	"context"
+	"sort"
+	"time"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/base/acl"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
-
-	"github.com/betalixt/gorr"
 )
 
 // TasksRepository repository implimentation for tasks
-type TasksRepository struct{}
+type TasksRepository struct {
+	store *MemoryStore
+}
 
 // NewTasksRepository creates new TasksRepository
-func NewTasksRepository() *TasksRepository {
-	return &TasksRepository{}
+func NewTasksRepository(
+	store *MemoryStore,
+) *TasksRepository {
+	return &TasksRepository{
+		store: store,
+	}
 }
 
 var _ tasks.IRepository = (*TasksRepository)(nil)
 
 // Create creates a new task
 func (r *TasksRepository) Create(
-	c context.Context,
+	ctx context.Context,
 	id string,
 	sagaID *string,
 	data tasks.TaskData,
 ) (*tasks.TaskEvent, error) {
-	return nil, gorr.NewNotImplemented()
+	var res *tasks.TaskEvent
+	err := r.store.write(ctx, func(st *state) error {
+		evnt, err := st.insertEvent(
+			sagaID,
+			domcom.TaskStreamName,
+			id,
+			0,
+			domcom.EventCreated,
+			data,
+		)
+		if err != nil {
+			return err
+		}
+		res = toTaskEvent(evnt)
+
+		task := tasks.Task{}
+		task.Apply(res)
+		st.tasks[id] = task
+		return nil
+	})
+	return res, err
 }
 
 // Get fetches an exiting task
@@ -32,35 +60,533 @@ func (r *TasksRepository) Get(
 	ctx context.Context,
 	id string,
 ) (*tasks.Task, error) {
-	return nil, gorr.NewNotImplemented()
+	var res *tasks.Task
+	err := r.store.read(ctx, func(st *state) error {
+		task, ok := st.tasks[id]
+		if !ok {
+			return domcom.NewTaskMissingError()
+		}
+		res = &task
+		return nil
+	})
+	return res, err
 }
 
-// List gives a paged list of tasks
+// List gives a paged list of the tasks matching the options
 func (r *TasksRepository) List(
 	ctx context.Context,
+	opts tasks.ListOptions,
 	countPerPage int,
 	pageNumber int,
 ) ([]tasks.Task, error) {
-	return nil, gorr.NewNotImplemented()
+	var res []tasks.Task
+	err := r.store.read(ctx, func(st *state) error {
+		all := st.listTasks(&opts)
+		sort.Slice(all, func(i, j int) bool {
+			return opts.Less(&all[i], &all[j])
+		})
+		start, end := page(len(all), countPerPage, pageNumber)
+		res = all[start:end]
+		return nil
+	})
+	return res, err
+}
+
+// Count counts the tasks matching the filters of the options
+func (r *TasksRepository) Count(
+	ctx context.Context,
+	opts tasks.ListOptions,
+) (uint64, error) {
+	opts.After = nil
+	var res uint64
+	err := r.store.read(ctx, func(st *state) error {
+		res = uint64(len(st.listTasks(&opts)))
+		return nil
+	})
+	return res, err
+}
+
+// listTasks gives the tasks matching the options in no particular order
+func (st *state) listTasks(opts *tasks.ListOptions) []tasks.Task {
+	all := make([]tasks.Task, 0, len(st.tasks))
+	for id := range st.tasks {
+		task := st.tasks[id]
+		if opts.Reader != nil {
+			perm := st.acl[aclKey{
+				domcom.TaskStreamName,
+				id,
+				opts.Reader.UserType,
+				opts.Reader.UserID,
+			}]
+			if perm&acl.Read == 0 {
+				continue
+			}
+		}
+		if opts.Matches(&task) && opts.MatchesAfter(&task) {
+			all = append(all, task)
+		}
+	}
+	return all
 }
 
 // Delete deletes an existing task
 func (r *TasksRepository) Delete(
-	c context.Context,
+	ctx context.Context,
 	id string,
 	sagaID *string,
 	version uint64,
 ) (*tasks.TaskEvent, error) {
-	return nil, gorr.NewNotImplemented()
+	var res *tasks.TaskEvent
+	err := r.store.write(ctx, func(st *state) error {
+		if _, ok := st.tasks[id]; !ok {
+			return domcom.NewTaskMissingError()
+		}
+		evnt, err := st.insertEvent(
+			sagaID,
+			domcom.TaskStreamName,
+			id,
+			version,
+			domcom.EventDeleted,
+			tasks.TaskData{},
+		)
+		if err != nil {
+			return err
+		}
+		res = toTaskEvent(evnt)
+		delete(st.tasks, id)
+		for key := range st.blockers {
+			if key.taskID == id || key.blockerID == id {
+				delete(st.blockers, key)
+			}
+		}
+		return nil
+	})
+	return res, err
 }
 
 // Update updates an existing task
 func (r *TasksRepository) Update(
-	c context.Context,
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	data tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(ctx, id, sagaID, version, domcom.EventUpdated, data)
+}
+
+// Compensate restores an existing task to the given data
+func (r *TasksRepository) Compensate(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	data tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	return r.update(ctx, id, sagaID, version, domcom.EventCompensated, data)
+}
+
+// Transition writes the event of a workflow transition moving an existing
+// task to the status
+func (r *TasksRepository) Transition(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	status string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		event,
+		tasks.TaskData{Status: &status},
+	)
+}
+
+// Assign writes an event assigning an existing task to a user
+func (r *TasksRepository) Assign(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	assignee string,
+	access *tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventAssigned,
+		tasks.TaskData{Assignee: &assignee, Access: access},
+	)
+}
+
+// Move writes an event moving an existing task under another task
+func (r *TasksRepository) Move(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	parentID string,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventMoved,
+		tasks.TaskData{ParentID: &parentID},
+	)
+}
+
+// LockHierarchy serializes the changes to the task hierarchy, only a single
+// transaction can write at a time so beginning the transaction of the context
+// is enough
+func (r *TasksRepository) LockHierarchy(ctx context.Context) error {
+	return r.lock(ctx)
+}
+
+// LockDependencies serializes the changes to the task dependencies the same
+// way the hierarchy is
+func (r *TasksRepository) LockDependencies(ctx context.Context) error {
+	return r.lock(ctx)
+}
+
+// lock begins the transaction of the context, waiting for the transaction
+// that is writing to end
+func (r *TasksRepository) lock(ctx context.Context) error {
+	return r.store.write(ctx, func(st *state) error {
+		return nil
+	})
+}
+
+// AddDependency writes an event blocking an existing task by another task
+func (r *TasksRepository) AddDependency(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	blockerID string,
+) (*tasks.TaskEvent, error) {
+	res, err := r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyAdded,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = r.store.write(ctx, func(st *state) error {
+		st.blockers[dependencyKey{id, blockerID}] = struct{}{}
+		return nil
+	})
+	return res, err
+}
+
+// RemoveDependency writes an event unblocking an existing task from another
+// task
+func (r *TasksRepository) RemoveDependency(
+	ctx context.Context,
 	id string,
 	sagaID *string,
 	version uint64,
-	dat tasks.TaskData,
+	blockerID string,
 ) (*tasks.TaskEvent, error) {
-	return nil, gorr.NewNotImplemented()
+	res, err := r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventDependencyRemoved,
+		tasks.TaskData{BlockerID: &blockerID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = r.store.write(ctx, func(st *state) error {
+		delete(st.blockers, dependencyKey{id, blockerID})
+		return nil
+	})
+	return res, err
+}
+
+// ListBlockers lists the ids of the tasks blocking a task ordered by id
+func (r *TasksRepository) ListBlockers(
+	ctx context.Context,
+	id string,
+) ([]string, error) {
+	ids := []string{}
+	err := r.store.read(ctx, func(st *state) error {
+		for key := range st.blockers {
+			if key.taskID == id {
+				ids = append(ids, key.blockerID)
+			}
+		}
+		return nil
+	})
+	sort.Strings(ids)
+	return ids, err
+}
+
+// ListBlocked lists the ids of the tasks blocked by a task ordered by id
+func (r *TasksRepository) ListBlocked(
+	ctx context.Context,
+	id string,
+) ([]string, error) {
+	ids := []string{}
+	err := r.store.read(ctx, func(st *state) error {
+		for key := range st.blockers {
+			if key.blockerID == id {
+				ids = append(ids, key.taskID)
+			}
+		}
+		return nil
+	})
+	sort.Strings(ids)
+	return ids, err
+}
+
+// Share writes an event granting a user access to an existing task
+func (r *TasksRepository) Share(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventShared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// Unshare writes an event revoking the access of a user to an existing task
+func (r *TasksRepository) Unshare(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	access tasks.Access,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		sagaID,
+		version,
+		domcom.EventUnshared,
+		tasks.TaskData{Access: &access},
+	)
+}
+
+// FireSchedule writes the event of a schedule of an existing task
+func (r *TasksRepository) FireSchedule(
+	ctx context.Context,
+	id string,
+	version uint64,
+	event string,
+	dueDate time.Time,
+) (*tasks.TaskEvent, error) {
+	return r.update(
+		ctx,
+		id,
+		nil,
+		version,
+		event,
+		tasks.TaskData{DueDate: &dueDate},
+	)
+}
+
+// SetSchedules replaces the schedules of a task
+func (r *TasksRepository) SetSchedules(
+	ctx context.Context,
+	id string,
+	schedules []tasks.Schedule,
+) error {
+	return r.store.write(ctx, func(st *state) error {
+		for key := range st.schedules {
+			if key.taskID == id {
+				delete(st.schedules, key)
+			}
+		}
+		for _, schd := range schedules {
+			st.schedules[scheduleKey{id, schd.Event}] = schd.FireAt
+		}
+		return nil
+	})
+}
+
+// ClaimDueSchedule removes and gives the earliest due schedule, transactions
+// write one at a time so a schedule can't be claimed twice
+func (r *TasksRepository) ClaimDueSchedule(
+	ctx context.Context,
+	now time.Time,
+) (*tasks.Schedule, error) {
+	var res *tasks.Schedule
+	err := r.store.write(ctx, func(st *state) error {
+		for key, at := range st.schedules {
+			if at.After(now) {
+				continue
+			}
+			if res == nil || at.Before(res.FireAt) ||
+				(at.Equal(res.FireAt) && key.taskID < res.TaskID) {
+				res = &tasks.Schedule{
+					TaskID: key.taskID,
+					Event:  key.event,
+					FireAt: at,
+				}
+			}
+		}
+		if res != nil {
+			delete(st.schedules, scheduleKey{res.TaskID, res.Event})
+		}
+		return nil
+	})
+	return res, err
+}
+
+// update writes an event changing the fields of the task that are set
+func (r *TasksRepository) update(
+	ctx context.Context,
+	id string,
+	sagaID *string,
+	version uint64,
+	event string,
+	data tasks.TaskData,
+) (*tasks.TaskEvent, error) {
+	if data.Title == nil &&
+		data.Description == nil &&
+		data.Status == nil &&
+		data.RandomMap == nil &&
+		data.Metadata == nil &&
+		data.Access == nil &&
+		data.Assignee == nil &&
+		data.DueDate == nil &&
+		data.ParentID == nil &&
+		data.BlockerID == nil {
+		return nil, domcom.NewNoTaskUpdatesError()
+	}
+
+	var res *tasks.TaskEvent
+	err := r.store.write(ctx, func(st *state) error {
+		task, ok := st.tasks[id]
+		if !ok {
+			return domcom.NewTaskMissingError()
+		}
+		evnt, err := st.insertEvent(
+			sagaID,
+			domcom.TaskStreamName,
+			id,
+			version,
+			event,
+			data,
+		)
+		if err != nil {
+			return err
+		}
+		res = toTaskEvent(evnt)
+		task.Apply(res)
+		st.tasks[id] = task
+		return nil
+	})
+	return res, err
+}
+
+// ListEvents gives a paged list of events of a task ordered by version
+func (r *TasksRepository) ListEvents(
+	ctx context.Context,
+	id string,
+	countPerPage int,
+	pageNumber int,
+) ([]tasks.TaskEvent, error) {
+	var res []tasks.TaskEvent
+	err := r.store.read(ctx, func(st *state) error {
+		evnts := st.streamEvents(domcom.TaskStreamName, id)
+		start, end := page(len(evnts), countPerPage, pageNumber)
+		res = toTaskEvents(evnts[start:end])
+		return nil
+	})
+	return res, err
+}
+
+// ListEventsAfter lists the events of all tasks after an event id
+func (r *TasksRepository) ListEventsAfter(
+	ctx context.Context,
+	afterID uint64,
+	count int,
+) ([]tasks.TaskEvent, error) {
+	var res []tasks.TaskEvent
+	err := r.store.read(ctx, func(st *state) error {
+		res = []tasks.TaskEvent{}
+		for idx := st.eventsAfter(afterID); idx < len(st.events) &&
+			len(res) < count; idx++ {
+			if st.events[idx].Stream == domcom.TaskStreamName {
+				res = append(res, *toTaskEvent(&st.events[idx]))
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+// GetSnapshot the in memory implementation doesn't take snapshots, the tasks
+// are always folded from the start of the stream
+func (r *TasksRepository) GetSnapshot(
+	ctx context.Context,
+	id string,
+	version *uint64,
+	eventTime *time.Time,
+) (*tasks.Task, error) {
+	return nil, nil
+}
+
+// ListEventsUntil lists the events of a task ordered by version starting from
+// a version up to and including the given version or event time
+func (r *TasksRepository) ListEventsUntil(
+	ctx context.Context,
+	id string,
+	fromVersion uint64,
+	version *uint64,
+	eventTime *time.Time,
+) ([]tasks.TaskEvent, error) {
+	var res []tasks.TaskEvent
+	err := r.store.read(ctx, func(st *state) error {
+		res = []tasks.TaskEvent{}
+		for _, evnt := range st.streamEvents(domcom.TaskStreamName, id) {
+			if evnt.Version < fromVersion ||
+				(version != nil && evnt.Version > *version) ||
+				(eventTime != nil && evnt.EventTime.After(*eventTime)) {
+				continue
+			}
+			res = append(res, *toTaskEvent(&evnt))
+		}
+		return nil
+	})
+	return res, err
+}
+
+func toTaskEvent(evnt *storedEvent) *tasks.TaskEvent {
+	data, _ := evnt.data.(tasks.TaskData)
+	return &tasks.TaskEvent{
+		EventEntity: evnt.EventEntity,
+		Data:        data,
+	}
+}
+
+func toTaskEvents(evnts []storedEvent) []tasks.TaskEvent {
+	res := make([]tasks.TaskEvent, len(evnts))
+	for idx := range evnts {
+		res[idx] = *toTaskEvent(&evnts[idx])
+	}
+	return res
 }
@@ -3,33 +3,74 @@ package repos
 import (
 	"context"
 	"techunicorn.com/udc-core/prototodo/pkg/domain/base/uniques"
-
-	"github.com/betalixt/gorr"
+	domcom "techunicorn.com/udc-core/prototodo/pkg/domain/common"
 )
 
-type UniquesRepository struct{}
+type UniquesRepository struct {
+	store *MemoryStore
+}
 
 var _ uniques.IRepository = (*UniquesRepository)(nil)
 
-func NewUniquesRepository() *UniquesRepository {
-	return &UniquesRepository{}
+func NewUniquesRepository(
+	store *MemoryStore,
+) *UniquesRepository {
+	return &UniquesRepository{
+		store: store,
+	}
 }
 
 func (r *UniquesRepository) RegisterConstraint(
-	c context.Context,
+	ctx context.Context,
 	stream string,
 	streamId string,
 	sagaId *string,
 	property string,
 	value string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		key := uniqueKey{stream, property, value}
+		if _, ok := st.uniques[key]; ok {
+			return domcom.NewUniqueConstraintViolationError(property)
+		}
+		err := st.checkSaga(sagaId)
+		if err != nil {
+			return err
+		}
+		st.uniques[key] = unique{
+			streamID: streamId,
+			sagaID:   copyString(sagaId),
+		}
+		st.recordSagaStep(sagaId, 0)
+		return nil
+	})
 }
 
 func (r *UniquesRepository) RemoveConstraint(
-	c context.Context,
+	ctx context.Context,
 	stream string,
 	streamId string,
 ) error {
-	return gorr.NewNotImplemented()
+	return r.store.write(ctx, func(st *state) error {
+		for key, unq := range st.uniques {
+			if key.stream == stream && unq.streamID == streamId {
+				delete(st.uniques, key)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *UniquesRepository) RemoveSagaConstraints(
+	ctx context.Context,
+	sagaId string,
+) error {
+	return r.store.write(ctx, func(st *state) error {
+		for key, unq := range st.uniques {
+			if unq.sagaID != nil && *unq.sagaID == sagaId {
+				delete(st.uniques, key)
+			}
+		}
+		return nil
+	})
 }
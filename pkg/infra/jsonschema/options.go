@@ -0,0 +1,10 @@
+package jsonschema
+
+import "encoding/json"
+
+// Options options for the metadata validator
+type Options struct {
+	// Schemas the json schema the metadata of each user type is validated
+	// against, the metadata of user types without a schema isn't validated
+	Schemas map[string]json.RawMessage
+}
@@ -0,0 +1,363 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// schema a compiled json schema, only the validation keywords that make sense
+// for metadata are supported. Annotations are ignored and any other keyword is
+// rejected so a schema is never partially enforced
+type schema struct {
+	// never set for the false schema which rejects every value
+	never bool
+
+	types    []string
+	enum     []interface{}
+	constant []interface{}
+
+	properties map[string]*schema
+	required   []string
+	additional *schema
+	minProps   *int
+	maxProps   *int
+
+	items    *schema
+	minItems *int
+	maxItems *int
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum *float64
+	maximum *float64
+	exclMin *float64
+	exclMax *float64
+}
+
+// knownTypes the types a schema can require
+var knownTypes = map[string]struct{}{
+	"null":    {},
+	"boolean": {},
+	"object":  {},
+	"array":   {},
+	"number":  {},
+	"integer": {},
+	"string":  {},
+}
+
+// annotations the keywords that only describe the schema, they're accepted
+// but don't affect validation
+var annotations = map[string]struct{}{
+	"$schema":     {},
+	"$id":         {},
+	"$comment":    {},
+	"title":       {},
+	"description": {},
+	"default":     {},
+	"examples":    {},
+	"deprecated":  {},
+	"readOnly":    {},
+	"writeOnly":   {},
+}
+
+// compile parses the raw json schema, keywords that aren't supported such as
+// $ref, the combinators or format fail the compilation
+func compile(raw json.RawMessage) (*schema, error) {
+	var always bool
+	if json.Unmarshal(raw, &always) == nil {
+		return &schema{never: !always}, nil
+	}
+	kwds := map[string]json.RawMessage{}
+	err := json.Unmarshal(raw, &kwds)
+	if err != nil {
+		return nil, errors.New("schema must be an object or a boolean")
+	}
+
+	s := &schema{}
+	for kwd, val := range kwds {
+		switch kwd {
+		case "type":
+			s.types, err = compileTypes(val)
+		case "enum":
+			err = json.Unmarshal(val, &s.enum)
+			if err == nil && len(s.enum) == 0 {
+				err = errors.New("must have at least one value")
+			}
+		case "const":
+			var cnst interface{}
+			err = json.Unmarshal(val, &cnst)
+			s.constant = []interface{}{cnst}
+		case "properties":
+			s.properties, err = compileProperties(val)
+		case "required":
+			err = json.Unmarshal(val, &s.required)
+		case "additionalProperties":
+			s.additional, err = compile(val)
+		case "minProperties":
+			s.minProps, err = compileCount(val)
+		case "maxProperties":
+			s.maxProps, err = compileCount(val)
+		case "items":
+			s.items, err = compile(val)
+		case "minItems":
+			s.minItems, err = compileCount(val)
+		case "maxItems":
+			s.maxItems, err = compileCount(val)
+		case "minLength":
+			s.minLength, err = compileCount(val)
+		case "maxLength":
+			s.maxLength, err = compileCount(val)
+		case "pattern":
+			var ptrn string
+			err = json.Unmarshal(val, &ptrn)
+			if err == nil {
+				s.pattern, err = regexp.Compile(ptrn)
+			}
+		case "minimum":
+			s.minimum, err = compileNumber(val)
+		case "maximum":
+			s.maximum, err = compileNumber(val)
+		case "exclusiveMinimum":
+			s.exclMin, err = compileNumber(val)
+		case "exclusiveMaximum":
+			s.exclMax, err = compileNumber(val)
+		default:
+			if _, ok := annotations[kwd]; !ok {
+				return nil, errors.New("unsupported keyword " + kwd)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", kwd, err)
+		}
+	}
+	return s, nil
+}
+
+func compileTypes(raw json.RawMessage) ([]string, error) {
+	var types []string
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		types = []string{single}
+	} else if err := json.Unmarshal(raw, &types); err != nil {
+		return nil, errors.New("must be a string or an array of strings")
+	}
+	for _, t := range types {
+		if _, ok := knownTypes[t]; !ok {
+			return nil, errors.New("unknown type " + t)
+		}
+	}
+	return types, nil
+}
+
+func compileProperties(raw json.RawMessage) (map[string]*schema, error) {
+	props := map[string]json.RawMessage{}
+	err := json.Unmarshal(raw, &props)
+	if err != nil {
+		return nil, errors.New("must be an object")
+	}
+	res := make(map[string]*schema, len(props))
+	for name, prop := range props {
+		res[name], err = compile(prop)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+	}
+	return res, nil
+}
+
+func compileCount(raw json.RawMessage) (*int, error) {
+	var cnt int
+	err := json.Unmarshal(raw, &cnt)
+	if err != nil || cnt < 0 {
+		return nil, errors.New("must be a non negative integer")
+	}
+	return &cnt, nil
+}
+
+func compileNumber(raw json.RawMessage) (*float64, error) {
+	var num float64
+	err := json.Unmarshal(raw, &num)
+	if err != nil {
+		return nil, errors.New("must be a number")
+	}
+	return &num, nil
+}
+
+// validate checks the value against the schema, the message describes the
+// first violation found and is empty if the value is valid. Values are the
+// generic values json is decoded to
+func (s *schema) validate(path string, val interface{}) string {
+	if s.never {
+		return path + " isn't allowed"
+	}
+	if len(s.types) != 0 && !s.hasType(val) {
+		return path + " must be of type " + strings.Join(s.types, " or ")
+	}
+	if s.enum != nil && !contains(s.enum, val) {
+		return path + " must be one of the allowed values"
+	}
+	if s.constant != nil && !contains(s.constant, val) {
+		return path + " must be the allowed value"
+	}
+
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return s.validateObject(path, v)
+	case []interface{}:
+		return s.validateArray(path, v)
+	case string:
+		return s.validateString(path, v)
+	case float64:
+		return s.validateNumber(path, v)
+	}
+	return ""
+}
+
+func (s *schema) hasType(val interface{}) bool {
+	for _, t := range s.types {
+		switch v := val.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *schema) validateObject(path string, obj map[string]interface{}) string {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			return propertyPath(path, name) + " is required"
+		}
+	}
+	if s.minProps != nil && len(obj) < *s.minProps {
+		return path + " must have at least " +
+			strconv.Itoa(*s.minProps) + " properties"
+	}
+	if s.maxProps != nil && len(obj) > *s.maxProps {
+		return path + " must have at most " +
+			strconv.Itoa(*s.maxProps) + " properties"
+	}
+
+	// sorted so the same violation is reported every time
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := s.properties[name]
+		if !ok {
+			prop = s.additional
+		}
+		if prop == nil {
+			continue
+		}
+		msg := prop.validate(propertyPath(path, name), obj[name])
+		if msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+func (s *schema) validateArray(path string, arr []interface{}) string {
+	if s.minItems != nil && len(arr) < *s.minItems {
+		return path + " must have at least " +
+			strconv.Itoa(*s.minItems) + " items"
+	}
+	if s.maxItems != nil && len(arr) > *s.maxItems {
+		return path + " must have at most " +
+			strconv.Itoa(*s.maxItems) + " items"
+	}
+	if s.items == nil {
+		return ""
+	}
+	for idx, item := range arr {
+		msg := s.items.validate(path+"["+strconv.Itoa(idx)+"]", item)
+		if msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+func (s *schema) validateString(path string, str string) string {
+	cnt := utf8.RuneCountInString(str)
+	if s.minLength != nil && cnt < *s.minLength {
+		return path + " must be at least " +
+			strconv.Itoa(*s.minLength) + " characters"
+	}
+	if s.maxLength != nil && cnt > *s.maxLength {
+		return path + " must be at most " +
+			strconv.Itoa(*s.maxLength) + " characters"
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return path + " must match " + s.pattern.String()
+	}
+	return ""
+}
+
+func (s *schema) validateNumber(path string, num float64) string {
+	if s.minimum != nil && num < *s.minimum {
+		return path + " must be at least " + formatNumber(*s.minimum)
+	}
+	if s.maximum != nil && num > *s.maximum {
+		return path + " must be at most " + formatNumber(*s.maximum)
+	}
+	if s.exclMin != nil && num <= *s.exclMin {
+		return path + " must be greater than " + formatNumber(*s.exclMin)
+	}
+	if s.exclMax != nil && num >= *s.exclMax {
+		return path + " must be less than " + formatNumber(*s.exclMax)
+	}
+	return ""
+}
+
+func contains(vals []interface{}, val interface{}) bool {
+	for _, v := range vals {
+		if reflect.DeepEqual(v, val) {
+			return true
+		}
+	}
+	return false
+}
+
+func propertyPath(path string, name string) string {
+	return path + "." + name
+}
+
+func formatNumber(num float64) string {
+	return strconv.FormatFloat(num, 'f', -1, 64)
+}
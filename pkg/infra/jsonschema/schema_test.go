@@ -0,0 +1,220 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+)
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{name: "true", schema: `true`},
+		{name: "false", schema: `false`},
+		{name: "empty", schema: `{}`},
+		{
+			name: "annotations",
+			schema: `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+				`"$id":"metadata","$comment":"c","title":"t","description":"d",` +
+				`"default":{},"examples":[{}],"deprecated":false,` +
+				`"readOnly":false,"writeOnly":false}`,
+		},
+		{
+			name: "supported",
+			schema: `{"type":"object","required":["a"],"minProperties":1,` +
+				`"maxProperties":3,"additionalProperties":false,"properties":{` +
+				`"a":{"type":["string","null"],"minLength":1,"maxLength":5,` +
+				`"pattern":"^[a-z]+$"},` +
+				`"b":{"type":"array","items":{"enum":[1,2]},"minItems":1,` +
+				`"maxItems":2},` +
+				`"c":{"type":"number","minimum":0,"maximum":10,` +
+				`"exclusiveMinimum":-1,"exclusiveMaximum":11,"const":5}}}`,
+		},
+		{name: "not a schema", schema: `1`, err: "must be an object"},
+		{name: "ref", schema: `{"$ref":"#/$defs/a"}`, err: "unsupported keyword $ref"},
+		{name: "defs", schema: `{"$defs":{}}`, err: "unsupported keyword $defs"},
+		{name: "all of", schema: `{"allOf":[true]}`, err: "unsupported keyword allOf"},
+		{name: "any of", schema: `{"anyOf":[true]}`, err: "unsupported keyword anyOf"},
+		{name: "one of", schema: `{"oneOf":[true]}`, err: "unsupported keyword oneOf"},
+		{name: "not", schema: `{"not":false}`, err: "unsupported keyword not"},
+		{name: "format", schema: `{"format":"email"}`, err: "unsupported keyword format"},
+		{name: "unknown", schema: `{"maxLenght":1}`, err: "unsupported keyword maxLenght"},
+		{
+			name:   "nested unsupported",
+			schema: `{"properties":{"a":{"items":{"format":"date"}}}}`,
+			err:    "unsupported keyword format",
+		},
+		{name: "unknown type", schema: `{"type":"int"}`, err: "unknown type int"},
+		{name: "empty enum", schema: `{"enum":[]}`, err: "at least one value"},
+		{name: "negative count", schema: `{"minItems":-1}`, err: "non negative"},
+		{name: "bad pattern", schema: `{"pattern":"("}`, err: "invalid pattern"},
+		{name: "tuple items", schema: `{"items":[true]}`, err: "invalid items"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := compile(json.RawMessage(c.schema))
+			if c.err == "" && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatalf("expected error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestNewValidatorRejectsUnsupported(t *testing.T) {
+	_, err := NewValidator(&Options{Schemas: map[string]json.RawMessage{
+		"user": json.RawMessage(`{"properties":{"a":{"oneOf":[true]}}}`),
+	}})
+	if err == nil || !strings.Contains(err.Error(), "user") {
+		t.Fatalf("expected schema of user to be rejected, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["ref"],
+		"additionalProperties": false,
+		"maxProperties": 4,
+		"properties": {
+			"ref": {"type": "string", "minLength": 2, "maxLength": 4,
+				"pattern": "^[a-z]+$"},
+			"count": {"type": "integer", "minimum": 1, "exclusiveMaximum": 10},
+			"ratio": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+			"tags": {"type": "array", "minItems": 1, "maxItems": 2,
+				"items": {"enum": ["a", "b"]}},
+			"kind": {"const": "task"},
+			"extra": {"type": ["object", "null"], "minProperties": 1}
+		}
+	}`
+	v, err := NewValidator(&Options{Schemas: map[string]json.RawMessage{
+		"user": json.RawMessage(schema),
+	}})
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		userType string
+		metadata string
+		msg      string
+	}{
+		{name: "valid", metadata: `{"ref":"abc","count":9,"tags":["a"]}`},
+		{name: "no schema", userType: "app", metadata: `{"any":1}`},
+		{name: "null allowed", metadata: `{"ref":"ab","extra":null}`},
+		{name: "missing required", metadata: `{}`, msg: "metadata.ref is required"},
+		{
+			name:     "additional property",
+			metadata: `{"ref":"ab","other":1}`,
+			msg:      "metadata.other isn't allowed",
+		},
+		{
+			name:     "too many properties",
+			metadata: `{"ref":"ab","count":1,"ratio":1,"kind":"task","tags":["a"]}`,
+			msg:      "metadata must have at most 4 properties",
+		},
+		{
+			name:     "wrong type",
+			metadata: `{"ref":1}`,
+			msg:      "metadata.ref must be of type string",
+		},
+		{
+			name:     "too short",
+			metadata: `{"ref":"a"}`,
+			msg:      "metadata.ref must be at least 2 characters",
+		},
+		{
+			name:     "too long",
+			metadata: `{"ref":"abcde"}`,
+			msg:      "metadata.ref must be at most 4 characters",
+		},
+		{
+			name:     "pattern",
+			metadata: `{"ref":"AB"}`,
+			msg:      "metadata.ref must match ^[a-z]+$",
+		},
+		{
+			name:     "not an integer",
+			metadata: `{"ref":"ab","count":1.5}`,
+			msg:      "metadata.count must be of type integer",
+		},
+		{
+			name:     "below minimum",
+			metadata: `{"ref":"ab","count":0}`,
+			msg:      "metadata.count must be at least 1",
+		},
+		{
+			name:     "exclusive maximum",
+			metadata: `{"ref":"ab","count":10}`,
+			msg:      "metadata.count must be less than 10",
+		},
+		{
+			name:     "exclusive minimum",
+			metadata: `{"ref":"ab","ratio":0}`,
+			msg:      "metadata.ratio must be greater than 0",
+		},
+		{
+			name:     "above maximum",
+			metadata: `{"ref":"ab","ratio":1.5}`,
+			msg:      "metadata.ratio must be at most 1",
+		},
+		{
+			name:     "too few items",
+			metadata: `{"ref":"ab","tags":[]}`,
+			msg:      "metadata.tags must have at least 1 items",
+		},
+		{
+			name:     "too many items",
+			metadata: `{"ref":"ab","tags":["a","b","a"]}`,
+			msg:      "metadata.tags must have at most 2 items",
+		},
+		{
+			name:     "item not in enum",
+			metadata: `{"ref":"ab","tags":["c"]}`,
+			msg:      "metadata.tags[0] must be one of the allowed values",
+		},
+		{
+			name:     "const",
+			metadata: `{"ref":"ab","kind":"note"}`,
+			msg:      "metadata.kind must be the allowed value",
+		},
+		{
+			name:     "too few properties",
+			metadata: `{"ref":"ab","extra":{}}`,
+			msg:      "metadata.extra must have at least 1 properties",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			metadata := map[string]interface{}{}
+			err := json.Unmarshal([]byte(c.metadata), &metadata)
+			if err != nil {
+				t.Fatalf("invalid metadata: %v", err)
+			}
+			userType := c.userType
+			if userType == "" {
+				userType = "user"
+			}
+
+			err = v.Validate(userType, metadata)
+			if c.msg == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if !common.IsError(err, common.InvalidTaskMetadataErrorCode) ||
+				!strings.Contains(err.Error(), c.msg) {
+				t.Fatalf("expected error %q, got %v", c.msg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package jsonschema validates task metadata against json schemas configured
+// per user type
+package jsonschema
+
+import (
+	"fmt"
+
+	"techunicorn.com/udc-core/prototodo/pkg/domain/common"
+	"techunicorn.com/udc-core/prototodo/pkg/domain/domains/tasks"
+)
+
+// Validator validates metadata against the schema of the user type setting it
+type Validator struct {
+	schemas map[string]*schema
+}
+
+var _ tasks.IMetadataValidator = (*Validator)(nil)
+
+// NewValidator constructs a new validator, the schemas are compiled up front
+// so an invalid schema fails the startup instead of the requests
+func NewValidator(optn *Options) (*Validator, error) {
+	v := &Validator{
+		schemas: make(map[string]*schema, len(optn.Schemas)),
+	}
+	for userType, raw := range optn.Schemas {
+		s, err := compile(raw)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid metadata schema for user type %s: %w",
+				userType,
+				err,
+			)
+		}
+		v.schemas[userType] = s
+	}
+	return v, nil
+}
+
+// Validate checks the metadata against the schema of the user type
+func (v *Validator) Validate(
+	userType string,
+	metadata map[string]interface{},
+) error {
+	s, ok := v.schemas[userType]
+	if !ok {
+		return nil
+	}
+	// the metadata is always an object so it's checked as a generic value
+	var val interface{} = metadata
+	if metadata == nil {
+		val = map[string]interface{}{}
+	}
+	msg := s.validate("metadata", val)
+	if msg != "" {
+		return common.NewInvalidTaskMetadataError(msg)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package sqlitedb embedded SQLite database context and migrations
+package sqlitedb
+
+import (
+	"fmt"
+
+	"github.com/BetaLixT/tsqlx"
+	"github.com/jmoiron/sqlx"
+
+	// blank import to load sqlite drivers
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// NewDatabaseContext opens the SQLite database file, transactions take the
+// write lock as soon as they begin so that concurrent writers wait on each
+// other instead of failing when upgrading their locks
+func NewDatabaseContext(
+	tracer tsqlx.ITracer,
+	optn *DatabaseOptions,
+) (*tsqlx.TracedDB, error) {
+	db, err := sqlx.Open(
+		"sqlite3",
+		fmt.Sprintf(
+			"file:%s?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate",
+			optn.Path,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+
+	return tsqlx.NewTracedDB(
+		db,
+		tracer,
+		optn.DatabaseServiceName,
+	), nil
+}
@@ -0,0 +1,90 @@
+package sqlitedb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"techunicorn.com/udc-core/prototodo/pkg/infra/psqldb"
+
+	"github.com/BetaLixT/tsqlx"
+	"go.uber.org/zap"
+)
+
+// RunMigrations runs the migrations that haven't been run yet in order, the
+// scripts are in the same format as the postgres migrations but are expected
+// to be written in the SQLite dialect
+func RunMigrations(
+	ctx context.Context,
+	lgr *zap.Logger,
+	db *tsqlx.TracedDB,
+	migrations []psqldb.MigrationScript,
+) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(ctx, migrationTable.Up)
+	if err != nil {
+		lgr.Error("failed to create migration table", zap.Error(err))
+		return err
+	}
+
+	var exMigrs []migrationEntity
+	err = tx.Select(ctx, &exMigrs, GetAllMigrations)
+	if err != nil {
+		lgr.Error("failed to fetch migrations", zap.Error(err))
+		return err
+	}
+
+	for idx, migr := range migrations {
+		if idx < len(exMigrs) {
+			if migr.Key != exMigrs[idx].Key {
+				return fmt.Errorf("migration key missmatch")
+			}
+			continue
+		}
+		lgr.Info("Running migration", zap.String("migration", migr.Key))
+		_, err = tx.Exec(ctx, migr.Up)
+		if err != nil {
+			lgr.Error(
+				"failed to run migration",
+				zap.String("migration", migr.Key),
+				zap.Error(err),
+			)
+			return err
+		}
+		_, err = tx.Exec(ctx, AddMigration, migr.Key, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+type migrationEntity struct {
+	Index           int       `db:"idx"`
+	Key             string    `db:"key"`
+	DateTimeCreated time.Time `db:"date_time_created"`
+}
+
+var migrationTable = psqldb.MigrationScript{
+	Up: `
+		CREATE TABLE IF NOT EXISTS migrations (
+			idx INTEGER PRIMARY KEY AUTOINCREMENT,
+			key text NOT NULL UNIQUE,
+			date_time_created timestamp NOT NULL
+		);`,
+	Down: `
+		DROP TABLE migrations;`,
+}
+
+const (
+	GetAllMigrations = `
+		SELECT * FROM migrations ORDER BY idx`
+
+	AddMigration = `
+		INSERT INTO migrations (key, date_time_created) VALUES (?1, ?2)`
+)
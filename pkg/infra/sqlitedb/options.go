@@ -0,0 +1,6 @@
+package sqlitedb
+
+type DatabaseOptions struct {
+	Path                string
+	DatabaseServiceName string
+}